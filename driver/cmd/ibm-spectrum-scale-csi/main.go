@@ -21,11 +21,16 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"os/signal"
 	"path"
 	"path/filepath"
 	"strings"
+	"syscall"
+	"time"
 
 	driver "github.com/IBM/ibm-spectrum-scale-csi/driver/csiplugin"
+	"github.com/IBM/ibm-spectrum-scale-csi/driver/csiplugin/pkg/audit"
+	"github.com/IBM/ibm-spectrum-scale-csi/driver/csiplugin/pkg/supportbundle"
 	"github.com/IBM/ibm-spectrum-scale-csi/driver/csiplugin/settings"
 	"github.com/IBM/ibm-spectrum-scale-csi/driver/csiplugin/utils"
 	"github.com/natefinch/lumberjack"
@@ -42,6 +47,14 @@ var (
 	driverName     = flag.String("drivername", "spectrumscale.csi.ibm.com", "name of the driver")
 	nodeID         = flag.String("nodeid", "", "node id")
 	kubeletRootDir = flag.String("kubeletRootDirPath", "/var/lib/kubelet", "kubelet root directory path")
+	tlsCertFile    = flag.String("tlsCertFile", "", "TLS certificate file of a tcp endpoint, reloaded when modified")
+	tlsKeyFile     = flag.String("tlsKeyFile", "", "TLS private key file of a tcp endpoint, reloaded when modified")
+	tlsClientCA    = flag.String("tlsClientCAFile", "", "CA file to verify client certificates of a tcp endpoint with, enables mutual TLS")
+	httpEndpoint   = flag.String("httpEndpoint", "", "address of the HTTP server serving /healthz and /readyz, e.g. :8080, disabled if empty")
+	drainTimeout   = flag.Duration("shutdownTimeout", 25*time.Second, "time to wait for requests and jobs in progress on SIGTERM or SIGINT, must be below the termination grace period of the pod")
+	persistentLogs = flag.String("servePersistentLogs", "", "serve the persistent logs of the node as archive on this address for a support bundle instead of running the driver")
+	auditLogFile   = flag.String("auditLogFile", "", "file to write an audit record of every mutating IBM Storage Scale call to as JSON lines, rotated by size, disabled if empty")
+	auditWebhook   = flag.String("auditWebhookURL", "", "URL to post every audit record to as JSON, disabled if empty")
 	vendorVersion  = "3.1.0"
 )
 
@@ -62,14 +75,34 @@ func main() {
 	err2 := flag.Set("v", value)
 	flag.Parse()
 
+	if flag.Arg(0) == validateConfigCommand {
+		os.Exit(validateConfig(flag.Args()[1:], os.Stdout, os.Stderr))
+	}
+
+	// the support bundle collector pods run the driver image to serve the
+	// persistent logs of their node
+	if *persistentLogs != "" {
+		if err := supportbundle.ServePersistentLogs(*persistentLogs, path.Join(settings.HostPath, settings.DirPath)); err != nil {
+			fmt.Fprintf(os.Stderr, "unable to serve persistent logs: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	defer func() {
 		if r := recover(); r != nil {
 			klog.Infof("Recovered from panic: [%v]", r)
 		}
 	}()
+	// os.Exit does not run deferred functions, the logs are flushed by
+	// flushLogs before exiting
+	fpClose := func() {}
 	if persistentLogEnabled == "ENABLED" {
-		fpClose := InitFileLogger()
-		defer fpClose()
+		fpClose = InitFileLogger()
+	}
+	flushLogs := func() {
+		klog.Flush()
+		fpClose()
 	}
 
 	ctx := setContext()
@@ -85,28 +118,64 @@ func main() {
 
 	if err := createPersistentStorage(path.Join(PluginFolder, "controller")); err != nil {
 		klog.Errorf("[%s] failed to create persistent storage for controller %v", loggerId, err)
+		flushLogs()
 		os.Exit(1)
 	}
 	if err := createPersistentStorage(path.Join(PluginFolder, "node")); err != nil {
 		klog.Errorf("[%s] failed to create persistent storage for node %v", loggerId, err)
+		flushLogs()
 		os.Exit(1)
 	}
 
-	defer klog.Flush()
-	handle(ctx)
+	handle(ctx, path.Join(PluginFolder, "controller"))
+	klog.Infof("[%s] IBM Storage Scale CSI driver stopped", loggerId)
+	flushLogs()
 	os.Exit(0)
 }
 
-func handle(ctx context.Context) {
+func handle(ctx context.Context, stateDir string) {
+	// SIGTERM is sent by the kubelet when the pod is deleted, e.g. during
+	// a rolling upgrade
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, syscall.SIGTERM, syscall.SIGINT)
+	defer signal.Stop(stop)
+	stopping := make(chan struct{})
+	go func() {
+		sig := <-stop
+		klog.Infof("[%s] received signal %v", utils.GetLoggerId(ctx), sig)
+		close(stopping)
+	}()
+
 	loggerId := utils.GetLoggerId(ctx)
+	tlsFiles := driver.TLSFiles{CertFile: *tlsCertFile, KeyFile: *tlsKeyFile, ClientCAFile: *tlsClientCA}
+	if tlsFiles.Enabled() {
+		if err := tlsFiles.Validate(); err != nil {
+			klog.Fatalf("[%s] Invalid TLS configuration: %v", loggerId, err)
+		}
+	}
+	if *auditLogFile != "" || *auditWebhook != "" {
+		auditLogger, err := audit.NewLogger(*auditLogFile, *auditWebhook)
+		if err != nil {
+			klog.Fatalf("[%s] Invalid audit configuration: %v", loggerId, err)
+		}
+		audit.SetLogger(auditLogger)
+		defer func() {
+			if err := auditLogger.Close(); err != nil {
+				klog.Errorf("[%s] %v", loggerId, err)
+			}
+		}()
+	}
 	driver := driver.GetScaleDriver(ctx)
-	err := driver.SetupScaleDriver(ctx, *driverName, vendorVersion, *nodeID)
+	if *httpEndpoint != "" {
+		driver.ServeHealth(ctx, *httpEndpoint)
+	}
+	err := driver.SetupScaleDriver(ctx, *driverName, vendorVersion, *nodeID, stateDir)
 	if err != nil {
 		klog.Fatalf("[%s] Failed to initialize Scale CSI Driver: %v", loggerId, err)
 	}
 	newDriver := driver
 	newDriver.PrintDriverInit(ctx)
-	driver.Run(ctx, *endpoint)
+	driver.Run(ctx, *endpoint, tlsFiles, stopping, *drainTimeout)
 }
 
 func createPersistentStorage(persistentStoragePath string) error {
@@ -178,6 +247,9 @@ func InitFileLogger() func() {
 	klog.SetOutput(l)
 
 	closeFn := func() {
+		if err := l.Close(); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to close log file %v\n", err)
+		}
 		err := logFile.Close()
 		if err != nil {
 			panic(fmt.Sprintf("failed to close log file %v", err))
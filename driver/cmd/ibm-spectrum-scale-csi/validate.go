@@ -0,0 +1,69 @@
+/*
+Copyright 2026 IBM Corporation.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/IBM/ibm-spectrum-scale-csi/driver/csiplugin/settings"
+)
+
+const validateConfigCommand = "validate-config"
+
+// validateConfig runs the validate-config command, which prints every
+// problem of spectrum-scale-config.json, and returns the exit code:
+//
+//	ibm-spectrum-scale-csi validate-config [-config <file>] [-secretsDir <dir>] [-certificatesDir <dir>]
+//
+// Run in the driver container it checks the configuration the driver uses,
+// with empty directories a copy of the configuration is checked alone.
+func validateConfig(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet(validateConfigCommand, flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	configFile := flags.String("config", settings.ConfigMapFile, "IBM Storage Scale configuration file")
+	secretsDir := flags.String("secretsDir", settings.SecretBasePath, "directory of the mounted GUI secrets, the secrets are not checked if empty")
+	certificatesDir := flags.String("certificatesDir", settings.CertificatePath, "directory of the mounted CA certificates, the certificates are not checked if empty")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	data, err := os.ReadFile(*configFile)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+	errorCount, warningCount := 0, 0
+	for _, problem := range settings.ValidateScaleConfig(data, *secretsDir, *certificatesDir) {
+		severity := "error"
+		if problem.Warning {
+			severity = "warning"
+			warningCount++
+		} else {
+			errorCount++
+		}
+		fmt.Fprintf(stdout, "%s: %s\n", severity, problem)
+	}
+	if errorCount > 0 {
+		fmt.Fprintf(stderr, "%s is invalid: %d errors, %d warnings\n", *configFile, errorCount, warningCount)
+		return 1
+	}
+	fmt.Fprintf(stdout, "%s is valid: %d warnings\n", *configFile, warningCount)
+	return 0
+}
@@ -0,0 +1,278 @@
+/*
+Copyright 2026 IBM Corporation.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// kubectl-scale_csi is a kubectl plugin, run as kubectl scale-csi, which maps
+// the PersistentVolumeClaims and VolumeSnapshots of IBM Storage Scale CSI
+// driver to their filesets, paths, quotas and snapshots:
+//
+//	kubectl scale-csi describe pvc <name> [-n <namespace>]
+//	kubectl scale-csi list snapshots [-n <namespace> | -A]
+//	kubectl scale-csi usage
+//	kubectl scale-csi verify [<pvc>...] [-n <namespace> | -A]
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+
+	"github.com/IBM/ibm-spectrum-scale-csi/driver/csiplugin/pkg/inspect"
+	"github.com/IBM/ibm-spectrum-scale-csi/driver/csiplugin/pkg/scaleconfig"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+const usageText = `Usage:
+  kubectl scale-csi describe pvc <name> [flags]
+  kubectl scale-csi list snapshots [flags]
+  kubectl scale-csi usage [flags]
+  kubectl scale-csi verify [<pvc>...] [flags]
+
+Flags:
+`
+
+// errProblems is returned by verify if a volume does not match its state in
+// IBM Storage Scale, the problems were printed already.
+var errProblems = errors.New("volumes with problems found")
+
+var (
+	flags         = flag.NewFlagSet("kubectl scale-csi", flag.ContinueOnError)
+	kubeconfig    = flags.String("kubeconfig", "", "path to the kubeconfig file, the in-cluster or default configuration is used if empty")
+	namespace     = flags.String("namespace", "", "namespace of the PersistentVolumeClaims and VolumeSnapshots, the namespace of the current context if empty")
+	allNamespaces = flags.Bool("all-namespaces", false, "list the VolumeSnapshots or verify the PersistentVolumeClaims of all namespaces")
+	output        = flags.String("output", "table", "output format: table or json")
+	csiNamespace  = flags.String("csi-namespace", "ibm-spectrum-scale-csi", "namespace of IBM Storage Scale CSI driver with the cluster configuration")
+	driverName    = flags.String("drivername", "spectrumscale.csi.ibm.com", "name of the driver")
+)
+
+func main() {
+	flags.StringVar(namespace, "n", "", "shorthand for --namespace")
+	flags.BoolVar(allNamespaces, "A", false, "shorthand for --all-namespaces")
+	flags.StringVar(output, "o", "table", "shorthand for --output")
+	flags.Usage = func() {
+		fmt.Fprint(flags.Output(), usageText)
+		flags.PrintDefaults()
+	}
+
+	args, err := parseArgs(os.Args[1:])
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return
+		}
+		os.Exit(2)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if err := run(ctx, args); err != nil {
+		if !errors.Is(err, errProblems) {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		}
+		os.Exit(1)
+	}
+}
+
+// parseArgs parses the flags before, between and after the arguments, as
+// kubectl does, and returns the arguments.
+func parseArgs(arguments []string) ([]string, error) {
+	args := []string{}
+	for {
+		if err := flags.Parse(arguments); err != nil {
+			return nil, err
+		}
+		if flags.NArg() == 0 {
+			return args, nil
+		}
+		args = append(args, flags.Arg(0))
+		arguments = flags.Args()[1:]
+	}
+}
+
+func run(ctx context.Context, args []string) error {
+	if *output != "table" && *output != "json" {
+		return fmt.Errorf("unsupported output format %q, expected table or json", *output)
+	}
+	command := strings.Join(args, " ")
+	switch {
+	case len(args) == 3 && args[0] == "describe" && (args[1] == "pvc" || args[1] == "persistentvolumeclaim"):
+	case command == "list snapshots" || command == "list volumesnapshots" || command == "usage":
+	case len(args) >= 1 && args[0] == "verify":
+	default:
+		flags.Usage()
+		return fmt.Errorf("unknown command %q", command)
+	}
+
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	loadingRules.ExplicitPath = *kubeconfig
+	clientConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, &clientcmd.ConfigOverrides{})
+	config, err := clientConfig.ClientConfig()
+	if err != nil {
+		return fmt.Errorf("unable to load kubeconfig: %w", err)
+	}
+	ns := *namespace
+	if ns == "" {
+		if ns, _, err = clientConfig.Namespace(); err != nil {
+			return fmt.Errorf("unable to get namespace of the current context: %w", err)
+		}
+	}
+	if *allNamespaces {
+		ns = ""
+	}
+	client, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return fmt.Errorf("unable to create Kubernetes client: %w", err)
+	}
+	dynamicClient, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return fmt.Errorf("unable to create Kubernetes client: %w", err)
+	}
+
+	// snapshots are listed from Kubernetes only
+	if args[0] == "list" {
+		snapshots, err := inspect.NewInspector(client, dynamicClient, nil, *driverName).ListSnapshots(ctx, ns)
+		if err != nil {
+			return err
+		}
+		return printResult(snapshots, func(w io.Writer) {
+			fmt.Fprintln(w, "NAMESPACE\tNAME\tPVC\tREADY\tRESTORESIZE\tFILESET\tSNAPSHOT\tCLUSTER")
+			for _, s := range snapshots {
+				fileset := s.Fileset
+				if s.Error != "" {
+					fileset = "<" + s.Error + ">"
+				}
+				fmt.Fprintf(w, "%s\t%s\t%s\t%t\t%s\t%s\t%s\t%s\n", s.Namespace, s.Name, s.Claim, s.Ready, s.RestoreSize, fileset, s.SnapshotName, s.ClusterID)
+			}
+		})
+	}
+
+	conns, err := scaleconfig.NewConnectors(ctx, client, *csiNamespace)
+	if err != nil {
+		return err
+	}
+	inspector := inspect.NewInspector(client, dynamicClient, conns, *driverName)
+	switch args[0] {
+	case "describe":
+		if ns == "" {
+			return fmt.Errorf("describe requires a namespace")
+		}
+		vol, err := inspector.DescribeClaim(ctx, ns, args[2])
+		if err != nil {
+			return err
+		}
+		return printResult(vol, func(w io.Writer) { describe(w, vol) })
+	case "usage":
+		usage, err := inspector.Usage(ctx)
+		if err != nil {
+			return err
+		}
+		return printResult(usage, func(w io.Writer) {
+			fmt.Fprintln(w, "NAMESPACE\tPVCS\tCAPACITY\tUSED\tUNMETERED")
+			for _, u := range usage {
+				fmt.Fprintf(w, "%s\t%d\t%s\t%s\t%d\n", u.Namespace, u.Claims, quantity(u.Capacity), quantity(u.Used), u.Unmetered)
+			}
+		})
+	default:
+		result, err := inspector.Verify(ctx, ns, args[1:])
+		if err != nil {
+			return err
+		}
+		failed := 0
+		err = printResult(result, func(w io.Writer) {
+			fmt.Fprintln(w, "NAMESPACE\tPVC\tPV\tSTATUS")
+			for _, v := range result {
+				if len(v.Problems) == 0 {
+					fmt.Fprintf(w, "%s\t%s\t%s\tOK\n", v.Namespace, v.Claim, v.PersistentVolume)
+				}
+				for _, problem := range v.Problems {
+					fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", v.Namespace, v.Claim, v.PersistentVolume, problem)
+				}
+			}
+		})
+		for _, v := range result {
+			if len(v.Problems) > 0 {
+				failed++
+			}
+		}
+		if err == nil && failed > 0 {
+			fmt.Fprintf(os.Stderr, "%d of %d volumes do not match IBM Storage Scale\n", failed, len(result))
+			return errProblems
+		}
+		return err
+	}
+}
+
+// printResult writes obj as JSON or the table of writeTable to stdout.
+func printResult(obj interface{}, writeTable func(w io.Writer)) error {
+	if *output == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(obj)
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	writeTable(w)
+	return w.Flush()
+}
+
+func describe(w io.Writer, vol *inspect.Volume) {
+	h := vol.Handle
+	fields := [][2]string{
+		{"Name", vol.Claim},
+		{"Namespace", vol.Namespace},
+		{"PersistentVolume", vol.PersistentVolume},
+		{"StorageClass", vol.StorageClass},
+		{"Capacity", quantity(vol.Capacity)},
+		{"VolumeHandle", h.VolumeHandle},
+		{"StorageClassType", h.StorageClassType},
+		{"VolumeType", h.VolumeType},
+		{"Cluster", h.ClusterID},
+		{"Filesystem", vol.Filesystem},
+		{"FilesystemUUID", h.FilesystemUUID},
+		{"LocalFilesystem", h.Filesystem},
+		{"ConsistencyGroup", h.ConsistencyGroup},
+		{"Fileset", h.Fileset},
+		{"LinkPath", h.Path},
+	}
+	if q := vol.Quota; q != nil {
+		fields = append(fields,
+			[2]string{"Quota", quantity(q.BlockLimit)},
+			[2]string{"Usage", quantity(q.BlockUsage)},
+			[2]string{"InodeLimit", fmt.Sprint(q.FilesLimit)},
+			[2]string{"InodeUsage", fmt.Sprint(q.FilesUsage)},
+		)
+	}
+	for _, field := range fields {
+		if field[1] != "" {
+			fmt.Fprintf(w, "%s:\t%s\n", field[0], field[1])
+		}
+	}
+	for _, err := range vol.Errors {
+		fmt.Fprintf(w, "Error:\t%s\n", err)
+	}
+}
+
+func quantity(value int64) string {
+	return resource.NewQuantity(value, resource.BinarySI).String()
+}
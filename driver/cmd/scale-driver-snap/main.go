@@ -0,0 +1,115 @@
+/*
+Copyright 2026 IBM Corporation.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// scale-driver-snap collects the Kubernetes resources, the logs and
+// optionally the IBM Storage Scale state of IBM Storage Scale CSI driver into
+// a support bundle for problem determination. It replaces
+// storage-scale-driver-snap.sh.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/IBM/ibm-spectrum-scale-csi/driver/csiplugin/connectors"
+	"github.com/IBM/ibm-spectrum-scale-csi/driver/csiplugin/pkg/scaleconfig"
+	"github.com/IBM/ibm-spectrum-scale-csi/driver/csiplugin/pkg/supportbundle"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+var (
+	kubeconfig     = flag.String("kubeconfig", "", "path to the kubeconfig file, the in-cluster or default configuration is used if empty")
+	csiNamespace   = flag.String("csi-namespace", "ibm-spectrum-scale-csi", "namespace of the operator and IBM Storage Scale CSI driver")
+	driverName     = flag.String("drivername", "spectrumscale.csi.ibm.com", "name of the driver")
+	driverSelector = flag.String("driver-selector", "app=ibm-spectrum-scale-csi", "label selector of the driver pods")
+	since          = flag.Duration("since", 0, "only collect the pod logs newer than this duration, e.g. 24h, all logs are collected if zero")
+	previous       = flag.Bool("previous", true, "collect the logs of the previous instance of the containers")
+	persistentLogs = flag.Bool("persistent-logs", false, "collect the persistent logs of the driver from each node through a short-lived pod with the driver image")
+	queryClusters  = flag.Bool("query-clusters", false, "collect the filesystems, filesets and quotas of each IBM Storage Scale cluster through its GUI")
+	outputDir      = flag.String("output-dir", ".", "directory of the support bundle")
+)
+
+func main() {
+	flag.Parse()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if err := run(ctx); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context) error {
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	loadingRules.ExplicitPath = *kubeconfig
+	config, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, &clientcmd.ConfigOverrides{}).ClientConfig()
+	if err != nil {
+		return fmt.Errorf("unable to load kubeconfig: %w", err)
+	}
+	client, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return fmt.Errorf("unable to create Kubernetes client: %w", err)
+	}
+	dynamicClient, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return fmt.Errorf("unable to create Kubernetes client: %w", err)
+	}
+
+	var conns map[string]connectors.SpectrumScaleConnector
+	if *queryClusters {
+		if conns, err = scaleconfig.NewConnectors(ctx, client, *csiNamespace); err != nil {
+			return err
+		}
+	}
+	collector := supportbundle.NewCollector(client, dynamicClient, conns, supportbundle.Options{
+		Namespace:      *csiNamespace,
+		DriverName:     *driverName,
+		DriverSelector: *driverSelector,
+		Since:          *since,
+		Previous:       *previous,
+		PersistentLogs: *persistentLogs,
+		QueryClusters:  *queryClusters,
+	})
+
+	file := filepath.Join(*outputDir, supportbundle.Name(time.Now())+".tar.gz")
+	f, err := os.OpenFile(filepath.Clean(file), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return fmt.Errorf("unable to create support bundle: %w", err)
+	}
+	manifest, err := collector.Run(ctx, f)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(file)
+		return fmt.Errorf("unable to write support bundle %s: %w", file, err)
+	}
+
+	for _, msg := range manifest.Errors {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", msg)
+	}
+	fmt.Printf("Support bundle %s with %d files written\n", file, len(manifest.Files))
+	return nil
+}
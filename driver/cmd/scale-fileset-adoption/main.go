@@ -0,0 +1,201 @@
+/*
+Copyright 2026 IBM Corporation.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// scale-fileset-adoption generates statically provisioned PersistentVolumes and
+// PersistentVolumeClaims for existing filesets, it replaces the YAML generated
+// by tools/generate_static_provisioning_yamls.sh for fileset based volumes.
+//
+// With --apply and --tag, the adopted filesets get a comment which lets the
+// driver manage them like dynamically provisioned filesets, e.g. to expand
+// or snapshot them. The driver never deletes an adopted fileset, neither when
+// its PersistentVolume is deleted nor as an orphan of scale-orphan-gc.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/IBM/ibm-spectrum-scale-csi/driver/csiplugin/pkg/filesetadoption"
+	"github.com/IBM/ibm-spectrum-scale-csi/driver/csiplugin/pkg/scaleconfig"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/yaml"
+)
+
+type stringsFlag []string
+
+func (s *stringsFlag) String() string {
+	return strings.Join(*s, ",")
+}
+
+func (s *stringsFlag) Set(value string) error {
+	*s = append(*s, value)
+	return nil
+}
+
+var (
+	kubeconfig   = flag.String("kubeconfig", "", "path to the kubeconfig file, the in-cluster or default configuration is used if empty")
+	csiNamespace = flag.String("csi-namespace", "ibm-spectrum-scale-csi", "namespace of IBM Storage Scale CSI driver with the cluster configuration")
+	driverName   = flag.String("drivername", "spectrumscale.csi.ibm.com", "name of the driver")
+	filesystem   = flag.String("filesystem", "", "name of the filesystem of the filesets on the primary cluster")
+	all          = flag.Bool("all", false, "adopt all linked filesets of the filesystem which are not created by the driver")
+	namespace    = flag.String("namespace", "", "namespace of the PersistentVolumeClaims")
+	storageClass = flag.String("storageclass", "", "StorageClass of the PersistentVolumes and PersistentVolumeClaims")
+	accessMode   = flag.String("accessmode", string(corev1.ReadWriteMany), "access mode of the volumes, ReadWriteMany or ReadWriteOnce")
+	size         = flag.String("size", "", "capacity of the volumes of filesets without a block quota, e.g. 10Gi")
+	output       = flag.String("output", "-", "file for the generated YAML, - writes to stdout")
+	apply        = flag.Bool("apply", false, "create the PersistentVolumes and PersistentVolumeClaims instead of only generating the YAML")
+	tag          = flag.Bool("tag", false, "set the comment of adopted filesets on the filesets once their volumes are created, so that the driver manages them afterwards, requires --apply")
+	filesets     stringsFlag
+)
+
+func main() {
+	flag.Var(&filesets, "fileset", "name of a fileset to adopt, can be repeated")
+	flag.Parse()
+
+	if err := run(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	if *filesystem == "" || *namespace == "" {
+		return fmt.Errorf("--filesystem and --namespace are required")
+	}
+	if len(filesets) == 0 && !*all {
+		return fmt.Errorf("specify the filesets to adopt with --fileset or use --all")
+	}
+	if *tag && !*apply {
+		return fmt.Errorf("--tag requires --apply, only the filesets of created volumes are tagged")
+	}
+	mode := corev1.PersistentVolumeAccessMode(*accessMode)
+	if mode != corev1.ReadWriteMany && mode != corev1.ReadWriteOnce {
+		return fmt.Errorf("invalid access mode %s, valid access modes are %s and %s", mode, corev1.ReadWriteMany, corev1.ReadWriteOnce)
+	}
+	opts := filesetadoption.Options{
+		Filesystem:   *filesystem,
+		DriverName:   *driverName,
+		Namespace:    *namespace,
+		StorageClass: *storageClass,
+		AccessMode:   mode,
+	}
+	if *size != "" {
+		quantity, err := resource.ParseQuantity(*size)
+		if err != nil {
+			return fmt.Errorf("invalid size %s: %w", *size, err)
+		}
+		opts.DefaultSize = &quantity
+	}
+
+	ctx := context.Background()
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	loadingRules.ExplicitPath = *kubeconfig
+	config, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, &clientcmd.ConfigOverrides{}).ClientConfig()
+	if err != nil {
+		return fmt.Errorf("unable to load kubeconfig: %w", err)
+	}
+	client, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return fmt.Errorf("unable to create Kubernetes client: %w", err)
+	}
+	conns, err := scaleconfig.NewConnectors(ctx, client, *csiNamespace)
+	if err != nil {
+		return err
+	}
+	adopter, err := filesetadoption.NewAdopter(ctx, conns, opts)
+	if err != nil {
+		return err
+	}
+
+	names := filesets
+	if *all {
+		names, err = adopter.Candidates(ctx)
+		if err != nil {
+			return err
+		}
+		if len(names) == 0 {
+			fmt.Fprintf(os.Stderr, "No fileset of filesystem %s can be adopted\n", *filesystem)
+			return nil
+		}
+	}
+
+	out := io.Writer(os.Stdout)
+	if *output != "-" {
+		file, err := os.Create(*output)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		out = file
+	}
+
+	failed := 0
+	for _, name := range names {
+		volume, err := adopter.Adopt(ctx, name)
+		if err == nil {
+			err = writeVolume(out, volume)
+		}
+		if err == nil && *apply {
+			err = createVolume(ctx, client, volume)
+		}
+		if err == nil && *tag {
+			err = volume.Tag(ctx)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to adopt fileset %s: %v\n", name, err)
+			failed++
+			continue
+		}
+		fmt.Fprintf(os.Stderr, "Adopted fileset %s as PersistentVolume %s and PersistentVolumeClaim %s/%s\n",
+			name, volume.PersistentVolume.Name, volume.PersistentVolumeClaim.Namespace, volume.PersistentVolumeClaim.Name)
+	}
+	if failed > 0 {
+		return fmt.Errorf("adoption of %d of %d filesets failed", failed, len(names))
+	}
+	return nil
+}
+
+func writeVolume(out io.Writer, volume *filesetadoption.Volume) error {
+	for _, obj := range []interface{}{volume.PersistentVolume, volume.PersistentVolumeClaim} {
+		data, err := yaml.Marshal(obj)
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(out, "---\n%s", data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func createVolume(ctx context.Context, client kubernetes.Interface, volume *filesetadoption.Volume) error {
+	if _, err := client.CoreV1().PersistentVolumes().Create(ctx, volume.PersistentVolume, metav1.CreateOptions{}); err != nil {
+		return fmt.Errorf("unable to create PersistentVolume %s: %w", volume.PersistentVolume.Name, err)
+	}
+	pvc := volume.PersistentVolumeClaim
+	if _, err := client.CoreV1().PersistentVolumeClaims(pvc.Namespace).Create(ctx, pvc, metav1.CreateOptions{}); err != nil {
+		return fmt.Errorf("unable to create PersistentVolumeClaim %s/%s: %w", pvc.Namespace, pvc.Name, err)
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2026 IBM Corporation.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// scale-orphan-gc reports and optionally deletes the filesets and directories
+// created by IBM Storage Scale CSI driver which are not referenced by any
+// PersistentVolume. It can run once or periodically, e.g. as a CronJob or as a
+// sidecar of the controller.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/IBM/ibm-spectrum-scale-csi/driver/csiplugin/pkg/orphangc"
+	"github.com/IBM/ibm-spectrum-scale-csi/driver/csiplugin/pkg/scaleconfig"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+var (
+	kubeconfig   = flag.String("kubeconfig", "", "path to the kubeconfig file, the in-cluster or default configuration is used if empty")
+	csiNamespace = flag.String("csi-namespace", "ibm-spectrum-scale-csi", "namespace of IBM Storage Scale CSI driver with the cluster configuration")
+	driverName   = flag.String("drivername", "spectrumscale.csi.ibm.com", "name of the driver")
+	deleteOrphan = flag.Bool("delete", false, "delete the orphans older than the grace period, otherwise they are only reported")
+	gracePeriod  = flag.Duration("grace-period", 24*time.Hour, "minimum age of an orphan before it is deleted")
+	localMounts  = flag.Bool("local-mounts", false, "scan lightweight volume directories and snapshot metadata directories through the filesystems mounted on this node at the mount points of the primary cluster")
+	namePrefix   = flag.String("volume-name-prefix", "pvc-", "prefix of the volume names generated by the external provisioner, directories with other names are never reported")
+	report       = flag.String("report", "", "path of the JSON report, the report is written to stdout if empty")
+	metricsFile  = flag.String("metrics-file", "", "path of a file receiving the number of orphans in the Prometheus text format")
+	interval     = flag.Duration("interval", 0, "run periodically with this interval instead of once")
+)
+
+func main() {
+	flag.Parse()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if err := run(ctx); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context) error {
+	if *gracePeriod <= 0 {
+		return fmt.Errorf("--grace-period must be positive")
+	}
+
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	loadingRules.ExplicitPath = *kubeconfig
+	config, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, &clientcmd.ConfigOverrides{}).ClientConfig()
+	if err != nil {
+		return fmt.Errorf("unable to load kubeconfig: %w", err)
+	}
+	client, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return fmt.Errorf("unable to create Kubernetes client: %w", err)
+	}
+	conns, err := scaleconfig.NewConnectors(ctx, client, *csiNamespace)
+	if err != nil {
+		return err
+	}
+	collector := orphangc.NewCollector(client, conns, orphangc.Options{
+		DriverName:       *driverName,
+		GracePeriod:      *gracePeriod,
+		Delete:           *deleteOrphan,
+		LocalMounts:      *localMounts,
+		VolumeNamePrefix: *namePrefix,
+	})
+
+	if *interval <= 0 {
+		return collect(ctx, collector)
+	}
+	ticker := time.NewTicker(*interval)
+	defer ticker.Stop()
+	for {
+		// a failed run is retried with the next interval
+		if err := collect(ctx, collector); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func collect(ctx context.Context, collector *orphangc.Collector) error {
+	result, err := collector.Run(ctx)
+	if err != nil {
+		return err
+	}
+	for _, orphan := range result.Orphans {
+		state := "orphaned"
+		switch {
+		case orphan.Deleted:
+			state = "deleted"
+		case orphan.Reason != "":
+			state = "kept: " + orphan.Reason
+		}
+		fmt.Fprintf(os.Stderr, "%s %s in filesystem %s of cluster %s: %s\n", orphan.Kind, orphan.Name, orphan.Filesystem, orphan.ClusterID, state)
+	}
+
+	if *report == "" {
+		if err := result.Encode(os.Stdout); err != nil {
+			return err
+		}
+	} else if err := result.Write(*report); err != nil {
+		return err
+	}
+	if *metricsFile != "" {
+		if err := result.WriteMetrics(*metricsFile); err != nil {
+			return err
+		}
+	}
+	if result.Summary.Failed > 0 {
+		return fmt.Errorf("deletion of %d orphans failed", result.Summary.Failed)
+	}
+	return nil
+}
@@ -0,0 +1,196 @@
+/*
+Copyright 2026 IBM Corporation.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// scale-volume-migration rewrites the volume handles of the PersistentVolumes
+// of IBM Storage Scale CSI driver, it replaces the migration_*.bash scripts of
+// tools/volume_migration_scripts.
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/IBM/ibm-spectrum-scale-csi/driver/csiplugin/pkg/volumemigration"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+type prefixFlag map[string]string
+
+func (p prefixFlag) String() string {
+	pairs := []string{}
+	for fs, prefix := range p {
+		pairs = append(pairs, fs+"="+prefix)
+	}
+	return strings.Join(pairs, ",")
+}
+
+func (p prefixFlag) Set(value string) error {
+	fs, prefix, ok := strings.Cut(value, "=")
+	if !ok || fs == "" || prefix == "" {
+		return fmt.Errorf("expected <volBackendFs>=<path prefix>, got %q", value)
+	}
+	p[fs] = prefix
+	return nil
+}
+
+var (
+	mode          = flag.String("mode", "", "migration mode: csi-to-cnsa, csi-primary-removal or cnsa-primary-removal")
+	newPathPrefix = flag.String("new-path-prefix", "", "mount path prefix of the filesystems after the migration, one of "+strings.Join(volumemigration.AllowedCNSAPathPrefixes, ", ")+" for IBM Storage Scale container native")
+	kubeconfig    = flag.String("kubeconfig", "", "path to the kubeconfig file, the in-cluster or default configuration is used if empty")
+	driverName    = flag.String("drivername", "spectrumscale.csi.ibm.com", "name of the driver")
+	dryRun        = flag.Bool("dry-run", false, "print the volume handle changes without changing any object")
+	batchSize     = flag.Int("batch-size", 0, "maximum number of PersistentVolumes processed in this run, 0 processes all of them")
+	checkpoint    = flag.String("checkpoint", filepath.Join("csi_migration_data", "checkpoint.json"), "checkpoint file used to resume the migration")
+	report        = flag.String("report", "", "path of the JSON report, defaults to report.json in the backup directory")
+	backupDir     = flag.String("backup-dir", "", "directory for the backup of the PersistentVolumes and PersistentVolumeClaims, defaults to csi_migration_data/<timestamp>")
+	validate      = flag.Bool("validate", true, "validate every rewritten volume handle against IBM Storage Scale")
+	csiNamespace  = flag.String("csi-namespace", "ibm-spectrum-scale-csi", "namespace of IBM Storage Scale CSI driver with the cluster configuration, used for validation")
+	timeout       = flag.Duration("timeout", 5*time.Minute, "time to wait for the deletion and binding of an object")
+	assumeYes     = flag.Bool("yes", false, "do not ask for confirmation before the migration")
+	fsPathPrefix  = prefixFlag{}
+)
+
+func main() {
+	flag.Var(fsPathPrefix, "fs-path-prefix", "mount path prefix of one filesystem as <volBackendFs>=<path prefix>, can be repeated")
+	flag.Parse()
+
+	if err := run(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	migrationMode := volumemigration.Mode(*mode)
+	prefixes := map[string]string{}
+	for fs, prefix := range fsPathPrefix {
+		prefixes[fs] = prefix
+	}
+	if *newPathPrefix != "" {
+		prefixes[""] = *newPathPrefix
+	}
+
+	switch migrationMode {
+	case volumemigration.ModeCSIToCNSA, volumemigration.ModeCNSAPrimaryRemoval:
+		if !slices.Contains(volumemigration.AllowedCNSAPathPrefixes, strings.TrimSuffix(*newPathPrefix, "/")) {
+			return fmt.Errorf("--new-path-prefix must be one of %s in mode %s", strings.Join(volumemigration.AllowedCNSAPathPrefixes, ", "), migrationMode)
+		}
+	case volumemigration.ModeCSIPrimaryRemoval:
+		if len(prefixes) == 0 {
+			return fmt.Errorf("--new-path-prefix or --fs-path-prefix is required in mode %s", migrationMode)
+		}
+	default:
+		return fmt.Errorf("unknown mode %q, supported modes are %s, %s and %s", *mode,
+			volumemigration.ModeCSIToCNSA, volumemigration.ModeCSIPrimaryRemoval, volumemigration.ModeCNSAPrimaryRemoval)
+	}
+	if *batchSize < 0 {
+		return fmt.Errorf("--batch-size must not be negative")
+	}
+
+	if *backupDir == "" {
+		*backupDir = filepath.Join("csi_migration_data", time.Now().Format("20060102_150405"))
+	}
+	if *report == "" {
+		*report = filepath.Join(*backupDir, "report.json")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	loadingRules.ExplicitPath = *kubeconfig
+	config, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, &clientcmd.ConfigOverrides{}).ClientConfig()
+	if err != nil {
+		return fmt.Errorf("unable to load kubeconfig: %w", err)
+	}
+	client, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return fmt.Errorf("unable to create Kubernetes client: %w", err)
+	}
+
+	var validator volumemigration.Validator
+	if *validate {
+		validator, err = volumemigration.NewBackendValidator(ctx, client, *csiNamespace)
+		if err != nil {
+			return fmt.Errorf("unable to set up validation, use --validate=false to skip it: %w", err)
+		}
+	}
+
+	state, err := volumemigration.LoadCheckpoint(*checkpoint, migrationMode)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Mode: %s\n", migrationMode)
+	for fs, prefix := range prefixes {
+		if fs == "" {
+			fs = "<all filesystems>"
+		}
+		fmt.Printf("Path prefix: %s => %s\n", fs, prefix)
+	}
+	if !*dryRun && !*assumeYes && !confirm() {
+		return fmt.Errorf("migration aborted")
+	}
+
+	migrator := volumemigration.NewMigrator(client, validator, state, volumemigration.Options{
+		Mode:         migrationMode,
+		DriverName:   *driverName,
+		PathPrefixes: prefixes,
+		DryRun:       *dryRun,
+		BatchSize:    *batchSize,
+		BackupDir:    *backupDir,
+		Timeout:      *timeout,
+	}, os.Stdout)
+	result, err := migrator.Run(ctx)
+	if err != nil {
+		return err
+	}
+	if err := result.Write(*report); err != nil {
+		return err
+	}
+
+	summary := result.Summary
+	fmt.Printf("\nMigration summary: total %d, migrated %d, planned %d, skipped %d, failed %d, remaining %d\n",
+		summary.Total, summary.Migrated, summary.Planned, summary.Skipped, summary.Failed, summary.Remaining)
+	fmt.Printf("Report: %s\n", *report)
+	if !*dryRun {
+		fmt.Printf("Checkpoint: %s\n", *checkpoint)
+	}
+	if summary.Failed > 0 {
+		return fmt.Errorf("migration of %d PersistentVolumes failed", summary.Failed)
+	}
+	return nil
+}
+
+func confirm() bool {
+	fmt.Print("The PersistentVolumeClaims will be deleted and recreated, continue? (y/n): ")
+	answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	switch strings.TrimSpace(strings.ToLower(answer)) {
+	case "y", "yes":
+		return true
+	}
+	return false
+}
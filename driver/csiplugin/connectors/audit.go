@@ -0,0 +1,304 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package connectors
+
+import (
+	"context"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/IBM/ibm-spectrum-scale-csi/driver/csiplugin/pkg/audit"
+	"github.com/IBM/ibm-spectrum-scale-csi/driver/csiplugin/settings"
+)
+
+// auditedConnector writes an audit record for every mutating call of the
+// connector, the other calls are passed through. Secrets like bucket keys
+// are never recorded.
+type auditedConnector struct {
+	SpectrumScaleConnector
+	clusterID string
+	username  string
+}
+
+func newAuditedConnector(conn SpectrumScaleConnector, cluster settings.Clusters) SpectrumScaleConnector {
+	return &auditedConnector{SpectrumScaleConnector: conn, clusterID: cluster.ID, username: cluster.MgmtUsername}
+}
+
+// record writes the audit record of a call which started at start, args
+// are pairs of argument names and values.
+func (c *auditedConnector) record(ctx context.Context, operation string, start time.Time, err error, args ...string) {
+	r := audit.Record{
+		Time:       start,
+		Operation:  operation,
+		Cluster:    c.clusterID,
+		User:       c.username,
+		Arguments:  map[string]string{},
+		Outcome:    audit.OutcomeSuccess,
+		DurationMs: time.Since(start).Milliseconds(),
+	}
+	if creds, ok := credentialsForCluster(ctx, c.clusterID); ok {
+		r.User = creds.Username
+	}
+	for i := 0; i+1 < len(args); i += 2 {
+		if args[i+1] != "" {
+			r.Arguments[args[i]] = args[i+1]
+		}
+	}
+	if err != nil {
+		r.Outcome = audit.OutcomeFailure
+		r.Error = err.Error()
+	}
+	audit.Log(ctx, r)
+}
+
+func (c *auditedConnector) CreateFileset(ctx context.Context, filesystemName string, volumeType string, filesetName string, opts map[string]interface{}, mode string, exportMapName string, nfsInfo map[string]string) error {
+	start := time.Now()
+	err := c.SpectrumScaleConnector.CreateFileset(ctx, filesystemName, volumeType, filesetName, opts, mode, exportMapName, nfsInfo)
+	c.record(ctx, "CreateFileset", start, err, "filesystem", filesystemName, "fileset", filesetName, "volumeType", volumeType, "mode", mode)
+	return err
+}
+
+func (c *auditedConnector) CreateAFMDRSecondaryFileset(ctx context.Context, filesystemName string, filesetName string, afmPrimaryID string, comment string) error {
+	start := time.Now()
+	err := c.SpectrumScaleConnector.CreateAFMDRSecondaryFileset(ctx, filesystemName, filesetName, afmPrimaryID, comment)
+	c.record(ctx, "CreateAFMDRSecondaryFileset", start, err, "filesystem", filesystemName, "fileset", filesetName, "afmPrimaryID", afmPrimaryID)
+	return err
+}
+
+func (c *auditedConnector) RunAFMDRCommand(ctx context.Context, filesystemName string, filesetName string, afmdrReq AFMDRCommandRequest) error {
+	start := time.Now()
+	err := c.SpectrumScaleConnector.RunAFMDRCommand(ctx, filesystemName, filesetName, afmdrReq)
+	c.record(ctx, "RunAFMDRCommand", start, err, "filesystem", filesystemName, "fileset", filesetName, "action", afmdrReq.Action, "afmTarget", afmdrReq.AfmTarget)
+	return err
+}
+
+func (c *auditedConnector) SetBucketKeys(ctx context.Context, access map[string]string, exportMapName string) error {
+	start := time.Now()
+	err := c.SpectrumScaleConnector.SetBucketKeys(ctx, access, exportMapName)
+	c.record(ctx, "SetBucketKeys", start, err, "bucket", access[BucketName], "exportMap", exportMapName)
+	return err
+}
+
+func (c *auditedConnector) DeleteBucketKeys(ctx context.Context, bucket string) error {
+	start := time.Now()
+	err := c.SpectrumScaleConnector.DeleteBucketKeys(ctx, bucket)
+	c.record(ctx, "DeleteBucketKeys", start, err, "bucket", bucket)
+	return err
+}
+
+func (c *auditedConnector) DeleteNodeMappingAFMWithCos(ctx context.Context, exportMapName string) error {
+	start := time.Now()
+	err := c.SpectrumScaleConnector.DeleteNodeMappingAFMWithCos(ctx, exportMapName)
+	c.record(ctx, "DeleteNodeMappingAFMWithCos", start, err, "exportMap", exportMapName)
+	return err
+}
+
+func (c *auditedConnector) CreateS3CacheFileset(ctx context.Context, filesystemName string, filesetName string, mode string, opts map[string]interface{}, access map[string]string, exportMapName string, parsedURL *url.URL) error {
+	start := time.Now()
+	err := c.SpectrumScaleConnector.CreateS3CacheFileset(ctx, filesystemName, filesetName, mode, opts, access, exportMapName, parsedURL)
+	c.record(ctx, "CreateS3CacheFileset", start, err, "filesystem", filesystemName, "fileset", filesetName, "mode", mode, "bucket", access[BucketName], "exportMap", exportMapName)
+	return err
+}
+
+func (c *auditedConnector) CreateNodeMappingAFMWithCos(ctx context.Context, exportMapName string, gatewayNodeName string, bucketInfo, nfsInfo map[string]string, isNfsSupported bool) error {
+	start := time.Now()
+	err := c.SpectrumScaleConnector.CreateNodeMappingAFMWithCos(ctx, exportMapName, gatewayNodeName, bucketInfo, nfsInfo, isNfsSupported)
+	c.record(ctx, "CreateNodeMappingAFMWithCos", start, err, "exportMap", exportMapName, "gatewayNode", gatewayNodeName)
+	return err
+}
+
+func (c *auditedConnector) UpdateFileset(ctx context.Context, filesystemName string, volType string, filesetName string, opts map[string]interface{}, setAfmAttributes string) error {
+	start := time.Now()
+	err := c.SpectrumScaleConnector.UpdateFileset(ctx, filesystemName, volType, filesetName, opts, setAfmAttributes)
+	c.record(ctx, "UpdateFileset", start, err, "filesystem", filesystemName, "fileset", filesetName, "volumeType", volType, "attributes", optionNames(opts))
+	return err
+}
+
+func (c *auditedConnector) DeleteFileset(ctx context.Context, filesystemName string, filesetName string) error {
+	start := time.Now()
+	err := c.SpectrumScaleConnector.DeleteFileset(ctx, filesystemName, filesetName)
+	c.record(ctx, "DeleteFileset", start, err, "filesystem", filesystemName, "fileset", filesetName)
+	return err
+}
+
+func (c *auditedConnector) LinkFileset(ctx context.Context, filesystemName string, filesetName string, linkpath string) error {
+	start := time.Now()
+	err := c.SpectrumScaleConnector.LinkFileset(ctx, filesystemName, filesetName, linkpath)
+	c.record(ctx, "LinkFileset", start, err, "filesystem", filesystemName, "fileset", filesetName, "path", linkpath)
+	return err
+}
+
+func (c *auditedConnector) UnlinkFileset(ctx context.Context, filesystemName string, filesetName string, force bool) error {
+	start := time.Now()
+	err := c.SpectrumScaleConnector.UnlinkFileset(ctx, filesystemName, filesetName, force)
+	c.record(ctx, "UnlinkFileset", start, err, "filesystem", filesystemName, "fileset", filesetName, "force", strconv.FormatBool(force))
+	return err
+}
+
+func (c *auditedConnector) SetFilesetQuota(ctx context.Context, filesystemName string, filesetName string, hardLimit string, softLimit string) error {
+	start := time.Now()
+	err := c.SpectrumScaleConnector.SetFilesetQuota(ctx, filesystemName, filesetName, hardLimit, softLimit)
+	c.record(ctx, "SetFilesetQuota", start, err, "filesystem", filesystemName, "fileset", filesetName, "hardLimit", hardLimit, "softLimit", softLimit)
+	return err
+}
+
+func (c *auditedConnector) MakeDirectory(ctx context.Context, filesystemName string, relativePath string, uid string, gid string) error {
+	start := time.Now()
+	err := c.SpectrumScaleConnector.MakeDirectory(ctx, filesystemName, relativePath, uid, gid)
+	c.record(ctx, "MakeDirectory", start, err, "filesystem", filesystemName, "path", relativePath, "uid", uid, "gid", gid)
+	return err
+}
+
+func (c *auditedConnector) MakeDirectoryV2(ctx context.Context, filesystemName string, relativePath string, uid string, gid string, permissions string) error {
+	start := time.Now()
+	err := c.SpectrumScaleConnector.MakeDirectoryV2(ctx, filesystemName, relativePath, uid, gid, permissions)
+	c.record(ctx, "MakeDirectory", start, err, "filesystem", filesystemName, "path", relativePath, "uid", uid, "gid", gid, "permissions", permissions)
+	return err
+}
+
+func (c *auditedConnector) MountFilesystem(ctx context.Context, filesystemName string, nodesNameList []string) error {
+	start := time.Now()
+	err := c.SpectrumScaleConnector.MountFilesystem(ctx, filesystemName, nodesNameList)
+	c.record(ctx, "MountFilesystem", start, err, "filesystem", filesystemName, "nodes", strings.Join(nodesNameList, ","))
+	return err
+}
+
+func (c *auditedConnector) UnmountFilesystem(ctx context.Context, filesystemName string, nodeName string) error {
+	start := time.Now()
+	err := c.SpectrumScaleConnector.UnmountFilesystem(ctx, filesystemName, nodeName)
+	c.record(ctx, "UnmountFilesystem", start, err, "filesystem", filesystemName, "nodes", nodeName)
+	return err
+}
+
+func (c *auditedConnector) CreateSymLink(ctx context.Context, SlnkfilesystemName string, TargetFs string, relativePath string, LnkPath string) error {
+	start := time.Now()
+	err := c.SpectrumScaleConnector.CreateSymLink(ctx, SlnkfilesystemName, TargetFs, relativePath, LnkPath)
+	c.record(ctx, "CreateSymLink", start, err, "filesystem", SlnkfilesystemName, "targetFilesystem", TargetFs, "target", relativePath, "path", LnkPath)
+	return err
+}
+
+func (c *auditedConnector) DeleteDirectory(ctx context.Context, filesystemName string, dirName string, safe bool) error {
+	start := time.Now()
+	err := c.SpectrumScaleConnector.DeleteDirectory(ctx, filesystemName, dirName, safe)
+	c.record(ctx, "DeleteDirectory", start, err, "filesystem", filesystemName, "path", dirName, "safe", strconv.FormatBool(safe))
+	return err
+}
+
+func (c *auditedConnector) DeleteSymLnk(ctx context.Context, filesystemName string, LnkName string) error {
+	start := time.Now()
+	err := c.SpectrumScaleConnector.DeleteSymLnk(ctx, filesystemName, LnkName)
+	c.record(ctx, "DeleteSymLink", start, err, "filesystem", filesystemName, "path", LnkName)
+	return err
+}
+
+func (c *auditedConnector) SetFilesystemPolicy(ctx context.Context, policy *Policy, filesystemName string) error {
+	start := time.Now()
+	err := c.SpectrumScaleConnector.SetFilesystemPolicy(ctx, policy, filesystemName)
+	partition := ""
+	if policy != nil {
+		partition = policy.Partition
+	}
+	c.record(ctx, "SetFilesystemPolicy", start, err, "filesystem", filesystemName, "partition", partition)
+	return err
+}
+
+func (c *auditedConnector) DeletePolicyPartition(ctx context.Context, partitionName string, filesystemName string) error {
+	start := time.Now()
+	err := c.SpectrumScaleConnector.DeletePolicyPartition(ctx, partitionName, filesystemName)
+	c.record(ctx, "DeletePolicyPartition", start, err, "filesystem", filesystemName, "partition", partitionName)
+	return err
+}
+
+func (c *auditedConnector) CancelJob(ctx context.Context, jobID uint64) error {
+	start := time.Now()
+	err := c.SpectrumScaleConnector.CancelJob(ctx, jobID)
+	c.record(ctx, "CancelJob", start, err, "jobId", strconv.FormatUint(jobID, 10))
+	return err
+}
+
+func (c *auditedConnector) CreateSnapshot(ctx context.Context, filesystemName string, filesetName string, snapshotName string) error {
+	start := time.Now()
+	err := c.SpectrumScaleConnector.CreateSnapshot(ctx, filesystemName, filesetName, snapshotName)
+	c.record(ctx, "CreateSnapshot", start, err, "filesystem", filesystemName, "fileset", filesetName, "snapshot", snapshotName)
+	return err
+}
+
+func (c *auditedConnector) DeleteSnapshot(ctx context.Context, filesystemName string, filesetName string, snapshotName string) error {
+	start := time.Now()
+	err := c.SpectrumScaleConnector.DeleteSnapshot(ctx, filesystemName, filesetName, snapshotName)
+	c.record(ctx, "DeleteSnapshot", start, err, "filesystem", filesystemName, "fileset", filesetName, "snapshot", snapshotName)
+	return err
+}
+
+func (c *auditedConnector) CreateSnapshotCloneCopy(ctx context.Context, filesystemName, filesetName, snapshotName, sourcePath, targetFilesystemName, targetFileset, targetPath string) error {
+	start := time.Now()
+	err := c.SpectrumScaleConnector.CreateSnapshotCloneCopy(ctx, filesystemName, filesetName, snapshotName, sourcePath, targetFilesystemName, targetFileset, targetPath)
+	c.record(ctx, "CreateSnapshotCloneCopy", start, err, "filesystem", filesystemName, "fileset", filesetName, "snapshot", snapshotName, "path", sourcePath,
+		"targetFilesystem", targetFilesystemName, "targetFileset", targetFileset, "targetPath", targetPath)
+	return err
+}
+
+func (c *auditedConnector) CreateSnapshotCloneSplit(ctx context.Context, filesystemName, filesetName string) error {
+	start := time.Now()
+	err := c.SpectrumScaleConnector.CreateSnapshotCloneSplit(ctx, filesystemName, filesetName)
+	c.record(ctx, "CreateSnapshotCloneSplit", start, err, "filesystem", filesystemName, "fileset", filesetName)
+	return err
+}
+
+func (c *auditedConnector) CopyFsetSnapshotPath(ctx context.Context, filesystemName string, filesetName string, snapshotName string, srcPath string, targetPath string, nodeclass string) (int, uint64, error) {
+	start := time.Now()
+	statusCode, jobID, err := c.SpectrumScaleConnector.CopyFsetSnapshotPath(ctx, filesystemName, filesetName, snapshotName, srcPath, targetPath, nodeclass)
+	c.record(ctx, "CopyFilesetSnapshotPath", start, err, "filesystem", filesystemName, "fileset", filesetName, "snapshot", snapshotName, "path", srcPath, "targetPath", targetPath, "jobId", jobIDString(jobID))
+	return statusCode, jobID, err
+}
+
+func (c *auditedConnector) CopyFilesetPath(ctx context.Context, filesystemName string, filesetName string, srcPath string, targetPath string, nodeclass string) (int, uint64, error) {
+	start := time.Now()
+	statusCode, jobID, err := c.SpectrumScaleConnector.CopyFilesetPath(ctx, filesystemName, filesetName, srcPath, targetPath, nodeclass)
+	c.record(ctx, "CopyFilesetPath", start, err, "filesystem", filesystemName, "fileset", filesetName, "path", srcPath, "targetPath", targetPath, "jobId", jobIDString(jobID))
+	return statusCode, jobID, err
+}
+
+func (c *auditedConnector) CopyDirectoryPath(ctx context.Context, filesystemName string, srcPath string, targetPath string, nodeclass string) (int, uint64, error) {
+	start := time.Now()
+	statusCode, jobID, err := c.SpectrumScaleConnector.CopyDirectoryPath(ctx, filesystemName, srcPath, targetPath, nodeclass)
+	c.record(ctx, "CopyDirectoryPath", start, err, "filesystem", filesystemName, "path", srcPath, "targetPath", targetPath, "jobId", jobIDString(jobID))
+	return statusCode, jobID, err
+}
+
+// jobIDString returns the ID of an asynchronous job, copies run as a job
+// which is recorded when it was started.
+func jobIDString(jobID uint64) string {
+	if jobID == 0 {
+		return ""
+	}
+	return strconv.FormatUint(jobID, 10)
+}
+
+// optionNames returns the names of the fileset attributes which are updated,
+// the values may contain secrets.
+func optionNames(opts map[string]interface{}) string {
+	names := make([]string, 0, len(opts))
+	for name := range opts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ",")
+}
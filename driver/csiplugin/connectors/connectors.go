@@ -43,6 +43,8 @@ type SpectrumScaleConnector interface {
 	ListGatewayNodes(ctx context.Context) ([]string, error)
 	//Fileset operations
 	CreateFileset(ctx context.Context, filesystemName string, volumeType string, filesetName string, opts map[string]interface{}, mode string, exportMapName string, nfsInfo map[string]string) error
+	CreateAFMDRSecondaryFileset(ctx context.Context, filesystemName string, filesetName string, afmPrimaryID string, comment string) error
+	RunAFMDRCommand(ctx context.Context, filesystemName string, filesetName string, afmdrReq AFMDRCommandRequest) error
 	CheckFilesetWithAFMTarget(ctx context.Context, filesystemName string, afmTarget string) (string, error)
 	SetBucketKeys(ctx context.Context, access map[string]string, exportMapName string) error
 	DeleteBucketKeys(ctx context.Context, bucket string) error
@@ -54,7 +56,7 @@ type SpectrumScaleConnector interface {
 	//LinkFileset(filesystemName string, filesetName string) error
 	LinkFileset(ctx context.Context, filesystemName string, filesetName string, linkpath string) error
 	UnlinkFileset(ctx context.Context, filesystemName string, filesetName string, force bool) error
-	//ListFilesets(filesystemName string) ([]resources.Volume, error)
+	ListFilesets(ctx context.Context, filesystemName string) ([]Fileset_v2, error)
 	ListFileset(ctx context.Context, filesystemName string, filesetName string) (Fileset_v2, error)
 	GetFilesetsInodeSpace(ctx context.Context, filesystemName string, inodeSpace int) ([]Fileset_v2, error)
 	IsFilesetLinked(ctx context.Context, filesystemName string, filesetName string) (bool, error)
@@ -82,16 +84,21 @@ type SpectrumScaleConnector interface {
 	GetFileSetResponseFromId(ctx context.Context, filesystemName string, Id string) (Fileset_v2, error)
 	GetFileSetResponseFromName(ctx context.Context, filesystemName string, filesetName string) (Fileset_v2, error)
 	SetFilesystemPolicy(ctx context.Context, policy *Policy, filesystemName string) error
+	DeletePolicyPartition(ctx context.Context, partitionName string, filesystemName string) error
 	DoesTierExist(ctx context.Context, tierName string, filesystemName string) error
 	GetTierInfoFromName(ctx context.Context, tierName string, filesystemName string) (*StorageTier, error)
 	GetFirstDataTier(ctx context.Context, filesystemName string) (string, error)
 	IsValidNodeclass(ctx context.Context, nodeclass string) (bool, error)
 	IsSnapshotSupported(ctx context.Context) (bool, error)
+	IsSnapshotDiffSupported(ctx context.Context) (bool, error)
 	CheckIfDefaultPolicyPartitionExists(ctx context.Context, partitionName string, filesystemName string) bool
+	GetFilesystemPolicyPartition(ctx context.Context, partitionName string, filesystemName string) (Policy, error)
 
 	//Snapshot operations
 	WaitForJobCompletion(ctx context.Context, statusCode int, jobID uint64) error
 	WaitForJobCompletionWithResp(ctx context.Context, statusCode int, jobID uint64) (GenericResponse, error)
+	GetJob(ctx context.Context, jobID uint64) (Job, error)
+	CancelJob(ctx context.Context, jobID uint64) error
 	CreateSnapshot(ctx context.Context, filesystemName string, filesetName string, snapshotName string) error
 	DeleteSnapshot(ctx context.Context, filesystemName string, filesetName string, snapshotName string) error
 	CreateSnapshotCloneCopy(ctx context.Context, filesystemName, filesetName, snapshotName, sourcePath, targetFilesystemName, targetFileset, targetPath string) error
@@ -105,6 +112,7 @@ type SpectrumScaleConnector interface {
 	CopyFsetSnapshotPath(ctx context.Context, filesystemName string, filesetName string, snapshotName string, srcPath string, targetPath string, nodeclass string) (int, uint64, error)
 	CopyFilesetPath(ctx context.Context, filesystemName string, filesetName string, srcPath string, targetPath string, nodeclass string) (int, uint64, error)
 	CopyDirectoryPath(ctx context.Context, filesystemName string, srcPath string, targetPath string, nodeclass string) (int, uint64, error)
+	SnapshotDiff(ctx context.Context, filesystemName string, filesetName string, baseSnapshot string, targetSnapshot string, path string) ([]SnapshotChange, error)
 	IsNodeComponentHealthy(ctx context.Context, nodeName string, component string) (bool, error)
 }
 
@@ -125,15 +133,33 @@ const (
 	UserSpecifiedCompression      string = "compression"
 	UserSpecifiedTier             string = "tier"
 	UserSpecifiedSnapWindow       string = "snapWindow"
+	UserSpecifiedSnapshotPruning  string = "snapshotPruning"
 	UserSpecifiedConsistencyGroup string = "consistencyGroup"
 	UserSpecifiedShared           string = "shared"
 	FilesetComment                string = "Fileset created by IBM Container Storage Interface driver"
 	FilesetCommentKey             string = "FilesetComment"
 	FilesetCommentValue           string = FilesetComment + " for PVC [ %s ] in the namespace [ %s ]"
+	FilesetNewNameKey             string = "FilesetNewName"
+	WarmPoolFilesetCommentPrefix  string = FilesetComment + " for warm pool"
+	WarmPoolFilesetComment        string = WarmPoolFilesetCommentPrefix + " [ %s ]"
+	AdoptedFilesetCommentPrefix   string = FilesetComment + " by adoption"
+	AdoptedFilesetComment         string = AdoptedFilesetCommentPrefix + " for PVC [ %s ] in the namespace [ %s ]"
 	UserSpecifiedCacheMode        string = "cacheMode"
 	UserSpecifiedVolumeType       string = "volumeType"
 	UserSpecifiedVolNamePrefix    string = "volNamePrefix"
 	UserSpecifiedExistingVolume   string = "existingVolume"
+	UserSpecifiedEncryptionKey    string = "encryptionKey"
+	UserSpecifiedEncryptionAlgo   string = "encryptionAlgorithm"
+	UserSpecifiedDataReplicas     string = "dataReplicas"
+	UserSpecifiedMetadataReplicas string = "metadataReplicas"
+	UserSpecifiedRevertToSnapshot string = "revertToSnapshot"
+	UserSpecifiedWarmPoolSize     string = "warmPoolSize"
+
+	// AFM-DR replication parameters
+	UserSpecifiedReplicationClusterId string = "replicationClusterId"
+	UserSpecifiedReplicationFs        string = "replicationFilesystem"
+	UserSpecifiedReplicationServer    string = "replicationTargetServer"
+	UserSpecifiedReplicationRPO       string = "replicationRPO"
 
 	// AFM tuning parameters to modify cache fileset for s3
 	AfmReadSparseThreshold     string = "afmReadSparseThreshold"
@@ -160,7 +186,33 @@ const (
 	AfmFileLookupRefreshIntervalDefault string   = "30"
 )
 
+// AFM-DR fileset modes and the mmafmctl commands run on AFM-DR filesets
+const (
+	AfmModePrimary   string = "primary"
+	AfmModeSecondary string = "secondary"
+
+	AfmDRConvertToPrimary       string = "convertToPrimary"
+	AfmDRConvertToSecondary     string = "convertToSecondary"
+	AfmDRFailoverToSecondary    string = "failoverToSecondary"
+	AfmDRFailbackToPrimaryStart string = "failbackToPrimaryStart"
+	AfmDRFailbackToPrimaryStop  string = "failbackToPrimaryStop"
+	AfmDRApplyUpdates           string = "applyUpdates"
+	AfmDRResync                 string = "resync"
+	AfmDRDisable                string = "disable"
+)
+
+// Changes reported by mmsnapdiff between two snapshots of a fileset
+const (
+	SnapshotChangeCreated  string = "created"
+	SnapshotChangeModified string = "modified"
+	SnapshotChangeDeleted  string = "deleted"
+)
+
 func GetSpectrumScaleConnector(ctx context.Context, config settings.Clusters) (SpectrumScaleConnector, error) {
 	klog.V(4).Infof("[%s] connector GetSpectrumScaleConnector", utils.GetLoggerId(ctx))
-	return NewSpectrumRestV2(ctx, config)
+	conn, err := NewSpectrumRestV2(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+	return newAuditedConnector(conn, config), nil
 }
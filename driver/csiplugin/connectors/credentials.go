@@ -0,0 +1,148 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package connectors
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// Keys of the GUI credentials in the secrets of a CSI request, the same
+	// keys as in the secret of a cluster, and the ID of the cluster whose
+	// GUI the credentials are for
+	CredentialsUsername  = "username"
+	CredentialsPassword  = "password" // #nosec G101 false positive
+	CredentialsClusterID = "clusterId"
+
+	// credentialClientIdleTimeout is the time after which the client of
+	// credentials which are not used anymore, e.g. after the password of a
+	// secret was changed, is removed
+	credentialClientIdleTimeout = 30 * time.Minute
+)
+
+// Credentials of a GUI user which are used for the requests of a context to
+// the GUI of the cluster ClusterID instead of the credentials of the
+// cluster. The requests to other clusters, like the primary cluster of a
+// volume in a remote cluster, use the credentials of those clusters.
+type Credentials struct {
+	ClusterID string
+	Username  string
+	Password  string
+}
+
+type credentialsKey struct{}
+
+// WithCredentials returns a context whose GUI requests are authenticated
+// with creds.
+func WithCredentials(ctx context.Context, creds Credentials) context.Context {
+	return context.WithValue(ctx, credentialsKey{}, creds)
+}
+
+// HasCredentials returns whether the GUI requests of ctx use the credentials
+// of a request for any cluster.
+func HasCredentials(ctx context.Context) bool {
+	_, ok := ctx.Value(credentialsKey{}).(Credentials)
+	return ok
+}
+
+// credentialsForCluster returns the credentials of ctx if they are for the
+// cluster clusterID.
+func credentialsForCluster(ctx context.Context, clusterID string) (Credentials, bool) {
+	creds, ok := ctx.Value(credentialsKey{}).(Credentials)
+	if !ok || creds.ClusterID != clusterID {
+		return Credentials{}, false
+	}
+	return creds, true
+}
+
+// CredentialsFromSecrets returns the GUI credentials in the secrets of a CSI
+// request and whether there are any. Secrets without the keys, like the
+// secrets of cache volumes, have none.
+func CredentialsFromSecrets(secrets map[string]string) (Credentials, bool, error) {
+	username, hasUsername := secrets[CredentialsUsername]
+	password, hasPassword := secrets[CredentialsPassword]
+	if !hasUsername && !hasPassword {
+		return Credentials{}, false, nil
+	}
+	creds := Credentials{
+		ClusterID: strings.TrimSpace(secrets[CredentialsClusterID]),
+		Username:  strings.TrimSpace(username),
+		Password:  strings.TrimSuffix(password, "\n"),
+	}
+	if creds.Username == "" || creds.Password == "" {
+		return Credentials{}, false, fmt.Errorf("the secret must contain both %s and %s", CredentialsUsername, CredentialsPassword)
+	}
+	if creds.ClusterID == "" {
+		return Credentials{}, false, fmt.Errorf("the secret must contain the %s of the cluster the credentials are for", CredentialsClusterID)
+	}
+	return creds, true, nil
+}
+
+type credentialClientKey struct {
+	connector *SpectrumRestV2
+	// hash of the username and password, the password is not kept in the key
+	credentials string
+}
+
+type credentialClient struct {
+	client   *http.Client
+	lastUsed time.Time
+}
+
+var (
+	credentialClients     = map[credentialClientKey]*credentialClient{}
+	credentialClientsLock sync.Mutex
+)
+
+// credentialHTTPClient returns the client of the connector for creds. Each
+// credential gets its own connections to the GUI, so the sessions of
+// different users are never shared.
+func (s *SpectrumRestV2) credentialHTTPClient(creds Credentials) *http.Client {
+	hash := sha256.Sum256([]byte(creds.Username + "\x00" + creds.Password))
+	key := credentialClientKey{connector: s, credentials: hex.EncodeToString(hash[:])}
+	now := time.Now()
+
+	credentialClientsLock.Lock()
+	defer credentialClientsLock.Unlock()
+	for k, c := range credentialClients {
+		if now.Sub(c.lastUsed) > credentialClientIdleTimeout {
+			if tr, ok := c.client.Transport.(*http.Transport); ok {
+				tr.CloseIdleConnections()
+			}
+			delete(credentialClients, k)
+		}
+	}
+	if c, ok := credentialClients[key]; ok {
+		c.lastUsed = now
+		return c.client
+	}
+
+	transport := s.HTTPclient.Transport
+	if tr, ok := transport.(*http.Transport); ok {
+		transport = tr.Clone()
+	}
+	c := &credentialClient{client: &http.Client{Transport: transport, Timeout: s.HTTPclient.Timeout}, lastUsed: now}
+	credentialClients[key] = c
+	return c.client
+}
@@ -0,0 +1,128 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package connectors
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/IBM/ibm-spectrum-scale-csi/driver/csiplugin/settings"
+)
+
+func TestCredentialsFromSecrets(t *testing.T) {
+	tests := []struct {
+		name    string
+		secrets map[string]string
+		want    Credentials
+		found   bool
+		wantErr bool
+	}{
+		{"no secrets", nil, Credentials{}, false, false},
+		{"secrets without credentials", map[string]string{"accesskey": "key"}, Credentials{}, false, false},
+		{
+			name:    "credentials",
+			secrets: map[string]string{CredentialsUsername: " tenant1\n", CredentialsPassword: "pass word\n", CredentialsClusterID: "123"},
+			want:    Credentials{ClusterID: "123", Username: "tenant1", Password: "pass word"},
+			found:   true,
+		},
+		{"username only", map[string]string{CredentialsUsername: "tenant1", CredentialsClusterID: "123"}, Credentials{}, false, true},
+		{"password only", map[string]string{CredentialsPassword: "secret", CredentialsClusterID: "123"}, Credentials{}, false, true},
+		{"empty password", map[string]string{CredentialsUsername: "tenant1", CredentialsPassword: "\n", CredentialsClusterID: "123"}, Credentials{}, false, true},
+		{"without cluster", map[string]string{CredentialsUsername: "tenant1", CredentialsPassword: "secret"}, Credentials{}, false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, found, err := CredentialsFromSecrets(tt.secrets)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CredentialsFromSecrets() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want || found != tt.found {
+				t.Errorf("CredentialsFromSecrets() = %+v, %v, want %+v, %v", got, found, tt.want, tt.found)
+			}
+		})
+	}
+}
+
+func TestCredentialsForCluster(t *testing.T) {
+	creds := Credentials{ClusterID: "123", Username: "tenant1", Password: "secret"}
+	ctx := WithCredentials(context.Background(), creds)
+
+	if got, ok := credentialsForCluster(ctx, "123"); !ok || got != creds {
+		t.Errorf("credentialsForCluster(123) = %+v, %v, want %+v", got, ok, creds)
+	}
+	// the primary or a remote cluster keeps its own credentials
+	if _, ok := credentialsForCluster(ctx, "456"); ok {
+		t.Errorf("credentialsForCluster(456) returned the credentials of cluster 123")
+	}
+	if _, ok := credentialsForCluster(context.Background(), "123"); ok {
+		t.Errorf("credentialsForCluster() returned credentials of a context without them")
+	}
+	if !HasCredentials(ctx) || HasCredentials(context.Background()) {
+		t.Errorf("HasCredentials() does not match the context")
+	}
+}
+
+func testConnector(clusterID string) *SpectrumRestV2 {
+	return &SpectrumRestV2{
+		HTTPclient:    &http.Client{Transport: &http.Transport{}, Timeout: time.Minute},
+		ClusterConfig: settings.Clusters{ID: clusterID},
+	}
+}
+
+func TestCredentialHTTPClient(t *testing.T) {
+	credentialClients = map[credentialClientKey]*credentialClient{}
+	defer func() { credentialClients = map[credentialClientKey]*credentialClient{} }()
+
+	conn, other := testConnector("123"), testConnector("456")
+	tenant1 := Credentials{ClusterID: "123", Username: "tenant1", Password: "secret"}
+	tenant2 := Credentials{ClusterID: "123", Username: "tenant2", Password: "secret"}
+
+	client := conn.credentialHTTPClient(tenant1)
+	if client == conn.HTTPclient || client.Timeout != conn.HTTPclient.Timeout {
+		t.Errorf("credentialHTTPClient() = %+v, want a new client with the timeout of the connector", client)
+	}
+	if conn.credentialHTTPClient(tenant1) != client {
+		t.Errorf("credentialHTTPClient() did not return the cached client of the same credentials")
+	}
+	if conn.credentialHTTPClient(tenant2) == client {
+		t.Errorf("credentialHTTPClient() shared the client of another user")
+	}
+	changed := tenant1
+	changed.Password = "changed"
+	if conn.credentialHTTPClient(changed) == client {
+		t.Errorf("credentialHTTPClient() shared the client of a changed password")
+	}
+	if other.credentialHTTPClient(tenant1) == client {
+		t.Errorf("credentialHTTPClient() shared the client of another connector")
+	}
+	if len(credentialClients) != 4 {
+		t.Errorf("cached %d clients, want 4", len(credentialClients))
+	}
+
+	// the clients which were not used for the idle timeout are removed
+	for _, c := range credentialClients {
+		c.lastUsed = time.Now().Add(-credentialClientIdleTimeout - time.Second)
+	}
+	if conn.credentialHTTPClient(tenant1) == client {
+		t.Errorf("credentialHTTPClient() returned an expired client")
+	}
+	if len(credentialClients) != 1 {
+		t.Errorf("cached %d clients after the idle timeout, want 1", len(credentialClients))
+	}
+}
@@ -279,6 +279,16 @@ type CopySnapshotRequest struct {
 	Force            bool   `json:"force,omitempty"`
 }
 
+type SnapshotDiffRequest struct {
+	BaseSnapshot string `json:"baseSnapshot"`
+	Path         string `json:"path,omitempty"`
+}
+
+type SnapshotChange struct {
+	Path       string
+	ChangeType string
+}
+
 type SnapshotCloneCopyRequest struct {
 	TargetFilesystem string `json:"targetFilesystem,omitempty"`
 	TargetFileset    string `json:"targetFileset,omitempty"`
@@ -476,6 +486,7 @@ type Info struct {
 
 type Path struct {
 	SnapCopyOp []string `json:"/filesystems/{filesystemName}/filesets/{filesetName}/snapshotCopy/{snapshotName},omitempty"`
+	SnapDiffOp []string `json:"/filesystems/{filesystemName}/filesets/{filesetName}/snapshotDiff/{snapshotName},omitempty"`
 }
 
 type NodeConfig struct {
@@ -550,6 +561,7 @@ type GetSnapshotResponse_v2 struct {
 type Fileset_v2 struct {
 	AFM         AFM              `json:"afm,omitempty"`
 	Config      FilesetConfig_v2 `json:"config,omitempty"`
+	State       FilesetState     `json:"state,omitempty"`
 	FilesetName string           `json:"filesetName,omitempty"`
 }
 
@@ -627,6 +639,7 @@ type UnlinkFilesetRequest struct {
 
 type CreateFilesetRequest struct {
 	FilesetName                  string `json:"filesetName,omitempty"`
+	NewFilesetName               string `json:"newFilesetName,omitempty"`
 	Path                         string `json:"path,omitempty"`
 	Owner                        string `json:"owner,omitempty"`
 	Permissions                  string `json:"permissions,omitempty"`
@@ -659,6 +672,15 @@ type CreateFilesetRequest struct {
 	MakeActive                   bool   `json:"makeActive,omitempty"`
 }
 
+type AFMDRCommandRequest struct {
+	Action       string `json:"action"`
+	AfmTarget    string `json:"afmTarget,omitempty"`
+	AfmRPO       int    `json:"afmRPO,omitempty"`
+	AfmPrimaryID string `json:"afmPrimaryID,omitempty"`
+	Inband       bool   `json:"inband,omitempty"`
+	NoRestore    bool   `json:"norestore,omitempty"`
+}
+
 type CreateS3CacheFilesetRequest struct {
 	FilesetName      string `json:"filesetName"`
 	Mode             string `json:"mode"`
@@ -717,6 +739,11 @@ type Policy struct {
 	Priority  int    `json:"priority,omitempty"`
 }
 
+type GetPolicyResponse struct {
+	Policies []Policy `json:"policies,omitempty"`
+	Status   Status   `json:"status,omitempty"`
+}
+
 type StorageTiers struct {
 	StorageTiers []StorageTier `json:"storagePool,omitempty"`
 	Status       Status        `json:"status,omitempty"`
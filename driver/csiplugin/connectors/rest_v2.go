@@ -26,6 +26,7 @@ import (
 	"strconv"
 	"strings"
 	"time"
+	"unicode"
 
 	"github.com/IBM/ibm-spectrum-scale-csi/driver/csiplugin/settings"
 	"github.com/IBM/ibm-spectrum-scale-csi/driver/csiplugin/utils"
@@ -142,6 +143,34 @@ func (s *SpectrumRestV2) WaitForJobCompletionWithResp(ctx context.Context, statu
 	return GenericResponse{}, nil
 }
 
+func (s *SpectrumRestV2) GetJob(ctx context.Context, jobID uint64) (Job, error) {
+	klog.V(6).Infof("[%s] rest_v2 GetJob. jobID: %d", utils.GetLoggerId(ctx), jobID)
+
+	jobURL := fmt.Sprintf("scalemgmt/v2/jobs/%d?fields=:all:", jobID)
+	jobQueryResponse := GenericResponse{}
+	err := s.doHTTP(ctx, jobURL, "GET", &jobQueryResponse, nil)
+	if err != nil {
+		return Job{}, err
+	}
+	if len(jobQueryResponse.Jobs) == 0 {
+		return Job{}, fmt.Errorf("unable to get Job details for %s: %v", jobURL, jobQueryResponse)
+	}
+	return jobQueryResponse.Jobs[0], nil
+}
+
+func (s *SpectrumRestV2) CancelJob(ctx context.Context, jobID uint64) error {
+	klog.V(4).Infof("[%s] rest_v2 CancelJob. jobID: %d", utils.GetLoggerId(ctx), jobID)
+
+	cancelURL := fmt.Sprintf("scalemgmt/v2/jobs/%d/cancel", jobID)
+	cancelResponse := GenericResponse{}
+	err := s.doHTTP(ctx, cancelURL, "PUT", &cancelResponse, nil)
+	if err != nil {
+		klog.Errorf("[%s] Error in cancel request for job %d: %v", utils.GetLoggerId(ctx), jobID, err)
+		return err
+	}
+	return nil
+}
+
 func (s *SpectrumRestV2) AsyncJobCompletion(ctx context.Context, jobURL string) (GenericResponse, error) {
 	klog.V(4).Infof("[%s] rest_v2 AsyncJobCompletion. jobURL: %s", utils.GetLoggerId(ctx), jobURL)
 
@@ -622,6 +651,10 @@ func (s *SpectrumRestV2) UpdateFileset(ctx context.Context, filesystemName strin
 	if commentSpecified {
 		filesetreq.Comment = fmt.Sprintf("%v", comment)
 	}
+	newName, newNameSpecified := opts[FilesetNewNameKey]
+	if newNameSpecified {
+		filesetreq.NewFilesetName = fmt.Sprintf("%v", newName)
+	}
 
 	if volType == cacheVolumeType && setAfmAttributes != "" {
 		if setAfmAttributes == settings.NfsCache {
@@ -857,6 +890,74 @@ func (s *SpectrumRestV2) CreateFileset(ctx context.Context, filesystemName strin
 	return nil
 }
 
+// CreateAFMDRSecondaryFileset creates an independent fileset in AFM-DR
+// secondary mode for the primary fileset with the given AFM primary ID.
+func (s *SpectrumRestV2) CreateAFMDRSecondaryFileset(ctx context.Context, filesystemName string, filesetName string, afmPrimaryID string, comment string) error {
+	loggerId := utils.GetLoggerId(ctx)
+	klog.V(4).Infof("[%s] rest_v2 CreateAFMDRSecondaryFileset. filesystem: %s, fileset: %s, primaryID: %s", loggerId, filesystemName, filesetName, afmPrimaryID)
+
+	filesetreq := CreateFilesetRequest{}
+	filesetreq.FilesetName = filesetName
+	filesetreq.Comment = comment
+	filesetreq.InodeSpace = "new"
+	filesetreq.AfmMode = AfmModeSecondary
+	filesetreq.AfmPrimaryID = afmPrimaryID
+
+	createFilesetURL := fmt.Sprintf("scalemgmt/v2/filesystems/%s/filesets", filesystemName)
+	createFilesetResponse := GenericResponse{}
+
+	err := s.doHTTP(ctx, createFilesetURL, "POST", &createFilesetResponse, filesetreq)
+	if err != nil {
+		klog.Errorf("[%s] Error in create secondary fileset request: %v", loggerId, err)
+		return err
+	}
+
+	err = s.isRequestAccepted(ctx, createFilesetResponse, createFilesetURL)
+	if err != nil {
+		klog.Errorf("[%s] Request not accepted for processing: %v", loggerId, err)
+		return err
+	}
+
+	err = s.WaitForJobCompletion(ctx, createFilesetResponse.Status.Code, createFilesetResponse.Jobs[0].JobID)
+	if err != nil {
+		if strings.Contains(err.Error(), "EFSSP1102C") { // job failed as fileset already exists
+			return nil
+		}
+		klog.Errorf("[%s] Unable to create secondary fileset %s: %v", loggerId, filesetName, err)
+		return err
+	}
+	return nil
+}
+
+// RunAFMDRCommand runs an mmafmctl AFM-DR command like failoverToSecondary
+// on a fileset and waits for it to complete.
+func (s *SpectrumRestV2) RunAFMDRCommand(ctx context.Context, filesystemName string, filesetName string, afmdrReq AFMDRCommandRequest) error {
+	loggerId := utils.GetLoggerId(ctx)
+	klog.V(4).Infof("[%s] rest_v2 RunAFMDRCommand. filesystem: %s, fileset: %s, request: %+v", loggerId, filesystemName, filesetName, afmdrReq)
+
+	afmctlURL := fmt.Sprintf("scalemgmt/v2/filesystems/%s/filesets/%s/afmctl", filesystemName, filesetName)
+	afmctlResponse := GenericResponse{}
+
+	err := s.doHTTP(ctx, afmctlURL, "PUT", &afmctlResponse, afmdrReq)
+	if err != nil {
+		klog.Errorf("[%s] Error in AFM-DR %s request for fileset %s: %v", loggerId, afmdrReq.Action, filesetName, err)
+		return err
+	}
+
+	err = s.isRequestAccepted(ctx, afmctlResponse, afmctlURL)
+	if err != nil {
+		klog.Errorf("[%s] Request not accepted for processing: %v", loggerId, err)
+		return err
+	}
+
+	err = s.WaitForJobCompletion(ctx, afmctlResponse.Status.Code, afmctlResponse.Jobs[0].JobID)
+	if err != nil {
+		klog.Errorf("[%s] Unable to run AFM-DR %s for fileset %s: %v", loggerId, afmdrReq.Action, filesetName, err)
+		return err
+	}
+	return nil
+}
+
 func (s *SpectrumRestV2) SetBucketKeys(ctx context.Context, bucketInfo map[string]string, exportMapName string) error {
 	loggerID := utils.GetLoggerId(ctx)
 	klog.V(4).Infof("[%s] rest_v2 SetBucketKeys", loggerID)
@@ -1203,6 +1304,26 @@ func (s *SpectrumRestV2) ListFileset(ctx context.Context, filesystemName string,
 	return getFilesetResponse.Filesets[0], nil
 }
 
+// ListFilesets returns all filesets of a filesystem, following the paging of the GUI.
+func (s *SpectrumRestV2) ListFilesets(ctx context.Context, filesystemName string) ([]Fileset_v2, error) {
+	loggerID := utils.GetLoggerId(ctx)
+	klog.V(4).Infof("[%s] rest_v2 ListFilesets. filesystem: %s", loggerID, filesystemName)
+
+	getFilesetsURL := fmt.Sprintf("scalemgmt/v2/filesystems/%s/filesets?fields=:all:", filesystemName)
+	filesets := []Fileset_v2{}
+	for getFilesetsURL != "" {
+		getFilesetsResponse := GetFilesetResponse_v2{}
+		err := s.doHTTP(ctx, getFilesetsURL, "GET", &getFilesetsResponse, nil)
+		if err != nil {
+			klog.Errorf("[%s] Error in list filesets request: %v", loggerID, err)
+			return nil, err
+		}
+		filesets = append(filesets, getFilesetsResponse.Filesets...)
+		getFilesetsURL = strings.TrimPrefix(getFilesetsResponse.Paging.Next, "/")
+	}
+	return filesets, nil
+}
+
 func (s *SpectrumRestV2) CheckFilesetWithAFMTarget(ctx context.Context, filesystemName string, afmTarget string) (string, error) {
 	loggerID := utils.GetLoggerId(ctx)
 	klog.V(4).Infof("[%s] rest_v2 CheckFilesetWithAFMTarget. filesystem: %s, afmTarget: %s", loggerID, filesystemName, afmTarget)
@@ -1503,6 +1624,23 @@ func (s *SpectrumRestV2) IsSnapshotSupported(ctx context.Context) (bool, error)
 	return true, nil
 }
 
+// IsSnapshotDiffSupported returns whether the GUI of the cluster runs
+// mmsnapdiff for SnapshotDiff.
+func (s *SpectrumRestV2) IsSnapshotDiffSupported(ctx context.Context) (bool, error) {
+	klog.V(4).Infof("[%s] rest_v2 IsSnapshotDiffSupported", utils.GetLoggerId(ctx))
+
+	getVersionURL := "scalemgmt/v2/info"
+	getVersionResponse := GetInfoResponse_v2{}
+
+	err := s.doHTTP(ctx, getVersionURL, "GET", &getVersionResponse, nil)
+	if err != nil {
+		klog.Errorf("[%s] Unable to get cluster information: [%v]", utils.GetLoggerId(ctx), err)
+		return false, err
+	}
+
+	return len(getVersionResponse.Info.Paths.SnapDiffOp) != 0, nil
+}
+
 func (s *SpectrumRestV2) GetFilesetQuotaDetails(ctx context.Context, filesystemName string, filesetName string) (Quota_v2, error) {
 	klog.V(4).Infof("[%s] rest_v2 GetFilesetQuotaDetails. filesystem: %s, fileset: %s", utils.GetLoggerId(ctx), filesystemName, filesetName)
 
@@ -1552,8 +1690,14 @@ func (s *SpectrumRestV2) doHTTP(ctx context.Context, urlSuffix string, method st
 	klog.V(4).Infof("[%s] rest_v2 doHTTP: urlSuffix: %s, method: %s, param: %v", utils.GetLoggerId(ctx), urlSuffix, method, paramToLog)
 	endpoint := s.Endpoint[s.EndPointIndex]
 	klog.V(4).Infof("[%s] rest_v2 doHTTP: endpoint: %s", utils.GetLoggerId(ctx), endpoint)
+	httpClient := s.HTTPclient
 	var user, password string
-	if s.RequestCalledBy == "operator" {
+	if creds, ok := credentialsForCluster(ctx, s.ClusterConfig.ID); ok {
+		klog.V(4).Infof("[%s] rest_v2 doHTTP: using the credentials of the request", utils.GetLoggerId(ctx))
+		user = creds.Username
+		password = creds.Password
+		httpClient = s.credentialHTTPClient(creds)
+	} else if s.RequestCalledBy == "operator" {
 		klog.V(0).Infof("[%s] rest_v2 doHTTP: requested by operator", utils.GetLoggerId(ctx))
 		user = s.ClusterConfig.MgmtUsername
 		password = s.ClusterConfig.MgmtPassword
@@ -1568,7 +1712,7 @@ func (s *SpectrumRestV2) doHTTP(ctx context.Context, urlSuffix string, method st
 	}
 
 	klog.V(4).Infof("[%s] rest_v2 doHTTP: setting user [%s] and password", utils.GetLoggerId(ctx), user)
-	response, err := utils.HttpExecuteUserAuth(ctx, s.HTTPclient, method, endpoint+urlSuffix, user, password, param)
+	response, err := utils.HttpExecuteUserAuth(ctx, httpClient, method, endpoint+urlSuffix, user, password, param)
 
 	activeEndpointFound := false
 	if err != nil {
@@ -1579,7 +1723,7 @@ func (s *SpectrumRestV2) doHTTP(ctx context.Context, urlSuffix string, method st
 			n := len(s.Endpoint)
 			for i := 0; i < n-1; i++ {
 				endpoint = s.getNextEndpoint(ctx)
-				response, err = utils.HttpExecuteUserAuth(ctx, s.HTTPclient, method, endpoint+urlSuffix, user, password, param)
+				response, err = utils.HttpExecuteUserAuth(ctx, httpClient, method, endpoint+urlSuffix, user, password, param)
 				if err == nil {
 					activeEndpointFound = true
 					break
@@ -1986,6 +2130,66 @@ func (s *SpectrumRestV2) ListFilesetSnapshots(ctx context.Context, filesystemNam
 	return listFilesetSnapshotResponse.Snapshots, nil
 }
 
+// SnapshotDiff runs mmsnapdiff between baseSnapshot and targetSnapshot of a
+// fileset as a job of the GUI and returns the files created, modified or
+// deleted below path, relative to the root of the fileset.
+func (s *SpectrumRestV2) SnapshotDiff(ctx context.Context, filesystemName string, filesetName string, baseSnapshot string, targetSnapshot string, path string) ([]SnapshotChange, error) {
+	loggerId := utils.GetLoggerId(ctx)
+	klog.V(4).Infof("[%s] rest_v2 SnapshotDiff. filesystem: %s, fileset: %s, base snapshot: %s, target snapshot: %s, path: %s", loggerId, filesystemName, filesetName, baseSnapshot, targetSnapshot, path)
+
+	snapDiffURL := fmt.Sprintf("scalemgmt/v2/filesystems/%s/filesets/%s/snapshotDiff/%s", filesystemName, filesetName, targetSnapshot)
+	snapDiffReq := SnapshotDiffRequest{BaseSnapshot: baseSnapshot, Path: path}
+	snapDiffResponse := GenericResponse{}
+
+	err := s.doHTTP(ctx, snapDiffURL, "PUT", &snapDiffResponse, snapDiffReq)
+	if err != nil {
+		klog.Errorf("[%s] Error in snapshot diff request: %v", loggerId, err)
+		return nil, err
+	}
+
+	err = s.isRequestAccepted(ctx, snapDiffResponse, snapDiffURL)
+	if err != nil {
+		klog.Errorf("[%s] request not accepted for processing: %v", loggerId, err)
+		return nil, err
+	}
+
+	jobResponse, err := s.WaitForJobCompletionWithResp(ctx, snapDiffResponse.Status.Code, snapDiffResponse.Jobs[0].JobID)
+	if err != nil {
+		klog.Errorf("[%s] unable to compare snapshots %s and %s of fileset %s, error: %v", loggerId, baseSnapshot, targetSnapshot, filesetName, err)
+		return nil, err
+	}
+	if len(jobResponse.Jobs) == 0 {
+		return nil, fmt.Errorf("unable to get the result of the snapshot diff job %d", snapDiffResponse.Jobs[0].JobID)
+	}
+	return parseSnapshotDiff(jobResponse.Jobs[0].Result.Stdout), nil
+}
+
+// parseSnapshotDiff parses the report of mmsnapdiff, one change per line
+// given by its operation and the path: + for created, - for deleted and any
+// other operation, like <> for changed content or attributes, for modified.
+// The header and the summary lines are skipped.
+func parseSnapshotDiff(stdout []string) []SnapshotChange {
+	var changes []SnapshotChange
+	for _, output := range stdout {
+		for _, line := range strings.Split(output, "\n") {
+			operation, changedPath, found := strings.Cut(strings.TrimSpace(line), " ")
+			changedPath = strings.TrimSpace(changedPath)
+			if !found || changedPath == "" || strings.IndexFunc(operation, unicode.IsLetter) >= 0 {
+				continue
+			}
+			change := SnapshotChange{Path: strings.TrimPrefix(changedPath, "/"), ChangeType: SnapshotChangeModified}
+			switch operation {
+			case "+":
+				change.ChangeType = SnapshotChangeCreated
+			case "-":
+				change.ChangeType = SnapshotChangeDeleted
+			}
+			changes = append(changes, change)
+		}
+	}
+	return changes
+}
+
 func (s *SpectrumRestV2) CheckIfFileDirPresent(ctx context.Context, filesystemName string, relPath string) (bool, error) {
 	klog.V(4).Infof("[%s] rest_v2 CheckIfFileDirPresent. filesystem: %s, path: %s", utils.GetLoggerId(ctx), filesystemName, relPath)
 
@@ -2077,6 +2281,33 @@ func (s *SpectrumRestV2) SetFilesystemPolicy(ctx context.Context, policy *Policy
 	return nil
 }
 
+func (s *SpectrumRestV2) DeletePolicyPartition(ctx context.Context, partitionName string, filesystemName string) error {
+	loggerId := GetLoggerId(ctx)
+	klog.V(4).Infof("[%s] rest_v2 DeletePolicyPartition. name %s, filesystem %s", loggerId, partitionName, filesystemName)
+
+	partitionURL := fmt.Sprintf("scalemgmt/v2/filesystems/%s/partition/%s", filesystemName, partitionName)
+	deletePartitionResponse := GenericResponse{}
+
+	err := s.doHTTP(ctx, partitionURL, "DELETE", &deletePartitionResponse, nil)
+	if err != nil {
+		klog.Errorf("[%s] unable to delete policy partition %s for filesystem %s: %v", loggerId, partitionName, filesystemName, deletePartitionResponse.Status.Message)
+		return err
+	}
+
+	err = s.isRequestAccepted(ctx, deletePartitionResponse, partitionURL)
+	if err != nil {
+		return err
+	}
+
+	err = s.WaitForJobCompletion(ctx, deletePartitionResponse.Status.Code, deletePartitionResponse.Jobs[0].JobID)
+	if err != nil {
+		klog.Errorf("[%s] deleting policy partition %s for filesystem %s failed with error %v", loggerId, partitionName, filesystemName, err)
+		return err
+	}
+
+	return nil
+}
+
 func (s *SpectrumRestV2) DoesTierExist(ctx context.Context, tierName string, filesystemName string) error {
 	loggerId := GetLoggerId(ctx)
 	klog.V(4).Infof("[%s] rest_v2 DoesTierExist. name %s, filesystem %s", loggerId, tierName, filesystemName)
@@ -2123,6 +2354,25 @@ func (s *SpectrumRestV2) CheckIfDefaultPolicyPartitionExists(ctx context.Context
 	return err == nil
 }
 
+func (s *SpectrumRestV2) GetFilesystemPolicyPartition(ctx context.Context, partitionName string, filesystemName string) (Policy, error) {
+	loggerId := GetLoggerId(ctx)
+	klog.V(4).Infof("[%s] rest_v2 GetFilesystemPolicyPartition. name %s, filesystem %s", loggerId, partitionName, filesystemName)
+
+	partitionURL := fmt.Sprintf("scalemgmt/v2/filesystems/%s/partition/%s", filesystemName, partitionName)
+	getPartitionResponse := GetPolicyResponse{}
+
+	err := s.doHTTP(ctx, partitionURL, "GET", &getPartitionResponse, nil)
+	if err != nil {
+		klog.Errorf("[%s] unable to get policy partition %s for filesystem %s: %v", loggerId, partitionName, filesystemName, err)
+		return Policy{}, err
+	}
+
+	if len(getPartitionResponse.Policies) == 0 {
+		return Policy{}, fmt.Errorf("unable to fetch policy partition %s for filesystem %s", partitionName, filesystemName)
+	}
+	return getPartitionResponse.Policies[0], nil
+}
+
 func (s *SpectrumRestV2) GetFirstDataTier(ctx context.Context, filesystemName string) (string, error) {
 	loggerId := GetLoggerId(ctx)
 	klog.V(4).Infof("[%s] rest_v2 GetFirstDataTier. filesystem %s", loggerId, filesystemName)
@@ -0,0 +1,37 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package connectors
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseSnapshotDiff(t *testing.T) {
+	stdout := []string{
+		"Operation  Path\n+ data/new file\n- data/old\n<> data/changed\n",
+		"Files created: 1\nFiles deleted: 1\nFiles changed: 1\n",
+	}
+	want := []SnapshotChange{
+		{Path: "data/new file", ChangeType: SnapshotChangeCreated},
+		{Path: "data/old", ChangeType: SnapshotChangeDeleted},
+		{Path: "data/changed", ChangeType: SnapshotChangeModified},
+	}
+	if got := parseSnapshotDiff(stdout); !reflect.DeepEqual(got, want) {
+		t.Errorf("parseSnapshotDiff() = %v, want %v", got, want)
+	}
+}
@@ -22,11 +22,16 @@ import (
 	"net/url"
 	"path/filepath"
 	"reflect"
+	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/IBM/ibm-spectrum-scale-csi/driver/csiplugin/connectors"
+	"github.com/IBM/ibm-spectrum-scale-csi/driver/csiplugin/pkg/audit"
+	"github.com/IBM/ibm-spectrum-scale-csi/driver/csiplugin/pkg/journal"
+	"github.com/IBM/ibm-spectrum-scale-csi/driver/csiplugin/pkg/scalehandle"
 	"github.com/IBM/ibm-spectrum-scale-csi/driver/csiplugin/settings"
 	"github.com/IBM/ibm-spectrum-scale-csi/driver/csiplugin/utils"
 	"github.com/container-storage-interface/spec/lib/go/csi"
@@ -35,7 +40,11 @@ import (
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/klog/v2"
 )
 
@@ -54,6 +63,11 @@ const (
 	defaultSnapWindow                 = "30" // default snapWindow for Consistency Group snapshots is 30 minutes
 	softQuotaPercent                  = 70   // This value is % of the hardQuotaLimit e.g. 70%
 	intermittentFusionSnapshot        = "csiclone"
+	maxSnapshotsPerFileset            = 256
+	// revertMarkerDir exists in the fileset of a volume while its data
+	// directory is reverted to a snapshot
+	revertMarkerDir      = ".csi-revert"
+	snapshotPrunedReason = "SnapshotPruned"
 
 	discoverCGFilesetDisabled = "DISABLED"
 
@@ -62,24 +76,19 @@ const (
 	refreshInterval     = 2147483647 //refresh Interval for afm tuning parameters
 )
 
+var (
+	replicateRuleRegex = regexp.MustCompile(`REPLICATE\((\d+)\)`)
+	statOwnerRegex     = regexp.MustCompile(`Access: \((\d+)/\S+\)\s+Uid: \(\s*(\d+)/.*Gid: \(\s*(\d+)/`)
+	// snapshots created through the external-snapshotter are named snapshot-<VolumeSnapshot UID>
+	csiSnapshotNameRegex     = regexp.MustCompile(`^snapshot-[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`)
+	volumeSnapshotContentGVR = schema.GroupVersionResource{Group: "snapshot.storage.k8s.io", Version: "v1", Resource: "volumesnapshotcontents"}
+)
+
 type ScaleControllerServer struct {
 	Driver *ScaleDriver
 	csi.UnimplementedControllerServer
 }
 
-func (cs *ScaleControllerServer) IfSameVolReqInProcess(scVol *scaleVolume) (bool, error) {
-	capacity, volpresent := cs.Driver.reqmap[scVol.VolName]
-	if volpresent {
-		/*  #nosec G115 -- false positive  */
-		if capacity == int64(scVol.VolSize) {
-			return true, nil
-		} else {
-			return false, status.Error(codes.Internal, fmt.Sprintf("Volume %v present in map but requested size %v does not match with size %v in map", scVol.VolName, scVol.VolSize, capacity))
-		}
-	}
-	return false, nil
-}
-
 // createLWVol: Create lightweight volume - return relative path of directory created
 func (cs *ScaleControllerServer) createLWVol(ctx context.Context, scVol *scaleVolume) (string, error) {
 	loggerId := utils.GetLoggerId(ctx)
@@ -107,6 +116,7 @@ func (cs *ScaleControllerServer) createLWVol(ctx context.Context, scVol *scaleVo
 		klog.Errorf("[%s] volume:[%v] - failed to create directory %v. Error : %v", loggerId, scVol.VolName, dirPath, err)
 		return "", status.Error(codes.Internal, err.Error())
 	}
+	cs.journalStep(ctx, scVol.VolName, journalStepDirectoryCreated)
 	return dirPath, nil
 }
 
@@ -118,7 +128,6 @@ func (cs *ScaleControllerServer) generateVolID(ctx context.Context, scVol *scale
 	loggerId := utils.GetLoggerId(ctx)
 	klog.Infof("[%s] volume: [%v] - ControllerServer:generateVolId", loggerId, scVol.VolName)
 	klog.V(4).Infof("[%s] scVol: [%+v] - ControllerServer:generateVolId targetPath:[%v]", loggerId, scVol, targetPath)
-	var volID string
 	var storageClassType string
 	var volumeType string
 
@@ -181,7 +190,19 @@ func (cs *ScaleControllerServer) generateVolID(ctx context.Context, scVol *scale
 		filesetName = filesetNameStatic
 	}
 
-	volID = fmt.Sprintf("%s;%s;%s;%s;%s;%s;%s", storageClassType, volumeType, scVol.ClusterId, uid, consistencyGroup, filesetName, path)
+	volID, err := scalehandle.Volume{
+		Version:          scalehandle.Latest,
+		StorageClassType: storageClassType,
+		VolumeType:       volumeType,
+		ClusterID:        scVol.ClusterId,
+		FilesystemUUID:   uid,
+		ConsistencyGroup: consistencyGroup,
+		FilesetName:      filesetName,
+		Path:             path,
+	}.Encode()
+	if err != nil {
+		return "", status.Error(codes.Internal, fmt.Sprintf("unable to encode volume ID for volume [%v]. Error [%v]", scVol.VolName, err))
+	}
 	klog.V(4).Infof("[%s] ControllerServer:generateVolId: volID [%v] ", loggerId, volID)
 	return volID, nil
 }
@@ -440,6 +461,18 @@ func (cs *ScaleControllerServer) createFilesetBasedVol(ctx context.Context, scVo
 			opt[connectors.UserSpecifiedParentFset] = scVol.ParentFileset
 		}
 
+		// Claim a pre-created fileset of the warm pool of the storageClass,
+		// the filesets of the pool are created with the credentials of the
+		// cluster, so a request with its own credentials creates its fileset
+		if scVol.WarmPoolSize > 0 && connectors.HasCredentials(ctx) {
+			klog.Infof("[%s] volume:[%v] - not using the warm pool for a request with credentials in its secret", loggerId, scVol.VolName)
+		} else if scVol.WarmPoolSize > 0 {
+			if _, ok := opt[connectors.UserSpecifiedFilesetType]; !ok {
+				opt[connectors.UserSpecifiedFilesetType] = independentFileset
+			}
+			cs.claimWarmPoolFileset(ctx, scVol, scVol.VolName, opt)
+		}
+
 		// Create fileset
 		klog.Infof("[%s] creating fileset for classic storageClass with fileset name: [%v]", loggerId, scVol.VolName)
 		createDataDir := true
@@ -473,6 +506,8 @@ func (cs *ScaleControllerServer) createFilesetVol(ctx context.Context, scVol *sc
 	// Check if fileset exist
 	filesetInfo, err := scVol.Connector.ListFileset(ctx, scVol.VolBackendFs, volName)
 	loggerId := utils.GetLoggerId(ctx)
+	// steps of the volume fileset are recorded in the operation journal of CreateVolume
+	journaled := !isCGIndependentFset && scVol.VolumeType != cacheVolume
 
 	if err != nil {
 		klog.Errorf("[%s] volume:[%v] - unable to list fileset [%v] in filesystem [%v]. Error: %v", loggerId, volName, volName, scVol.VolBackendFs, err)
@@ -538,6 +573,9 @@ func (cs *ScaleControllerServer) createFilesetVol(ctx context.Context, scVol *sc
 			klog.Errorf("[%s] volume:[%v] - unable to create fileset [%v] in filesystem [%v]. Error: %v", loggerId, volName, volName, scVol.VolBackendFs, fseterr)
 			return "", status.Error(codes.Internal, fmt.Sprintf("unable to create fileset [%v] in filesystem [%v]. Error: %v", volName, scVol.VolBackendFs, fseterr))
 		}
+		if journaled {
+			cs.journalStep(ctx, volName, journalStepFilesetCreated)
+		}
 		// list fileset and update filesetInfo
 		filesetInfo, err = scVol.Connector.ListFileset(ctx, scVol.VolBackendFs, volName)
 		if err != nil {
@@ -601,6 +639,9 @@ func (cs *ScaleControllerServer) createFilesetVol(ctx context.Context, scVol *sc
 			klog.Errorf("[%s] volume:[%v] - linking fileset [%v] in filesystem [%v] at path [%v] failed. Error: %v", loggerId, volName, volName, scVol.VolBackendFs, junctionPath, err)
 			return "", status.Error(codes.Internal, fmt.Sprintf("linking fileset [%v] in filesystem [%v] at path [%v] failed. Error: %v", volName, scVol.VolBackendFs, junctionPath, err))
 		}
+		if journaled {
+			cs.journalStep(ctx, volName, journalStepFilesetLinked)
+		}
 		// update fileset details
 		filesetInfo, err = scVol.Connector.ListFileset(ctx, scVol.VolBackendFs, volName)
 		if err != nil {
@@ -615,6 +656,9 @@ func (cs *ScaleControllerServer) createFilesetVol(ctx context.Context, scVol *sc
 			if err != nil {
 				return "", status.Error(codes.Internal, err.Error())
 			}
+			if journaled {
+				cs.journalStep(ctx, volName, journalStepQuotaSet)
+			}
 		}
 
 		isCacheVolume := false
@@ -631,6 +675,9 @@ func (cs *ScaleControllerServer) createFilesetVol(ctx context.Context, scVol *sc
 		if err != nil {
 			return "", status.Error(codes.Internal, err.Error())
 		}
+		if journaled {
+			cs.journalStep(ctx, volName, journalStepDirectoryCreated)
+		}
 
 		// Create a cacheTempDir inside the fileset for all the cacheModes except ro mode.
 		if scVol.VolumeType == cacheVolume && scVol.CacheMode != afmModeRO && !cacheVolId.IsNfsSupported {
@@ -745,7 +792,9 @@ func checkSCSupportedParams(params map[string]string) (string, bool) {
 			"volBackendFs", "volDirBasePath", "uid", "gid", "permissions",
 			"clusterId", "filesetType", "parentFileset", "inodeLimit", "nodeClass",
 			"version", "tier", "compression", "consistencyGroup", "shared",
-			"volumeType", "cacheMode", "volNamePrefix", "existingVolume", "filesetName":
+			"volumeType", "cacheMode", "volNamePrefix", "existingVolume", "filesetName",
+			"encryptionKey", "encryptionAlgorithm", "dataReplicas", "metadataReplicas",
+			"replicationClusterId", "replicationFilesystem", "replicationTargetServer", "replicationRPO":
 			// These are valid parameters, do nothing here
 		default:
 			invalidParams = append(invalidParams, k)
@@ -861,14 +910,32 @@ func (cs *ScaleControllerServer) getPrimaryClusterDetails(ctx context.Context) (
 	return primaryConn, cs.Driver.primary.PrimaryCid, err
 }
 
-// CreateVolume - Create Volume
-func (cs *ScaleControllerServer) CreateVolume(newctx context.Context, req *csi.CreateVolumeRequest) (*csi.CreateVolumeResponse, error) { //nolint:gocyclo,funlen
+// CreateVolume creates a volume. A retry of a CreateVolume in progress waits
+// for its result.
+func (cs *ScaleControllerServer) CreateVolume(ctx context.Context, req *csi.CreateVolumeRequest) (*csi.CreateVolumeResponse, error) {
+	params := map[string]string{
+		"requiredBytes": strconv.FormatInt(req.GetCapacityRange().GetRequiredBytes(), 10),
+		"limitBytes":    strconv.FormatInt(req.GetCapacityRange().GetLimitBytes(), 10),
+		"snapshot":      req.GetVolumeContentSource().GetSnapshot().GetSnapshotId(),
+		"volume":        req.GetVolumeContentSource().GetVolume().GetVolumeId(),
+	}
+	ctx = audit.WithRequest(ctx, audit.Request{Operation: createVolume, Name: req.GetName(), PVCName: req.GetParameters()[PvcNameKey], PVCNamespace: req.GetParameters()[PvcNamespaceKey]})
+	return trackOperation(ctx, cs.Driver.optracker, req.GetName(), createVolume, operationParams(params), func() (*csi.CreateVolumeResponse, error) {
+		return cs.handleCreateVolume(ctx, req)
+	})
+}
+
+func (cs *ScaleControllerServer) handleCreateVolume(newctx context.Context, req *csi.CreateVolumeRequest) (*csi.CreateVolumeResponse, error) { //nolint:gocyclo,funlen
 	loggerId := utils.GetLoggerId(newctx)
 	ctx := utils.SetModuleName(newctx, createVolume)
 
 	reqToLog := proto.Clone(req).(*csi.CreateVolumeRequest)
 	reqToLog.Secrets = nil
 	klog.Infof("[%s] CreateVolume req: %+v", loggerId, reqToLog)
+	ctx, err := cs.withSecretCredentials(ctx, req.GetSecrets())
+	if err != nil {
+		return nil, err
+	}
 
 	if err := cs.Driver.ValidateControllerServiceRequest(ctx, csi.ControllerServiceCapability_RPC_CREATE_DELETE_VOLUME); err != nil {
 		klog.Errorf("[%s] invalid create volume req: %v", loggerId, reqToLog)
@@ -1150,20 +1217,27 @@ func (cs *ScaleControllerServer) CreateVolume(newctx context.Context, req *csi.C
 
 	}
 
-	volReqInProcess, err := cs.IfSameVolReqInProcess(scaleVol)
-	if err != nil {
-		return nil, err
+	if scaleVol.IsFilesetBased && scaleVol.EncryptionKey != "" {
+		err = cs.checkVolEncryptionAndSetFilesystemPolicy(ctx, scaleVol, volName)
+		if err != nil {
+			cs.deleteFailedVolPolicyPartitions(ctx, scaleVol)
+			return nil, err
+		}
 	}
 
-	if volReqInProcess {
-		klog.Errorf("[%s] volume:[%v] - volume creation already in process ", loggerId, scaleVol.VolName)
-		return nil, status.Error(codes.Aborted, fmt.Sprintf("volume creation already in process : %v", scaleVol.VolName))
+	if scaleVol.IsFilesetBased && (scaleVol.DataReplicas != 0 || scaleVol.MetadataReplicas != 0) {
+		err = cs.checkVolReplicationAndSetFilesystemPolicy(ctx, scaleVol, volName)
+		if err != nil {
+			cs.deleteFailedVolPolicyPartitions(ctx, scaleVol)
+			return nil, err
+		}
 	}
 
 	volResponse, err := cs.getCopyJobStatus(ctx, req, volSrc, scaleVol, isVolSource, isSnapSource, snapIdMembers)
 	if err != nil {
 		return nil, err
 	} else if volResponse != nil {
+		cs.journalComplete(ctx, scaleVol.VolName)
 		return volResponse, nil
 	}
 
@@ -1174,10 +1248,17 @@ func (cs *ScaleControllerServer) CreateVolume(newctx context.Context, req *csi.C
 		}
 	}
 
-	/* Update driver map with new volume. Make sure to defer delete */
-
-	cs.Driver.reqmap[scaleVol.VolName] = int64(scaleVol.VolSize) // #nosec G115 -- false positive
-	defer delete(cs.Driver.reqmap, scaleVol.VolName)
+	/* Record the volume in the operation journal, so that the fileset or
+	directory is rolled back if the driver restarts before returning it */
+	if !scaleVol.IsStaticPVBased && scaleVol.VolumeType != cacheVolume {
+		rec := journal.Record{ID: scaleVol.VolName, Operation: journal.CreateVolume, ClusterID: scaleVol.ClusterId, Filesystem: scaleVol.VolBackendFs, PVCName: scaleVol.PVCName, PVCNamespace: scaleVol.Namespace}
+		if scaleVol.IsFilesetBased {
+			rec.Fileset = scaleVol.VolName
+		} else {
+			rec.Path = fmt.Sprintf("%s/%s", scaleVol.VolDirBasePath, scaleVol.VolName)
+		}
+		cs.journalBegin(ctx, rec)
+	}
 
 	if scaleVol.VolumeType == cacheVolume {
 		gatewayNodeName, err := scaleVol.Connector.GetGatewayNode(ctx)
@@ -1248,6 +1329,15 @@ func (cs *ScaleControllerServer) CreateVolume(newctx context.Context, req *csi.C
 		}
 	}
 
+	if scaleVol.Replication != nil {
+		err = cs.enableFilesetReplication(ctx, scaleVol.Connector, scaleVol.VolBackendFs, scaleVol.VolName, scaleVol.Replication)
+		if err != nil {
+			klog.Errorf("[%s] CreateVolume [%s]: unable to enable replication: [%v]", loggerId, volName, err)
+			return nil, err
+		}
+	}
+
+	cs.journalComplete(ctx, scaleVol.VolName)
 	return &csi.CreateVolumeResponse{
 		Volume: &csi.Volume{
 			VolumeId:      volID,
@@ -1400,6 +1490,27 @@ func validateCacheSecret(ctx context.Context, secretData map[string]string) ([]s
 	return missingKeys, isNfsSupported, nil
 }
 
+// withSecretCredentials returns a context whose GUI requests to the cluster
+// named by the clusterId of the secrets of a request, e.g. set by
+// csi.storage.k8s.io/provisioner-secret-name in the StorageClass, use the
+// credentials in the secrets, or ctx if there are none.
+func (cs *ScaleControllerServer) withSecretCredentials(ctx context.Context, secrets map[string]string) (context.Context, error) {
+	creds, ok, err := connectors.CredentialsFromSecrets(secrets)
+	if err != nil {
+		klog.Errorf("[%s] invalid credentials in the secret of the request: %v", utils.GetLoggerId(ctx), err)
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("invalid credentials in the secret of the request: %v", err))
+	}
+	if !ok {
+		return ctx, nil
+	}
+	if _, ok := cs.Driver.connmap[creds.ClusterID]; !ok {
+		klog.Errorf("[%s] unknown cluster %s in the secret of the request", utils.GetLoggerId(ctx), creds.ClusterID)
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("unknown cluster %s in the secret of the request", creds.ClusterID))
+	}
+	klog.V(4).Infof("[%s] using the credentials of user %s from the secret of the request for cluster %s", utils.GetLoggerId(ctx), creds.Username, creds.ClusterID)
+	return connectors.WithCredentials(ctx, creds), nil
+}
+
 func (cs *ScaleControllerServer) setScaleVolume(ctx context.Context, req *csi.CreateVolumeRequest, volName string, volSize int64) (*scaleVolume, bool, error) {
 	scaleVol, err := getScaleVolumeOptions(ctx, req.GetParameters())
 	if err != nil {
@@ -1591,6 +1702,13 @@ func (cs *ScaleControllerServer) checkVolTierAndSetFilesystemPolicy(ctx context.
 		return err
 	}
 
+	return cs.setDefaultPolicyPartition(ctx, scaleVol, volName)
+}
+
+// setDefaultPolicyPartition installs the csi-defaultRule placement partition
+// if it is not already present on the volume filesystem.
+func (cs *ScaleControllerServer) setDefaultPolicyPartition(ctx context.Context, scaleVol *scaleVolume, volName string) error {
+	loggerId := utils.GetLoggerId(ctx)
 	// Since we are using a SET POOL rule, if there is not already a default rule in place in the policy partition
 	// then all files that do not match our rules will have no defined place to go. This sets a default rule with
 	// "lower" priority than the main policy as a catch all. If there is already a default rule in the main policy
@@ -1616,6 +1734,162 @@ func (cs *ScaleControllerServer) checkVolTierAndSetFilesystemPolicy(ctx context.
 	return nil
 }
 
+// checkVolEncryptionAndSetFilesystemPolicy validates that the volume filesystem
+// supports encryption and installs a per-fileset policy partition holding the
+// ENCRYPTION and SET ENCRYPTION rules for the volume.
+func (cs *ScaleControllerServer) checkVolEncryptionAndSetFilesystemPolicy(ctx context.Context, scaleVol *scaleVolume, volName string) error {
+	loggerId := utils.GetLoggerId(ctx)
+	fsDetails, err := scaleVol.Connector.GetFilesystemDetails(ctx, scaleVol.VolBackendFs)
+	if err != nil {
+		klog.Errorf("[%s] volume:[%v] - unable to get details of filesystem [%v]. Error: %v", loggerId, volName, scaleVol.VolBackendFs, err)
+		return status.Error(codes.Internal, fmt.Sprintf("unable to get details of filesystem [%v]. Error: %v", scaleVol.VolBackendFs, err))
+	}
+	if !fsDetails.Settings.Encryption {
+		klog.Errorf("[%s] volume:[%v] - encryption is not enabled for filesystem [%v] of cluster [%v]", loggerId, volName, scaleVol.VolBackendFs, scaleVol.ClusterId)
+		return status.Error(codes.InvalidArgument, fmt.Sprintf("encryption is not enabled for filesystem %v of cluster %v", scaleVol.VolBackendFs, scaleVol.ClusterId))
+	}
+
+	encryptionName := fmt.Sprintf("csi-E%s", scaleVol.VolName)
+	rule := "RULE '%s' ENCRYPTION '%s' IS ALGO '%s' KEYS('%s')\nRULE '%s-set' SET ENCRYPTION '%s' WHERE FILESET_NAME = '%s'"
+	policy := connectors.Policy{}
+	policy.Policy = fmt.Sprintf(rule, encryptionName, encryptionName, scaleVol.EncryptionAlgo, scaleVol.EncryptionKey, encryptionName, encryptionName, scaleVol.VolName)
+	policy.Priority = -5
+	policy.Partition = encryptionName
+	klog.Infof("[%s] checkVolEncryptionAndSetFilesystemPolicy: setting policy:[%v]", loggerId, policy.Policy)
+
+	err = scaleVol.Connector.SetFilesystemPolicy(ctx, &policy, scaleVol.VolBackendFs)
+	if err != nil {
+		klog.Errorf("[%s] volume:[%v] - setting encryption policy failed [%v]", loggerId, volName, err)
+		return status.Error(codes.Internal, fmt.Sprintf("setting encryption policy for volume [%v] failed. Error: %v", volName, err))
+	}
+
+	return cs.setDefaultPolicyPartition(ctx, scaleVol, volName)
+}
+
+// deleteVolPolicyPartition removes a policy partition installed for a volume,
+// a partition which does not exist is ignored.
+func (cs *ScaleControllerServer) deleteVolPolicyPartition(ctx context.Context, conn connectors.SpectrumScaleConnector, filesystemName string, partitionName string) error {
+	loggerId := utils.GetLoggerId(ctx)
+	if !conn.CheckIfDefaultPolicyPartitionExists(ctx, partitionName, filesystemName) {
+		return nil
+	}
+	klog.Infof("[%s] deleting policy partition [%v] of filesystem [%v]", loggerId, partitionName, filesystemName)
+	err := conn.DeletePolicyPartition(ctx, partitionName, filesystemName)
+	if err != nil {
+		return status.Error(codes.Internal, fmt.Sprintf("unable to delete policy partition [%v] of filesystem [%v]. Error: %v", partitionName, filesystemName, err))
+	}
+	return nil
+}
+
+// deleteVolPolicyPartitions removes the encryption and replication policy
+// partitions installed for the fileset of a volume.
+func (cs *ScaleControllerServer) deleteVolPolicyPartitions(ctx context.Context, conn connectors.SpectrumScaleConnector, filesystemName string, filesetName string) error {
+	for _, partitionName := range []string{fmt.Sprintf("csi-E%s", filesetName), fmt.Sprintf("csi-R%s", filesetName)} {
+		if err := cs.deleteVolPolicyPartition(ctx, conn, filesystemName, partitionName); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteFailedVolPolicyPartitions removes the encryption and replication
+// policy partitions of a volume whose policy could not be set. The partitions
+// of an existing fileset, e.g. of a retried CreateVolume of a created volume,
+// are kept.
+func (cs *ScaleControllerServer) deleteFailedVolPolicyPartitions(ctx context.Context, scaleVol *scaleVolume) {
+	loggerId := utils.GetLoggerId(ctx)
+	filesetExists, err := scaleVol.Connector.CheckIfFilesetExist(ctx, scaleVol.VolBackendFs, scaleVol.VolName)
+	if err != nil || filesetExists {
+		return
+	}
+	err = cs.deleteVolPolicyPartitions(ctx, scaleVol.Connector, scaleVol.VolBackendFs, scaleVol.VolName)
+	if err != nil {
+		klog.Errorf("[%s] volume:[%v] - unable to delete the policy partitions of the volume. Error: %v", loggerId, scaleVol.VolName, err)
+	}
+}
+
+// checkVolReplicationAndSetFilesystemPolicy validates the requested replication
+// factors against the volume filesystem and installs a per-fileset placement
+// policy partition that applies the data replication factor.
+func (cs *ScaleControllerServer) checkVolReplicationAndSetFilesystemPolicy(ctx context.Context, scaleVol *scaleVolume, volName string) error {
+	loggerId := utils.GetLoggerId(ctx)
+	fsDetails, err := scaleVol.Connector.GetFilesystemDetails(ctx, scaleVol.VolBackendFs)
+	if err != nil {
+		klog.Errorf("[%s] volume:[%v] - unable to get details of filesystem [%v]. Error: %v", loggerId, volName, scaleVol.VolBackendFs, err)
+		return status.Error(codes.Internal, fmt.Sprintf("unable to get details of filesystem [%v]. Error: %v", scaleVol.VolBackendFs, err))
+	}
+
+	if scaleVol.DataReplicas > fsDetails.Replication.MaxDataReplicas {
+		return status.Error(codes.InvalidArgument, fmt.Sprintf("dataReplicas %d exceeds the maximum data replicas %d of filesystem %v", scaleVol.DataReplicas, fsDetails.Replication.MaxDataReplicas, scaleVol.VolBackendFs))
+	}
+	if scaleVol.MetadataReplicas > fsDetails.Replication.MaxMetadataReplicas {
+		return status.Error(codes.InvalidArgument, fmt.Sprintf("metadataReplicas %d exceeds the maximum metadata replicas %d of filesystem %v", scaleVol.MetadataReplicas, fsDetails.Replication.MaxMetadataReplicas, scaleVol.VolBackendFs))
+	}
+	// Metadata replication cannot be set per fileset through a placement rule,
+	// so the requested value must be met by the filesystem default.
+	if scaleVol.MetadataReplicas != 0 && scaleVol.MetadataReplicas != fsDetails.Replication.DefaultMetadataReplicas {
+		return status.Error(codes.InvalidArgument, fmt.Sprintf("metadataReplicas %d does not match the default metadata replicas %d of filesystem %v", scaleVol.MetadataReplicas, fsDetails.Replication.DefaultMetadataReplicas, scaleVol.VolBackendFs))
+	}
+
+	if scaleVol.DataReplicas == 0 {
+		return nil
+	}
+
+	poolName := scaleVol.Tier
+	if poolName == "" {
+		poolName, err = scaleVol.Connector.GetFirstDataTier(ctx, scaleVol.VolBackendFs)
+		if err != nil {
+			return status.Error(codes.Unavailable, fmt.Sprintf("tier info request could not be completed: filesystemName %s", scaleVol.VolBackendFs))
+		}
+	}
+
+	partitionName := fmt.Sprintf("csi-R%s", scaleVol.VolName)
+	rule := "RULE '%s' SET POOL '%s' REPLICATE(%d) WHERE FILESET_NAME = '%s'"
+	policy := connectors.Policy{}
+	policy.Policy = fmt.Sprintf(rule, partitionName, poolName, scaleVol.DataReplicas, scaleVol.VolName)
+	// Evaluated before the tier partitions so that the replicated placement wins.
+	policy.Priority = -10
+	policy.Partition = partitionName
+	klog.Infof("[%s] checkVolReplicationAndSetFilesystemPolicy: setting policy:[%v]", loggerId, policy.Policy)
+
+	err = scaleVol.Connector.SetFilesystemPolicy(ctx, &policy, scaleVol.VolBackendFs)
+	if err != nil {
+		klog.Errorf("[%s] volume:[%v] - setting replication policy failed [%v]", loggerId, volName, err)
+		return status.Error(codes.Internal, fmt.Sprintf("setting replication policy for volume [%v] failed. Error: %v", volName, err))
+	}
+
+	return cs.setDefaultPolicyPartition(ctx, scaleVol, volName)
+}
+
+// getVolReplication returns the effective data and metadata replication of a
+// volume. The data replication comes from the per-fileset placement partition
+// if one was installed at creation, otherwise from the filesystem default.
+func (cs *ScaleControllerServer) getVolReplication(ctx context.Context, conn connectors.SpectrumScaleConnector, filesystemName string, filesetName string, fsDetails connectors.FileSystem_v2) (int, int) {
+	loggerId := utils.GetLoggerId(ctx)
+	dataReplicas := fsDetails.Replication.DefaultDataReplicas
+	metadataReplicas := fsDetails.Replication.DefaultMetadataReplicas
+	if filesetName == "" {
+		return dataReplicas, metadataReplicas
+	}
+
+	partitionName := fmt.Sprintf("csi-R%s", filesetName)
+	if !conn.CheckIfDefaultPolicyPartitionExists(ctx, partitionName, filesystemName) {
+		return dataReplicas, metadataReplicas
+	}
+	policy, err := conn.GetFilesystemPolicyPartition(ctx, partitionName, filesystemName)
+	if err != nil {
+		klog.Errorf("[%s] unable to get replication policy partition [%v] of filesystem [%v]. Error: %v", loggerId, partitionName, filesystemName, err)
+		return dataReplicas, metadataReplicas
+	}
+	match := replicateRuleRegex.FindStringSubmatch(policy.Policy)
+	if len(match) == 2 {
+		if replicas, err := strconv.Atoi(match[1]); err == nil {
+			dataReplicas = replicas
+		}
+	}
+	return dataReplicas, metadataReplicas
+}
+
 func (cs *ScaleControllerServer) getCopyJobStatus(ctx context.Context, req *csi.CreateVolumeRequest, volSrc *csi.VolumeContentSource, scaleVol *scaleVolume, isVolSource bool, isSnapSource bool, snapIdMembers scaleSnapId) (*csi.CreateVolumeResponse, error) {
 	loggerId := utils.GetLoggerId(ctx)
 	if isVolSource {
@@ -1730,9 +2004,12 @@ func (cs *ScaleControllerServer) copySnapContent(ctx context.Context, scVol *sca
 
 	jobDetails := SnapCopyJobDetails{SNAP_JOB_RUNNING, volID}
 	cs.Driver.snapjobstatusmap.Store(scVol.VolName, jobDetails)
+	cs.journalCopyJob(ctx, scVol.VolName, journal.SnapshotCopy, snapId.ClusterId, jobStatus, jobID, volID)
 
 	isResponseStatusUnknown := false
+	stopMonitor := cs.monitorCopyJob(ctx, conn, scVol, jobID)
 	response, err := conn.WaitForJobCompletionWithResp(ctx, jobStatus, jobID)
+	stopMonitor()
 	if len(response.Jobs) != 0 {
 		if response.Jobs[0].Status == ResponseStatusUnknown {
 			isResponseStatusUnknown = true
@@ -1761,6 +2038,7 @@ func (cs *ScaleControllerServer) copySnapContent(ctx context.Context, scVol *sca
 	}
 
 	klog.Infof("[%s] copy snapshot completed for snapId: [%v], scaleVolume: [%v]", loggerId, snapId, scVol)
+	_ = cs.setCopyProgress(ctx, scVol, copyProgressCompleted)
 	jobDetails.jobStatus = SNAP_JOB_COMPLETED
 	cs.Driver.snapjobstatusmap.Store(scVol.VolName, jobDetails)
 	//delete(cs.Driver.snapjobmap, scVol.VolName)
@@ -1807,7 +2085,10 @@ func (cs *ScaleControllerServer) copyShallowVolumeContent(ctx context.Context, n
 
 	jobDetails = VolCopyJobDetails{VOLCOPY_JOB_RUNNING, volID}
 	cs.Driver.volcopyjobstatusmap.Store(newvolume.VolName, jobDetails)
+	cs.journalCopyJob(ctx, newvolume.VolName, journal.VolumeCopy, sourcevolume.ClusterId, jobStatus, jobID, volID)
+	stopMonitor := cs.monitorCopyJob(ctx, conn, newvolume, jobID)
 	response, err = conn.WaitForJobCompletionWithResp(ctx, jobStatus, jobID)
+	stopMonitor()
 	if err != nil {
 		klog.Errorf("[%s] failed while calling WaitForJobCompletionWithResp: %v.", loggerId, err)
 	}
@@ -1834,6 +2115,7 @@ func (cs *ScaleControllerServer) copyShallowVolumeContent(ctx context.Context, n
 	}
 
 	klog.Infof("[%s] volume copy completed for volumeID: [%v], scaleVolume: [%v]", loggerId, sourcevolume, newvolume)
+	_ = cs.setCopyProgress(ctx, newvolume, copyProgressCompleted)
 	jobDetails.jobStatus = VOLCOPY_JOB_COMPLETED
 	cs.Driver.volcopyjobstatusmap.Store(newvolume.VolName, jobDetails)
 	return nil
@@ -1950,7 +2232,10 @@ func (cs *ScaleControllerServer) copyVolumeContent(ctx context.Context, newvolum
 
 		jobDetails = VolCopyJobDetails{VOLCOPY_JOB_RUNNING, volID}
 		cs.Driver.volcopyjobstatusmap.Store(newvolume.VolName, jobDetails)
+		cs.journalCopyJob(ctx, newvolume.VolName, journal.VolumeCopy, sourcevolume.ClusterId, jobStatus, jobID, volID)
+		stopMonitor := cs.monitorCopyJob(ctx, conn, newvolume, jobID)
 		response, err = conn.WaitForJobCompletionWithResp(ctx, jobStatus, jobID)
+		stopMonitor()
 	} else {
 
 		var sLinkRelPath string
@@ -1973,7 +2258,10 @@ func (cs *ScaleControllerServer) copyVolumeContent(ctx context.Context, newvolum
 
 		jobDetails = VolCopyJobDetails{VOLCOPY_JOB_RUNNING, volID}
 		cs.Driver.volcopyjobstatusmap.Store(newvolume.VolName, jobDetails)
+		cs.journalCopyJob(ctx, newvolume.VolName, journal.VolumeCopy, sourcevolume.ClusterId, jobStatus, jobID, volID)
+		stopMonitor := cs.monitorCopyJob(ctx, conn, newvolume, jobID)
 		response, err = conn.WaitForJobCompletionWithResp(ctx, jobStatus, jobID)
+		stopMonitor()
 		if err != nil {
 			klog.Errorf("[%s] failed while calling WaitForJobCompletionWithResp: %v.", loggerId, err)
 		}
@@ -2009,6 +2297,7 @@ func (cs *ScaleControllerServer) copyVolumeContent(ctx context.Context, newvolum
 	}
 
 	klog.Infof("[%s] volume copy completed for volumeID: [%v], scaleVolume: [%v]", loggerId, sourcevolume, newvolume)
+	_ = cs.setCopyProgress(ctx, newvolume, copyProgressCompleted)
 	jobDetails.jobStatus = VOLCOPY_JOB_COMPLETED
 	cs.Driver.volcopyjobstatusmap.Store(newvolume.VolName, jobDetails)
 	//delete(cs.Driver.volcopyjobstatusmap, scVol.VolName)
@@ -2520,39 +2809,25 @@ func (cs *ScaleControllerServer) isSourceVolORSnapSourceVolStatic(ctx context.Co
 }
 
 func (cs *ScaleControllerServer) GetSnapIdMembers(sId string) (scaleSnapId, error) {
-	splitSid := strings.Split(sId, ";")
-	var sIdMem scaleSnapId
-
-	if len(splitSid) < 4 {
+	handle, err := scalehandle.DecodeSnapshot(sId)
+	if err != nil {
 		return scaleSnapId{}, status.Error(codes.Internal, fmt.Sprintf("Invalid Snapshot Id : [%v]", sId))
 	}
-
-	if len(splitSid) >= 8 {
-		/* storageclass_type;volumeType;clusterId;FSUUID;consistency_group;filesetName;snapshotName;path */
-		sIdMem.StorageClassType = splitSid[0]
-		sIdMem.VolType = splitSid[1]
-		sIdMem.ClusterId = splitSid[2]
-		sIdMem.FsUUID = splitSid[3]
-		sIdMem.ConsistencyGroup = splitSid[4]
-		sIdMem.FsetName = splitSid[5]
-		sIdMem.SnapName = splitSid[6]
-		sIdMem.MetaSnapName = splitSid[7]
-		if len(splitSid) == 9 && splitSid[8] != "" {
-			sIdMem.Path = splitSid[8]
-		} else {
-			sIdMem.Path = "/"
-		}
-	} else {
-		/* clusterId;FSUUID;filesetName;snapshotName;path */
-		sIdMem.ClusterId = splitSid[0]
-		sIdMem.FsUUID = splitSid[1]
-		sIdMem.FsetName = splitSid[2]
-		sIdMem.SnapName = splitSid[3]
-		if len(splitSid) == 5 && splitSid[4] != "" {
-			sIdMem.Path = splitSid[4]
-		} else {
-			sIdMem.Path = "/"
-		}
+	sIdMem := scaleSnapId{
+		StorageClassType: handle.StorageClassType,
+		VolType:          handle.VolumeType,
+		ClusterId:        handle.ClusterID,
+		FsUUID:           handle.FilesystemUUID,
+		ConsistencyGroup: handle.ConsistencyGroup,
+		FsetName:         handle.FilesetName,
+		SnapName:         handle.SnapshotName,
+		MetaSnapName:     handle.MetaSnapshotName,
+		Path:             handle.Path,
+	}
+	if sIdMem.Path == "" {
+		sIdMem.Path = "/"
+	}
+	if handle.Version == scalehandle.V1 {
 		sIdMem.StorageClassType = STORAGECLASS_CLASSIC
 	}
 	return sIdMem, nil
@@ -2650,13 +2925,25 @@ func (cs *ScaleControllerServer) DeleteCGFileset(ctx context.Context, Filesystem
 	return nil
 }
 
+// ControllerModifyVolume modifies the mutable parameters of a volume.
 func (cs *ScaleControllerServer) ControllerModifyVolume(ctx context.Context, req *csi.ControllerModifyVolumeRequest) (*csi.ControllerModifyVolumeResponse, error) {
+	ctx = audit.WithRequest(ctx, audit.Request{Operation: modifyVolume, Name: req.GetVolumeId()})
+	return trackOperation(ctx, cs.Driver.optracker, volumeOperationKey(req.GetVolumeId()), modifyVolume, operationParams(req.GetMutableParameters()), func() (*csi.ControllerModifyVolumeResponse, error) {
+		return cs.handleControllerModifyVolume(ctx, req)
+	})
+}
+
+func (cs *ScaleControllerServer) handleControllerModifyVolume(ctx context.Context, req *csi.ControllerModifyVolumeRequest) (*csi.ControllerModifyVolumeResponse, error) {
 	loggerId := utils.GetLoggerId(ctx)
 	afmTuningParams := make(map[string]interface{})
 
 	reqToLog := proto.Clone(req).(*csi.ControllerModifyVolumeRequest)
 	reqToLog.Secrets = nil
 	klog.Infof("[%s] ControllerModifyVolume - request: %#v", loggerId, reqToLog)
+	ctx, err := cs.withSecretCredentials(ctx, req.GetSecrets())
+	if err != nil {
+		return nil, err
+	}
 	klog.Infof("[%s] ControllerModifyVolume - Number of param: %v", loggerId, len(req.MutableParameters))
 
 	if err := cs.Driver.ValidateControllerServiceRequest(ctx, csi.ControllerServiceCapability_RPC_MODIFY_VOLUME); err != nil {
@@ -2697,6 +2984,17 @@ func (cs *ScaleControllerServer) ControllerModifyVolume(ctx context.Context, req
 
 	filesetName := volumeIDMembers.FsetName
 
+	if snapshotID, ok := mutableParams[connectors.UserSpecifiedRevertToSnapshot]; ok {
+		if len(mutableParams) > 1 {
+			return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("ControllerModifyVolume: - the parameter [%s] must not be specified together with other parameters", connectors.UserSpecifiedRevertToSnapshot))
+		}
+		err = cs.revertVolumeToSnapshot(ctx, volumeId, volumeIDMembers, conn, filesystemName, snapshotID)
+		if err != nil {
+			return nil, err
+		}
+		return &csi.ControllerModifyVolumeResponse{}, nil
+	}
+
 	isStaticPVBased := false
 	// Check if fileset exists and the creator is not IBM Storage Scale CSI driver for static volumes
 	filesetInfo, err := conn.ListFileset(ctx, filesystemName, filesetName)
@@ -2756,13 +3054,195 @@ func (cs *ScaleControllerServer) ControllerModifyVolume(ctx context.Context, req
 	return &csi.ControllerModifyVolumeResponse{}, nil
 }
 
-func (cs *ScaleControllerServer) DeleteVolume(newctx context.Context, req *csi.DeleteVolumeRequest) (*csi.DeleteVolumeResponse, error) {
+// revertVolumeToSnapshot reverts the data of a fileset based volume in place to
+// the content of one of its own snapshots. The volume ID is preserved; the data
+// directory is recreated with its original owner and permissions and is then
+// filled from the snapshot by a copy job. The revert returns Aborted while the
+// job runs and succeeds on the retry after the job completed.
+func (cs *ScaleControllerServer) revertVolumeToSnapshot(ctx context.Context, volumeId string, volumeIDMembers scaleVolId, conn connectors.SpectrumScaleConnector, filesystemName string, snapshotID string) error { //nolint:funlen
+	loggerId := utils.GetLoggerId(ctx)
+	klog.Infof("[%s] revertVolumeToSnapshot - volume [%s], snapshot [%s]", loggerId, volumeId, snapshotID)
+
+	if !volumeIDMembers.IsFilesetBased || volumeIDMembers.StorageClassType != STORAGECLASS_CLASSIC ||
+		(volumeIDMembers.VolType != FILE_INDEPENDENTFILESET_VOLUME && volumeIDMembers.VolType != FILE_DEPENDENTFILESET_VOLUME) {
+		return status.Error(codes.InvalidArgument, fmt.Sprintf("revertVolumeToSnapshot - volume [%s] - in place revert is only supported for fileset based volumes of classic storageClass", volumeId))
+	}
+
+	snapIdMembers, err := cs.GetSnapIdMembers(snapshotID)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, fmt.Sprintf("revertVolumeToSnapshot - Error in snapshot ID %v: %v", snapshotID, err))
+	}
+	if snapIdMembers.StorageClassType != STORAGECLASS_CLASSIC || snapIdMembers.ClusterId != volumeIDMembers.ClusterId ||
+		snapIdMembers.FsUUID != volumeIDMembers.FsUUID || snapIdMembers.FsetName != volumeIDMembers.FsetName {
+		return status.Error(codes.InvalidArgument, fmt.Sprintf("revertVolumeToSnapshot - snapshot [%s] is not a snapshot of volume [%s]", snapshotID, volumeId))
+	}
+
+	filesetInfo, err := conn.ListFileset(ctx, filesystemName, volumeIDMembers.FsetName)
+	if err != nil {
+		return status.Error(codes.Internal, fmt.Sprintf("unable to list fileset [%v] in filesystem [%v]. Error: %v", volumeIDMembers.FsetName, filesystemName, err))
+	}
+	if !strings.Contains(filesetInfo.Config.Comment, connectors.FilesetComment) {
+		return status.Error(codes.InvalidArgument, fmt.Sprintf("revertVolumeToSnapshot - fileset [%v] is not created by IBM Storage Scale CSI driver, in place revert is not supported for static volumes", volumeIDMembers.FsetName))
+	}
+
+	snapExist, err := conn.CheckIfSnapshotExist(ctx, filesystemName, snapIdMembers.FsetName, snapIdMembers.SnapName)
+	if err != nil {
+		return status.Error(codes.Internal, fmt.Sprintf("unable to get the snapshot details for [%s]. Error [%v]", snapIdMembers.SnapName, err))
+	}
+	if !snapExist {
+		return status.Error(codes.NotFound, fmt.Sprintf("snapshot [%s] does not exist for fileset [%s:%s]", snapIdMembers.SnapName, filesystemName, snapIdMembers.FsetName))
+	}
+
+	primaryConn, isprimaryConnPresent := cs.Driver.connmap["primary"]
+	if !isprimaryConnPresent {
+		return status.Error(codes.Internal, "revertVolumeToSnapshot - unable to find primary cluster details in custom resource")
+	}
+	primaryFsName, err := primaryConn.GetFilesystemName(ctx, volumeIDMembers.FsUUID)
+	if err != nil {
+		return status.Error(codes.Internal, fmt.Sprintf("unable to get filesystem Name for Filesystem Uid [%v] in primary cluster. Error [%v]", volumeIDMembers.FsUUID, err))
+	}
+	primaryMountInfo, err := primaryConn.GetFilesystemMountDetails(ctx, primaryFsName)
+	if err != nil {
+		return status.Error(codes.Internal, fmt.Sprintf("unable to get mount info for FS [%v] in primary cluster", primaryFsName))
+	}
+	relPath := strings.Trim(strings.Replace(volumeIDMembers.Path, primaryMountInfo.MountPoint, "", 1), "!/")
+	if filepath.Base(relPath) != fmt.Sprintf("%s-data", volumeIDMembers.FsetName) {
+		return status.Error(codes.InvalidArgument, fmt.Sprintf("revertVolumeToSnapshot - unexpected data path [%s] for volume [%s]", relPath, volumeId))
+	}
+	markerPath := fmt.Sprintf("%s/%s", filepath.Dir(relPath), revertMarkerDir)
+
+	// The same revert is retried by the resizer until the copy job completed.
+	jobKey := fmt.Sprintf("%s-revert-%s", volumeIDMembers.FsetName, snapIdMembers.SnapName)
+	if jobDetails, found := cs.Driver.snapjobstatusmap.Load(jobKey); found {
+		switch jobDetails.(SnapCopyJobDetails).jobStatus {
+		case SNAP_JOB_RUNNING:
+			return status.Error(codes.Aborted, fmt.Sprintf("revert of volume [%s] to snapshot [%s] is in progress", volumeId, snapIdMembers.SnapName))
+		case SNAP_JOB_COMPLETED:
+			err = conn.DeleteDirectory(ctx, filesystemName, markerPath, false)
+			if err != nil && !(strings.Contains(err.Error(), "EFSSG0264C") || strings.Contains(err.Error(), "does not exist")) {
+				return status.Error(codes.Internal, fmt.Sprintf("unable to delete revert marker [%s] of volume [%s]. Error [%v]", markerPath, volumeId, err))
+			}
+			cs.Driver.snapjobstatusmap.Delete(jobKey)
+			klog.Infof("[%s] revertVolumeToSnapshot - volume [%s] reverted to snapshot [%s]", loggerId, volumeId, snapIdMembers.SnapName)
+			return nil
+		default:
+			// the revert is started again from the snapshot
+			cs.Driver.snapjobstatusmap.Delete(jobKey)
+		}
+	}
+
+	attached, err := cs.isVolumeAttached(ctx, volumeId)
+	if err != nil {
+		return status.Error(codes.Internal, fmt.Sprintf("unable to check attachments of volume [%s]. Error [%v]", volumeId, err))
+	}
+	if attached {
+		return status.Error(codes.FailedPrecondition, fmt.Sprintf("revertVolumeToSnapshot - volume [%s] is attached to a node, stop the workload using it before reverting", volumeId))
+	}
+
+	fsDetails, err := conn.GetFilesystemDetails(ctx, filesystemName)
+	if err != nil {
+		return status.Error(codes.Internal, fmt.Sprintf("unable to get filesystem details for [%v]. Error [%v]", filesystemName, err))
+	}
+
+	// The marker records that the data directory is being replaced. It is
+	// created with the owner of the data directory before the directory is
+	// deleted, so an interrupted or failed revert is started again from the
+	// snapshot by the next retry and the volume is not published meanwhile.
+	markerExists, err := conn.CheckIfFileDirPresent(ctx, filesystemName, markerPath)
+	if err != nil {
+		return status.Error(codes.Internal, fmt.Sprintf("unable to check revert marker [%s] of volume [%s]. Error [%v]", markerPath, volumeId, err))
+	}
+	if !markerExists {
+		statInfo, err := conn.StatDirectory(ctx, filesystemName, relPath)
+		if err != nil {
+			return status.Error(codes.Internal, fmt.Sprintf("unable to stat data directory [%s] of volume [%s]. Error [%v]", relPath, volumeId, err))
+		}
+		uid, gid, permissions, err := parseStatDirOwner(statInfo)
+		if err != nil {
+			return status.Error(codes.Internal, fmt.Sprintf("unable to get owner of data directory [%s] of volume [%s]. Error [%v]", relPath, volumeId, err))
+		}
+		if err = conn.MakeDirectoryV2(ctx, filesystemName, markerPath, uid, gid, permissions); err != nil {
+			return status.Error(codes.Internal, fmt.Sprintf("unable to create revert marker [%s] of volume [%s]. Error [%v]", markerPath, volumeId, err))
+		}
+	} else {
+		klog.Infof("[%s] revertVolumeToSnapshot - resuming interrupted revert of volume [%s]", loggerId, volumeId)
+	}
+	statInfo, err := conn.StatDirectory(ctx, filesystemName, markerPath)
+	if err != nil {
+		return status.Error(codes.Internal, fmt.Sprintf("unable to stat revert marker [%s] of volume [%s]. Error [%v]", markerPath, volumeId, err))
+	}
+	uid, gid, permissions, err := parseStatDirOwner(statInfo)
+	if err != nil {
+		return status.Error(codes.Internal, fmt.Sprintf("unable to get owner of revert marker [%s] of volume [%s]. Error [%v]", markerPath, volumeId, err))
+	}
+
+	klog.Infof("[%s] revertVolumeToSnapshot - recreating data directory [%s] of volume [%s]", loggerId, relPath, volumeId)
+	err = conn.DeleteDirectory(ctx, filesystemName, relPath, false)
+	if err != nil && !(strings.Contains(err.Error(), "EFSSG0264C") || strings.Contains(err.Error(), "does not exist")) {
+		return status.Error(codes.Internal, fmt.Sprintf("unable to delete data directory [%s] of volume [%s]. Error [%v]", relPath, volumeId, err))
+	}
+	if err = conn.MakeDirectoryV2(ctx, filesystemName, relPath, uid, gid, permissions); err != nil {
+		return status.Error(codes.Internal, fmt.Sprintf("unable to create data directory [%s] of volume [%s]. Error [%v]", relPath, volumeId, err))
+	}
+
+	targetPath := fmt.Sprintf("%s/%s", fsDetails.Mount.MountPoint, relPath)
+	jobStatus, jobID, err := conn.CopyFsetSnapshotPath(ctx, filesystemName, snapIdMembers.FsetName, snapIdMembers.SnapName, snapIdMembers.Path, targetPath, "")
+	if err != nil {
+		klog.Errorf("[%s] failed to revert volume [%s] to snapshot %s: [%v]", loggerId, volumeId, snapIdMembers.SnapName, err)
+		return status.Error(codes.Internal, fmt.Sprintf("failed to revert volume [%s] to snapshot %s: [%v]", volumeId, snapIdMembers.SnapName, err))
+	}
+
+	cs.Driver.snapjobstatusmap.Store(jobKey, SnapCopyJobDetails{SNAP_JOB_RUNNING, volumeId})
+	cs.waitForRevertJob(ctx, conn, jobKey, volumeId, jobStatus, jobID)
+	return status.Error(codes.Aborted, fmt.Sprintf("revert of volume [%s] to snapshot [%s] is in progress", volumeId, snapIdMembers.SnapName))
+}
+
+// isVolumeAttached checks whether a VolumeAttachment of this driver exists for
+// the volume volumeId. The PersistentVolume of an attachment is resolved, as
+// its name differs from the fileset name of static and of compressed or tiered
+// volumes.
+func (cs *ScaleControllerServer) isVolumeAttached(ctx context.Context, volumeId string) (bool, error) {
+	vaList, err := cs.Driver.clientset.StorageV1().VolumeAttachments().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return false, err
+	}
+	for _, va := range vaList.Items {
+		if va.Spec.Attacher != cs.Driver.name || va.Spec.Source.PersistentVolumeName == nil {
+			continue
+		}
+		pv, err := cs.Driver.clientset.CoreV1().PersistentVolumes().Get(ctx, *va.Spec.Source.PersistentVolumeName, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return false, err
+		}
+		if pv.Spec.CSI != nil && pv.Spec.CSI.VolumeHandle == volumeId {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// DeleteVolume deletes a volume. A retry of a DeleteVolume in progress waits
+// for its result.
+func (cs *ScaleControllerServer) DeleteVolume(ctx context.Context, req *csi.DeleteVolumeRequest) (*csi.DeleteVolumeResponse, error) {
+	ctx = audit.WithRequest(ctx, audit.Request{Operation: deleteVolume, Name: req.GetVolumeId()})
+	return trackOperation(ctx, cs.Driver.optracker, volumeOperationKey(req.GetVolumeId()), deleteVolume, "", func() (*csi.DeleteVolumeResponse, error) {
+		return cs.handleDeleteVolume(ctx, req)
+	})
+}
+
+func (cs *ScaleControllerServer) handleDeleteVolume(newctx context.Context, req *csi.DeleteVolumeRequest) (_ *csi.DeleteVolumeResponse, err error) {
 	loggerId := utils.GetLoggerId(newctx)
 	ctx := utils.SetModuleName(newctx, deleteVolume)
 
 	reqToLog := proto.Clone(req).(*csi.DeleteVolumeRequest)
 	reqToLog.Secrets = nil
 	klog.Infof("[%s] DeleteVolume req: %v", loggerId, reqToLog)
+	ctx, err = cs.withSecretCredentials(ctx, req.GetSecrets())
+	if err != nil {
+		return nil, err
+	}
 
 	if err := cs.Driver.ValidateControllerServiceRequest(ctx, csi.ControllerServiceCapability_RPC_CREATE_DELETE_VOLUME); err != nil {
 		klog.Errorf("[%s] Invalid delete volume req: %v", loggerId, reqToLog)
@@ -2782,6 +3262,15 @@ func (cs *ScaleControllerServer) DeleteVolume(newctx context.Context, req *csi.D
 
 	klog.V(4).Infof("[%s] Volume Id Members [%v]", loggerId, volumeIdMembers)
 
+	/* Record the deletion in the operation journal, so that it is rolled
+	forward if the driver restarts before it completes */
+	cs.journalBegin(ctx, journal.Record{ID: volumeID, Operation: journal.DeleteVolume, ClusterID: volumeIdMembers.ClusterId, Fileset: volumeIdMembers.FsetName, VolumeID: volumeID})
+	defer func() {
+		if err == nil {
+			cs.journalComplete(ctx, volumeID)
+		}
+	}()
+
 	if volumeIdMembers.IsFilesetBased {
 		klog.V(4).Infof("[%s] Volume is IsFilesetBased [%v]", loggerId, volumeIdMembers.IsFilesetBased)
 	}
@@ -2882,6 +3371,13 @@ func (cs *ScaleControllerServer) DeleteVolume(newctx context.Context, req *csi.D
 		}
 	}
 
+	// Cancel the copy job if the volume is deleted while its content is still copied
+	if volumeIdMembers.IsFilesetBased {
+		cs.cancelCopyJob(ctx, volumeIdMembers.FsetName)
+	} else if volumeIdMembers.VolType != FILE_SHALLOWCOPY_VOLUME {
+		cs.cancelCopyJob(ctx, filepath.Base(relPath))
+	}
+
 	if volumeIdMembers.IsFilesetBased {
 		var FilesetName string
 
@@ -2904,6 +3400,18 @@ func (cs *ScaleControllerServer) DeleteVolume(newctx context.Context, req *csi.D
 		} else if !reflect.ValueOf(filesetInfo).IsZero() && !strings.Contains(filesetInfo.Config.Comment, connectors.FilesetComment) {
 			klog.Infof("Fileset [%v] is not created by IBM Container Storage Interface driver, skipping the fileset delete", FilesetName)
 			return &csi.DeleteVolumeResponse{}, nil
+		} else if strings.HasPrefix(filesetInfo.Config.Comment, connectors.AdoptedFilesetCommentPrefix) {
+			klog.Infof("[%s] fileset [%v] was adopted by IBM Container Storage Interface driver, skipping the fileset delete", loggerId, FilesetName)
+			return &csi.DeleteVolumeResponse{}, nil
+		}
+
+		// the policy partitions are removed even if the fileset was deleted
+		// before, e.g. by an interrupted DeleteVolume
+		if reflect.ValueOf(filesetInfo).IsZero() {
+			err = cs.deleteVolPolicyPartitions(ctx, conn, FilesystemName, FilesetName)
+			if err != nil {
+				return nil, err
+			}
 		}
 
 		if FilesetName != "" && isPvcFromSnapshot {
@@ -2934,11 +3442,23 @@ func (cs *ScaleControllerServer) DeleteVolume(newctx context.Context, req *csi.D
 				if volumeIdMembers.VolType == FILE_INDEPENDENTFILESET_VOLUME {
 					checkForSnapshots = true
 				}
+				if filesetInfo.AFM.AFMMode == connectors.AfmModePrimary && filesetInfo.AFM.AFMTarget != "" {
+					err = cs.deleteFilesetReplication(ctx, conn, volumeIdMembers.ClusterId, FilesystemName, filesetInfo)
+					if err != nil {
+						return nil, err
+					}
+				}
 				_, err := cs.DeleteFilesetVol(ctx, FilesystemName, FilesetName, volumeIdMembers, conn, checkForSnapshots)
 				if err != nil {
 					return nil, err
 				}
 
+				// the partitions of the volume fileset match no fileset anymore
+				err = cs.deleteVolPolicyPartitions(ctx, conn, FilesystemName, FilesetName)
+				if err != nil {
+					return nil, err
+				}
+
 				// Delete fileset related symlink
 				if volumeIdMembers.StorageClassType == STORAGECLASS_CLASSIC && symlinkExists {
 					err = primaryConn.DeleteSymLnk(ctx, pfsName, relPath)
@@ -3491,14 +4011,26 @@ func (cs *ScaleControllerServer) MakeSnapMetadataDir(ctx context.Context, conn c
 	return nil
 }
 
-// CreateSnapshot Create Snapshot
-func (cs *ScaleControllerServer) CreateSnapshot(newctx context.Context, req *csi.CreateSnapshotRequest) (*csi.CreateSnapshotResponse, error) { //nolint:gocyclo,funlen
+// CreateSnapshot creates a snapshot of a volume. A retry of a CreateSnapshot
+// in progress waits for its result.
+func (cs *ScaleControllerServer) CreateSnapshot(ctx context.Context, req *csi.CreateSnapshotRequest) (*csi.CreateSnapshotResponse, error) {
+	ctx = audit.WithRequest(ctx, audit.Request{Operation: createSnapshot, Name: req.GetName()})
+	return trackOperation(ctx, cs.Driver.optracker, req.GetName(), createSnapshot, req.GetSourceVolumeId(), func() (*csi.CreateSnapshotResponse, error) {
+		return cs.handleCreateSnapshot(ctx, req)
+	})
+}
+
+func (cs *ScaleControllerServer) handleCreateSnapshot(newctx context.Context, req *csi.CreateSnapshotRequest) (*csi.CreateSnapshotResponse, error) { //nolint:gocyclo,funlen
 	loggerId := utils.GetLoggerId(newctx)
 	ctx := utils.SetModuleName(newctx, createSnapshot)
 
 	reqToLog := proto.Clone(req).(*csi.CreateSnapshotRequest)
 	reqToLog.Secrets = nil
 	klog.Infof("[%s] CreateSnapshot - create snapshot req: %v", loggerId, reqToLog)
+	ctx, err := cs.withSecretCredentials(ctx, req.GetSecrets())
+	if err != nil {
+		return nil, err
+	}
 
 	if err := cs.Driver.ValidateControllerServiceRequest(ctx, csi.ControllerServiceCapability_RPC_CREATE_DELETE_SNAPSHOT); err != nil {
 		klog.Errorf("[%s] CreateSnapshot - invalid create snapshot req: %v", loggerId, reqToLog)
@@ -3604,9 +4136,16 @@ func (cs *ScaleControllerServer) CreateSnapshot(newctx context.Context, req *csi
 	}
 
 	snapName := req.GetName()
+	snapParams := req.GetParameters()
+	pruneSnapshots := false
+	if pruning, ok := snapParams[connectors.UserSpecifiedSnapshotPruning]; ok {
+		pruneSnapshots, err = strconv.ParseBool(pruning)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("CreateSnapshot [%s] - invalid %s value: [%v]", snapName, connectors.UserSpecifiedSnapshotPruning, pruning))
+		}
+	}
 	snapWindowInt := 0
 	if volumeIDMembers.StorageClassType == STORAGECLASS_ADVANCED {
-		snapParams := req.GetParameters()
 		snapWindow, snapWindowSpecified := snapParams[connectors.UserSpecifiedSnapWindow]
 		if !snapWindowSpecified {
 			// use default snapshot window for consistency group
@@ -3630,29 +4169,42 @@ func (cs *ScaleControllerServer) CreateSnapshot(newctx context.Context, req *csi
 		return nil, err
 	}
 	if createNewSnap {
-		snapName, err = cs.CreateNewSnapshot(ctx, conn, filesystemName, filesetName, snapName, volumeIDMembers.StorageClassType, snapExist)
+		snapName, err = cs.CreateNewSnapshot(ctx, conn, filesystemName, volumeIDMembers.FsUUID, filesetName, snapName, volumeIDMembers.StorageClassType, snapExist, pruneSnapshots)
 		if err != nil {
 			klog.Errorf("[%s] CreateSnapshot [%s] unable to create new snapshot for fileset [%s:%s]. Error: [%v]", loggerId, snapName, filesystemName, filesetName, err)
 			return nil, err
 		}
 	}
 
-	snapID := ""
+	snapHandle := scalehandle.Snapshot{
+		Version:          scalehandle.Latest,
+		StorageClassType: volumeIDMembers.StorageClassType,
+		VolumeType:       volumeIDMembers.VolType,
+		ClusterID:        volumeIDMembers.ClusterId,
+		FilesystemUUID:   volumeIDMembers.FsUUID,
+		FilesetName:      filesetName,
+		SnapshotName:     snapName,
+	}
 	if volumeIDMembers.StorageClassType == STORAGECLASS_ADVANCED {
 		// storageclass_type;volumeType;clusterId;FSUUID;consistency_group;filesetName;snapshotName;metaSnapshotName
-		snapID = fmt.Sprintf("%s;%s;%s;%s;%s;%s;%s;%s", volumeIDMembers.StorageClassType, volumeIDMembers.VolType, volumeIDMembers.ClusterId, volumeIDMembers.FsUUID, filesetName, filesetResp.FilesetName, snapName, req.GetName())
+		snapHandle.ConsistencyGroup = filesetName
+		snapHandle.FilesetName = filesetResp.FilesetName
+		snapHandle.MetaSnapshotName = req.GetName()
+	} else if strings.Contains(filesetResp.Config.Comment, connectors.FilesetComment) {
+		// Dynamically created PVC, here path is the xxx-data directory within the fileset where all volume data resides
+		// storageclass_type;volumeType;clusterId;FSUUID;consistency_group;filesetName;snapshotName;metaSnapshotName;path
+		snapHandle.Path = filesetName + "-data"
 	} else {
-		if strings.Contains(filesetResp.Config.Comment, connectors.FilesetComment) {
-			// Dynamically created PVC, here path is the xxx-data directory within the fileset where all volume data resides
-			// storageclass_type;volumeType;clusterId;FSUUID;consistency_group;filesetName;snapshotName;metaSnapshotName;path
-			snapID = fmt.Sprintf("%s;%s;%s;%s;%s;%s;%s;%s;%s-data", volumeIDMembers.StorageClassType, volumeIDMembers.VolType, volumeIDMembers.ClusterId, volumeIDMembers.FsUUID, "", filesetName, snapName, "", filesetName)
-		} else {
-			// This is statically created PVC from an independent fileset, here path is the root of fileset
-			// storageclass_type;volumeType;clusterId;FSUUID;consistency_group;filesetName;snapshotName;metaSnapshotName;/
-			snapID = fmt.Sprintf("%s;%s;%s;%s;%s;%s;%s;%s;/", volumeIDMembers.StorageClassType, volumeIDMembers.VolType, volumeIDMembers.ClusterId, volumeIDMembers.FsUUID, "", filesetName, snapName, "")
-			klog.Infof("[%s] CreateSnapshot - create snapshot  snapID, [%v]", loggerId, snapID)
-		}
+		// This is statically created PVC from an independent fileset, here path is the root of fileset
+		// storageclass_type;volumeType;clusterId;FSUUID;consistency_group;filesetName;snapshotName;metaSnapshotName;/
+		snapHandle.Path = "/"
+	}
+	snapID, err := snapHandle.Encode()
+	if err != nil {
+		klog.Errorf("[%s] CreateSnapshot [%s] - unable to encode snapshot ID. Error [%v]", loggerId, snapName, err)
+		return nil, status.Error(codes.Internal, fmt.Sprintf("unable to encode snapshot ID for [%s]. Error [%v]", snapName, err))
 	}
+	klog.Infof("[%s] CreateSnapshot - create snapshot  snapID, [%v]", loggerId, snapID)
 
 	timestamp, err := cs.getSnapshotCreateTimestamp(ctx, conn, filesystemName, filesetName, snapName)
 	if err != nil {
@@ -3726,7 +4278,7 @@ func (cs *ScaleControllerServer) CheckIfNewSnapshotIsRequired(ctx context.Contex
 	return snapName, createNewSnap, nil
 }
 
-func (cs *ScaleControllerServer) CreateNewSnapshot(ctx context.Context, conn connectors.SpectrumScaleConnector, filesystemName, filesetName, snapName, storageClassType string, snapExist bool) (string, error) {
+func (cs *ScaleControllerServer) CreateNewSnapshot(ctx context.Context, conn connectors.SpectrumScaleConnector, filesystemName, fsUUID, filesetName, snapName, storageClassType string, snapExist bool, pruneSnapshots bool) (string, error) {
 	loggerId := utils.GetLoggerId(ctx)
 
 	if storageClassType == STORAGECLASS_ADVANCED {
@@ -3745,7 +4297,20 @@ func (cs *ScaleControllerServer) CreateNewSnapshot(ctx context.Context, conn con
 		return snapName, status.Error(codes.Internal, fmt.Sprintf("unable to list snapshots for fileset [%s:%s]. Error: [%v]", filesystemName, filesetName, err))
 	}
 
-	if len(snapshotList) >= 256 {
+	if len(snapshotList) >= maxSnapshotsPerFileset && pruneSnapshots {
+		pruned, err := cs.pruneUnreferencedSnapshots(ctx, conn, filesystemName, fsUUID, filesetName, storageClassType, snapName, snapshotList)
+		if err != nil {
+			klog.Errorf("[%s] CreateSnapshot [%s] - unable to prune snapshots of fileset [%s:%s]. Error: [%v]", loggerId, snapName, filesystemName, filesetName, err)
+		}
+		klog.Infof("[%s] CreateSnapshot [%s] - pruned [%d] unreferenced snapshots of fileset [%s:%s]: %v", loggerId, snapName, len(pruned), filesystemName, filesetName, pruned)
+		snapshotList, err = conn.ListFilesetSnapshots(ctx, filesystemName, filesetName)
+		if err != nil {
+			klog.Errorf("[%s] CreateSnapshot [%s] - unable to list snapshots for fileset [%s:%s]. Error: [%v]", loggerId, snapName, filesystemName, filesetName, err)
+			return snapName, status.Error(codes.Internal, fmt.Sprintf("unable to list snapshots for fileset [%s:%s]. Error: [%v]", filesystemName, filesetName, err))
+		}
+	}
+
+	if len(snapshotList) >= maxSnapshotsPerFileset {
 		klog.Errorf("[%s] CreateSnapshot [%s] - max limit of snapshots reached for fileset [%s:%s]. No more snapshots can be created for this fileset.", loggerId, snapName, filesystemName, filesetName)
 		return snapName, status.Error(codes.OutOfRange, fmt.Sprintf("max limit of snapshots reached for fileset [%s:%s]. No more snapshots can be created for this fileset.", filesystemName, filesetName))
 	}
@@ -3759,6 +4324,176 @@ func (cs *ScaleControllerServer) CreateNewSnapshot(ctx context.Context, conn con
 	return snapName, nil
 }
 
+// pruneUnreferencedSnapshots deletes the oldest snapshots of a fileset which are
+// created by CSI but are no longer referenced by any VolumeSnapshotContent, until
+// the fileset is below the snapshot limit again. It returns the names of the
+// deleted snapshots.
+func (cs *ScaleControllerServer) pruneUnreferencedSnapshots(ctx context.Context, conn connectors.SpectrumScaleConnector, filesystemName, fsUUID, filesetName, storageClassType, snapName string, snapshotList []connectors.Snapshot_v2) ([]string, error) {
+	loggerId := utils.GetLoggerId(ctx)
+	pruned := []string{}
+
+	referenced, err := cs.getReferencedSnapshots(ctx, fsUUID, filesetName, storageClassType)
+	if err != nil {
+		return pruned, fmt.Errorf("unable to get the snapshots referenced by VolumeSnapshotContents. Error: [%v]", err)
+	}
+
+	candidates := []connectors.Snapshot_v2{}
+	for _, snapshot := range snapshotList {
+		if !csiSnapshotNameRegex.MatchString(snapshot.SnapshotName) || referenced[snapshot.SnapshotName] {
+			continue
+		}
+		candidates = append(candidates, snapshot)
+	}
+	// snapshot IDs are assigned in increasing order, lowest is the oldest
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].SnapID < candidates[j].SnapID })
+	klog.Infof("[%s] pruneUnreferencedSnapshots - fileset [%s:%s] has [%d] snapshots, [%d] of them are unreferenced CSI snapshots", loggerId, filesystemName, filesetName, len(snapshotList), len(candidates))
+
+	customPath := ""
+	if len(candidates) > 0 {
+		fsMountPoint, err := conn.GetFilesystemMountDetails(ctx, filesystemName)
+		if err != nil {
+			return pruned, fmt.Errorf("unable to get mount info for FS [%v] in cluster", filesystemName)
+		}
+		filesetInfo, err := conn.ListFileset(ctx, filesystemName, filesetName)
+		if err != nil {
+			return pruned, fmt.Errorf("unable to list fileset [%v] in filesystem [%v] Error: %v", filesetName, filesystemName, err)
+		}
+		newPath := strings.Replace(filesetInfo.Config.Path, fsMountPoint.MountPoint, "", 1)
+		customPath = strings.Trim(strings.Replace(newPath, filesetName, "", 1), "!/")
+	}
+
+	remaining := len(snapshotList)
+	for _, snapshot := range candidates {
+		if remaining < maxSnapshotsPerFileset {
+			break
+		}
+		basePath := filesetName
+		if customPath != "" {
+			basePath = fmt.Sprintf("%s/%s", customPath, filesetName)
+		}
+		deleted, err := cs.pruneSnapshot(ctx, conn, filesystemName, filesetName, basePath, storageClassType, snapshot.SnapshotName)
+		if err != nil {
+			return pruned, err
+		}
+		if !deleted {
+			continue
+		}
+		klog.Infof("[%s] pruneUnreferencedSnapshots - pruned snapshot [%s] created at [%s] from fileset [%s:%s] as it is not referenced by any VolumeSnapshotContent", loggerId, snapshot.SnapshotName, snapshot.Created, filesystemName, filesetName)
+		cs.recordSnapshotPruned(snapName, filesystemName, filesetName, snapshot)
+		pruned = append(pruned, snapshot.SnapshotName)
+		remaining--
+	}
+	return pruned, nil
+}
+
+// pruneSnapshot deletes an unreferenced snapshot of a fileset unless it is
+// still the source of a shallow copy volume, and returns whether it was
+// deleted.
+func (cs *ScaleControllerServer) pruneSnapshot(ctx context.Context, conn connectors.SpectrumScaleConnector, filesystemName, filesetName, basePath, storageClassType, snapshotName string) (bool, error) {
+	loggerId := utils.GetLoggerId(ctx)
+	oldMetadataPath := fmt.Sprintf("%s/%s", basePath, snapshotName)
+	newMetadataPath := fmt.Sprintf("%s/.csimetadata/%s", basePath, snapshotName)
+
+	if storageClassType == STORAGECLASS_ADVANCED {
+		// shallow copy volumes of a consistency group snapshot are tracked
+		// below its metadata directory, hold the lock of createSnapshotTrackingDir
+		// as a delete so that no volume is created from the snapshot meanwhile
+		trackingPath := fmt.Sprintf("%s/.csimetadata/%s", filesetName, snapshotName)
+		lockCtx := utils.SetModuleName(ctx, deleteSnapshot)
+		if !CgSnapshotLock(lockCtx, trackingPath, true) {
+			klog.Infof("[%s] pruneSnapshot - skipping snapshot [%s] of fileset [%s:%s] as another operation is in progress for it", loggerId, snapshotName, filesystemName, filesetName)
+			return false, nil
+		}
+		defer CgSnapshotUnlock(lockCtx, trackingPath)
+	}
+
+	// keep snapshots which are still used as source of a shallow copy volume
+	deleteOld, err := cs.CheckShallowCopyReferencePath(ctx, conn, filesystemName, snapshotName, oldMetadataPath, false)
+	if err != nil || !deleteOld {
+		klog.Infof("[%s] pruneSnapshot - skipping snapshot [%s] of fileset [%s:%s] as it may be referenced by a shallow copy volume", loggerId, snapshotName, filesystemName, filesetName)
+		return false, nil
+	}
+	deleteNew, err := cs.CheckShallowCopyReferencePath(ctx, conn, filesystemName, snapshotName, newMetadataPath, true)
+	if err != nil || !deleteNew {
+		klog.Infof("[%s] pruneSnapshot - skipping snapshot [%s] of fileset [%s:%s] as it may be referenced by a shallow copy volume", loggerId, snapshotName, filesystemName, filesetName)
+		return false, nil
+	}
+
+	if storageClassType == STORAGECLASS_ADVANCED {
+		// the metadata directories of the consistency group snapshot are empty
+		// and no VolumeSnapshotContent refers to the snapshot anymore
+		for _, metadataPath := range []string{oldMetadataPath, newMetadataPath} {
+			err := conn.DeleteDirectory(ctx, filesystemName, metadataPath, false)
+			if err != nil && !(strings.Contains(err.Error(), "EFSSG0264C") || strings.Contains(err.Error(), "does not exist")) {
+				return false, fmt.Errorf("unable to delete stale snapshot metadata directory [%s] in filesystem [%s]. Error: [%v]", metadataPath, filesystemName, err)
+			}
+		}
+	}
+
+	err = conn.DeleteSnapshot(ctx, filesystemName, filesetName, snapshotName)
+	if err != nil {
+		return false, fmt.Errorf("unable to delete snapshot [%s] of fileset [%s:%s]. Error: [%v]", snapshotName, filesystemName, filesetName, err)
+	}
+	return true, nil
+}
+
+// recordSnapshotPruned emits an event for a pruned snapshot on the
+// VolumeSnapshotContent of the snapshot whose creation pruned it.
+func (cs *ScaleControllerServer) recordSnapshotPruned(snapName, filesystemName, filesetName string, snapshot connectors.Snapshot_v2) {
+	if cs.Driver.recorder == nil {
+		return
+	}
+	content := &corev1.ObjectReference{
+		APIVersion: volumeSnapshotContentGVR.GroupVersion().String(),
+		Kind:       "VolumeSnapshotContent",
+		Name:       fmt.Sprintf("snapcontent-%s", strings.TrimPrefix(snapName, "snapshot-")),
+	}
+	cs.Driver.recorder.Eventf(content, corev1.EventTypeNormal, snapshotPrunedReason, "Deleted snapshot %s created at %s of fileset %s:%s to stay below the limit of %d snapshots, no VolumeSnapshotContent refers to it", snapshot.SnapshotName, snapshot.Created, filesystemName, filesetName, maxSnapshotsPerFileset)
+}
+
+// getReferencedSnapshots returns the names of the snapshots of a fileset which
+// are referenced by a VolumeSnapshotContent of this driver.
+func (cs *ScaleControllerServer) getReferencedSnapshots(ctx context.Context, fsUUID, filesetName, storageClassType string) (map[string]bool, error) {
+	loggerId := utils.GetLoggerId(ctx)
+	if cs.Driver.dynamicClient == nil {
+		return nil, fmt.Errorf("kubernetes client is not initialized")
+	}
+	contentList, err := cs.Driver.dynamicClient.Resource(volumeSnapshotContentGVR).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	referenced := make(map[string]bool)
+	for _, content := range contentList.Items {
+		driverName, _, _ := unstructured.NestedString(content.Object, "spec", "driver")
+		if driverName != cs.Driver.name {
+			continue
+		}
+		snapHandle, found, _ := unstructured.NestedString(content.Object, "status", "snapshotHandle")
+		if !found {
+			snapHandle, found, _ = unstructured.NestedString(content.Object, "spec", "source", "snapshotHandle")
+		}
+		if !found {
+			// snapshot creation is still in progress, its name is the one the snapshot will get
+			klog.V(4).Infof("[%s] getReferencedSnapshots - VolumeSnapshotContent [%s] has no snapshotHandle yet", loggerId, content.GetName())
+			referenced[fmt.Sprintf("snapshot-%s", strings.TrimPrefix(content.GetName(), "snapcontent-"))] = true
+			continue
+		}
+		snapIdMembers, err := cs.GetSnapIdMembers(snapHandle)
+		if err != nil || snapIdMembers.FsUUID != fsUUID {
+			continue
+		}
+		if storageClassType == STORAGECLASS_ADVANCED && snapIdMembers.ConsistencyGroup != filesetName {
+			continue
+		}
+		if storageClassType != STORAGECLASS_ADVANCED && snapIdMembers.FsetName != filesetName {
+			continue
+		}
+		referenced[snapIdMembers.SnapName] = true
+	}
+	return referenced, nil
+}
+
 func (cs *ScaleControllerServer) retryToCreateNewSnap(ctx context.Context) {
 
 	loggerId := utils.GetLoggerId(ctx)
@@ -3924,6 +4659,20 @@ func (cs *ScaleControllerServer) DelSnapMetadataDir(ctx context.Context, conn co
 	return false, nil
 }
 
+// parseStatDirOwner returns the uid, gid and permission bits from the output of
+// stat on a directory.
+func parseStatDirOwner(statInfo string) (string, string, string, error) {
+	match := statOwnerRegex.FindStringSubmatch(statInfo)
+	if len(match) != 4 {
+		return "", "", "", fmt.Errorf("unable to parse stat output [%s]", statInfo)
+	}
+	permissions := match[1]
+	if len(permissions) > 3 {
+		permissions = permissions[len(permissions)-3:]
+	}
+	return match[2], match[3], permissions, nil
+}
+
 func parseStatDirInfo(statInfo string) (int, error) {
 	statSplit := strings.Split(statInfo, "\n")
 	thirdLineSplit := strings.Split(statSplit[2], " ")
@@ -3933,14 +4682,26 @@ func parseStatDirInfo(statInfo string) (int, error) {
 	return nlink, err
 }
 
-// DeleteSnapshot - Delete snapshot
-func (cs *ScaleControllerServer) DeleteSnapshot(newctx context.Context, req *csi.DeleteSnapshotRequest) (*csi.DeleteSnapshotResponse, error) {
+// DeleteSnapshot deletes a snapshot. A retry of a DeleteSnapshot in progress
+// waits for its result.
+func (cs *ScaleControllerServer) DeleteSnapshot(ctx context.Context, req *csi.DeleteSnapshotRequest) (*csi.DeleteSnapshotResponse, error) {
+	ctx = audit.WithRequest(ctx, audit.Request{Operation: deleteSnapshot, Name: req.GetSnapshotId()})
+	return trackOperation(ctx, cs.Driver.optracker, snapshotOperationKey(req.GetSnapshotId()), deleteSnapshot, "", func() (*csi.DeleteSnapshotResponse, error) {
+		return cs.handleDeleteSnapshot(ctx, req)
+	})
+}
+
+func (cs *ScaleControllerServer) handleDeleteSnapshot(newctx context.Context, req *csi.DeleteSnapshotRequest) (*csi.DeleteSnapshotResponse, error) {
 	loggerId := utils.GetLoggerId(newctx)
 	ctx := utils.SetModuleName(newctx, deleteSnapshot)
 
 	reqToLog := proto.Clone(req).(*csi.DeleteSnapshotRequest)
 	reqToLog.Secrets = nil
 	klog.Infof("[%s] DeleteSnapshot - delete snapshot req: %v", loggerId, reqToLog)
+	ctx, err := cs.withSecretCredentials(ctx, req.GetSecrets())
+	if err != nil {
+		return nil, err
+	}
 
 	if err := cs.Driver.ValidateControllerServiceRequest(ctx, csi.ControllerServiceCapability_RPC_CREATE_DELETE_SNAPSHOT); err != nil {
 		klog.Errorf("[%s] DeleteSnapshot - invalid delete snapshot req %v: %v", loggerId, reqToLog, err)
@@ -4168,12 +4929,26 @@ func (cs *ScaleControllerServer) GetCapacity(ctx context.Context, req *csi.GetCa
 func (cs *ScaleControllerServer) ListVolumes(ctx context.Context, req *csi.ListVolumesRequest) (*csi.ListVolumesResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "")
 }
+
+// ControllerExpandVolume expands the quota of a volume.
 func (cs *ScaleControllerServer) ControllerExpandVolume(ctx context.Context, req *csi.ControllerExpandVolumeRequest) (*csi.ControllerExpandVolumeResponse, error) {
+	requiredBytes := strconv.FormatInt(req.GetCapacityRange().GetRequiredBytes(), 10)
+	ctx = audit.WithRequest(ctx, audit.Request{Operation: expandVolume, Name: req.GetVolumeId()})
+	return trackOperation(ctx, cs.Driver.optracker, volumeOperationKey(req.GetVolumeId()), expandVolume, requiredBytes, func() (*csi.ControllerExpandVolumeResponse, error) {
+		return cs.handleControllerExpandVolume(ctx, req)
+	})
+}
+
+func (cs *ScaleControllerServer) handleControllerExpandVolume(ctx context.Context, req *csi.ControllerExpandVolumeRequest) (*csi.ControllerExpandVolumeResponse, error) {
 	loggerId := utils.GetLoggerId(ctx)
 
 	reqToLog := proto.Clone(req).(*csi.ControllerExpandVolumeRequest)
 	reqToLog.Secrets = nil
 	klog.Infof("[%s] ControllerExpandVolume - Volume expand req: %v", loggerId, reqToLog)
+	ctx, err := cs.withSecretCredentials(ctx, req.GetSecrets())
+	if err != nil {
+		return nil, err
+	}
 
 	if err := cs.Driver.ValidateControllerServiceRequest(ctx, csi.ControllerServiceCapability_RPC_EXPAND_VOLUME); err != nil {
 		klog.Errorf("[%s] ControllerExpandVolume - invalid expand volume req: %v", loggerId, reqToLog)
@@ -4307,7 +5082,80 @@ func (cs *ScaleControllerServer) ControllerExpandVolume(ctx context.Context, req
 }
 
 func (cs *ScaleControllerServer) ControllerGetVolume(ctx context.Context, req *csi.ControllerGetVolumeRequest) (*csi.ControllerGetVolumeResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "")
+	loggerId := utils.GetLoggerId(ctx)
+	klog.Infof("[%s] ControllerGetVolume - req: %v", loggerId, req)
+
+	if err := cs.Driver.ValidateControllerServiceRequest(ctx, csi.ControllerServiceCapability_RPC_GET_VOLUME); err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("ControllerGetVolume ValidateControllerServiceRequest failed: %v", err))
+	}
+
+	volID := req.GetVolumeId()
+	if len(volID) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "volume ID missing in request")
+	}
+
+	volumeIDMembers, err := getVolIDMembers(volID)
+	if err != nil {
+		klog.Errorf("[%s] ControllerGetVolume - Error in Volume ID %v: %v", loggerId, volID, err)
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("ControllerGetVolume - Error in Volume ID %v: %v", volID, err))
+	}
+
+	conn, err := cs.getConnFromClusterID(ctx, volumeIDMembers.ClusterId)
+	if err != nil {
+		return nil, err
+	}
+
+	filesystemName, err := conn.GetFilesystemName(ctx, volumeIDMembers.FsUUID)
+	if err != nil {
+		klog.Errorf("[%s] ControllerGetVolume - unable to get filesystem Name for Filesystem Uid [%v] and clusterId [%v]. Error [%v]", loggerId, volumeIDMembers.FsUUID, volumeIDMembers.ClusterId, err)
+		return nil, status.Error(codes.Internal, fmt.Sprintf("ControllerGetVolume - unable to get filesystem Name for Filesystem Uid [%v] and clusterId [%v]. Error [%v]", volumeIDMembers.FsUUID, volumeIDMembers.ClusterId, err))
+	}
+
+	fsDetails, err := conn.GetFilesystemDetails(ctx, filesystemName)
+	if err != nil {
+		klog.Errorf("[%s] unable to get filesystem details for [%v]. Error [%v]", loggerId, filesystemName, err)
+		return nil, status.Error(codes.Internal, fmt.Sprintf("unable to get filesystem details for [%v]. Error [%v]", filesystemName, err))
+	}
+
+	var capacity uint64
+	filesetName := ""
+	if volumeIDMembers.IsFilesetBased && volumeIDMembers.VolType != FILE_SHALLOWCOPY_VOLUME {
+		filesetName = volumeIDMembers.FsetName
+		fsetExist, err := conn.CheckIfFilesetExist(ctx, filesystemName, filesetName)
+		if err != nil {
+			klog.Errorf("[%s] unable to check fileset [%v] existance in filesystem [%v]. Error [%v]", loggerId, filesetName, filesystemName, err)
+			return nil, status.Error(codes.Internal, fmt.Sprintf("unable to check fileset [%v] existance in filesystem [%v]. Error [%v]", filesetName, filesystemName, err))
+		}
+		if !fsetExist {
+			return nil, status.Error(codes.NotFound, fmt.Sprintf("fileset [%v] does not exist in filesystem [%v]", filesetName, filesystemName))
+		}
+
+		quota, err := conn.ListFilesetQuota(ctx, filesystemName, filesetName)
+		if err != nil {
+			klog.Errorf("[%s] unable to list quota for fileset [%v] in filesystem [%v]. Error [%v]", loggerId, filesetName, filesystemName, err)
+			return nil, status.Error(codes.Internal, fmt.Sprintf("unable to list quota for fileset [%v] in filesystem [%v]. Error [%v]", filesetName, filesystemName, err))
+		}
+		capacity, err = ConvertToBytes(quota)
+		if err != nil {
+			// Invalid number means quota is not set
+			capacity = 0
+		}
+	}
+
+	dataReplicas, metadataReplicas := cs.getVolReplication(ctx, conn, filesystemName, filesetName, fsDetails)
+	klog.V(4).Infof("[%s] ControllerGetVolume - volume [%v] dataReplicas [%d], metadataReplicas [%d]", loggerId, volID, dataReplicas, metadataReplicas)
+
+	return &csi.ControllerGetVolumeResponse{
+		Volume: &csi.Volume{
+			VolumeId:      volID,
+			CapacityBytes: int64(capacity), // #nosec G115 -- false positive
+			VolumeContext: map[string]string{
+				connectors.UserSpecifiedDataReplicas:     strconv.Itoa(dataReplicas),
+				connectors.UserSpecifiedMetadataReplicas: strconv.Itoa(metadataReplicas),
+			},
+		},
+		Status: &csi.ControllerGetVolumeResponse_VolumeStatus{},
+	}, nil
 }
 
 // getRemoteClusterID returns the cluster ID for the passed cluster name.
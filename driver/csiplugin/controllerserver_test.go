@@ -0,0 +1,97 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package scale
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+const testDriverName = "spectrumscale.csi.ibm.com"
+
+func testPV(name, volumeHandle string) *corev1.PersistentVolume {
+	return &corev1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: corev1.PersistentVolumeSpec{
+			PersistentVolumeSource: corev1.PersistentVolumeSource{
+				CSI: &corev1.CSIPersistentVolumeSource{Driver: testDriverName, VolumeHandle: volumeHandle},
+			},
+		},
+	}
+}
+
+func testVolumeAttachment(name, attacher, pvName string) *storagev1.VolumeAttachment {
+	return &storagev1.VolumeAttachment{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: storagev1.VolumeAttachmentSpec{
+			Attacher: attacher,
+			NodeName: "worker-1",
+			Source:   storagev1.VolumeAttachmentSource{PersistentVolumeName: &pvName},
+		},
+	}
+}
+
+func TestIsVolumeAttached(t *testing.T) {
+	const (
+		compressedHandle = "0;2;7118073361626808055;09762E35:5D26932A;;pvc-1-COMPRESSZcsi;/ibm/gpfs0/pvc-1-COMPRESSZcsi/pvc-1-COMPRESSZcsi-data"
+		tieredHandle     = "0;2;7118073361626808055;09762E35:5D26932A;;pvc-2-Tgoldcsi;/ibm/gpfs0/pvc-2-Tgoldcsi/pvc-2-Tgoldcsi-data"
+		staticHandle     = "0;2;7118073361626808055;09762E35:5D26932A;;data;/ibm/gpfs0/data/data-data"
+		detachedHandle   = "0;2;7118073361626808055;09762E35:5D26932A;;pvc-4-Tgoldcsi;/ibm/gpfs0/pvc-4-Tgoldcsi/pvc-4-Tgoldcsi-data"
+		otherHandle      = "0;2;7118073361626808055;09762E35:5D26932A;;pvc-5;/ibm/gpfs0/pvc-5/pvc-5-data"
+	)
+	clientset := fake.NewClientset(
+		testPV("pvc-1", compressedHandle),
+		testPV("pvc-2", tieredHandle),
+		testPV("static-pv", staticHandle),
+		testPV("pvc-4", detachedHandle),
+		testPV("pvc-5", otherHandle),
+		testVolumeAttachment("csi-1", testDriverName, "pvc-1"),
+		testVolumeAttachment("csi-2", testDriverName, "pvc-2"),
+		testVolumeAttachment("csi-3", testDriverName, "static-pv"),
+		testVolumeAttachment("csi-5", "other.csi.driver", "pvc-5"),
+		testVolumeAttachment("csi-6", testDriverName, "deleted-pv"),
+	)
+	cs := &ScaleControllerServer{Driver: &ScaleDriver{name: testDriverName, clientset: clientset}}
+
+	tests := []struct {
+		name         string
+		volumeHandle string
+		want         bool
+	}{
+		{"compressed volume", compressedHandle, true},
+		{"tiered volume", tieredHandle, true},
+		{"static volume", staticHandle, true},
+		{"detached volume", detachedHandle, false},
+		{"volume attached by another driver", otherHandle, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := cs.isVolumeAttached(context.Background(), tt.volumeHandle)
+			if err != nil {
+				t.Fatalf("isVolumeAttached() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("isVolumeAttached() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
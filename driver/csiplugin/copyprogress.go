@@ -0,0 +1,198 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package scale
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/IBM/ibm-spectrum-scale-csi/driver/csiplugin/connectors"
+	"github.com/IBM/ibm-spectrum-scale-csi/driver/csiplugin/utils"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// copyProgressAnnotation is set on the PVC of a volume created from a
+	// snapshot or volume with the progress of the copy job
+	copyProgressAnnotation = "spectrumscale.csi.ibm.com/copy-progress"
+	copyProgressCompleted  = "100%"
+	copyProgressInterval   = 30 * time.Second
+	jobStatusRunning       = "RUNNING"
+)
+
+var copyProgressRegex = regexp.MustCompile(`(\d{1,3}(?:\.\d+)?)\s*%`)
+
+// jobProgress returns the last percentage reported in the progress output of
+// an asynchronous job.
+func jobProgress(job connectors.Job) (string, bool) {
+	for i := len(job.Result.Progress) - 1; i >= 0; i-- {
+		matches := copyProgressRegex.FindAllStringSubmatch(job.Result.Progress[i], -1)
+		if len(matches) != 0 {
+			return matches[len(matches)-1][1] + "%", true
+		}
+	}
+	return "", false
+}
+
+// monitorCopyJob polls the copy job of a volume until the returned stop
+// function is called. The progress of the job is set as annotation on the PVC
+// of the volume and the job is cancelled if the PVC was deleted, as the
+// provisioner stops retrying CreateVolume then.
+func (cs *ScaleControllerServer) monitorCopyJob(ctx context.Context, conn connectors.SpectrumScaleConnector, scVol *scaleVolume, jobID uint64) func() {
+	if cs.Driver.clientset == nil || scVol.PVCName == "" || scVol.Namespace == "" {
+		return func() {}
+	}
+	loggerId := utils.GetLoggerId(ctx)
+	// the copy job outlives the CreateVolume request which started it
+	ctx = context.WithoutCancel(ctx)
+	done := make(chan struct{})
+
+	go func() {
+		ticker := time.NewTicker(copyProgressInterval)
+		defer ticker.Stop()
+		lastProgress := ""
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+			}
+
+			pvc, err := cs.Driver.clientset.CoreV1().PersistentVolumeClaims(scVol.Namespace).Get(ctx, scVol.PVCName, metav1.GetOptions{})
+			if apierrors.IsNotFound(err) || (err == nil && pvc.DeletionTimestamp != nil) {
+				klog.Infof("[%s] volume:[%v] - PVC [%s/%s] was deleted, cancelling copy job %d", loggerId, scVol.VolName, scVol.Namespace, scVol.PVCName, jobID)
+				if err := conn.CancelJob(ctx, jobID); err != nil {
+					klog.Errorf("[%s] volume:[%v] - unable to cancel copy job %d. Error: %v", loggerId, scVol.VolName, jobID, err)
+				}
+				return
+			} else if err != nil {
+				klog.V(4).Infof("[%s] volume:[%v] - unable to get PVC [%s/%s]. Error: %v", loggerId, scVol.VolName, scVol.Namespace, scVol.PVCName, err)
+				continue
+			}
+
+			job, err := conn.GetJob(ctx, jobID)
+			if err != nil {
+				klog.V(4).Infof("[%s] volume:[%v] - unable to get copy job %d. Error: %v", loggerId, scVol.VolName, jobID, err)
+				continue
+			}
+			if job.Status != jobStatusRunning {
+				return
+			}
+			progress, found := jobProgress(job)
+			if !found || progress == lastProgress {
+				continue
+			}
+			klog.V(4).Infof("[%s] volume:[%v] - copy job %d progress %s", loggerId, scVol.VolName, jobID, progress)
+			if err := cs.setCopyProgress(ctx, scVol, progress); err == nil {
+				lastProgress = progress
+			}
+		}
+	}()
+
+	return func() { close(done) }
+}
+
+// setCopyProgress sets the copy progress annotation on the PVC of a volume.
+func (cs *ScaleControllerServer) setCopyProgress(ctx context.Context, scVol *scaleVolume, progress string) error {
+	if cs.Driver.clientset == nil || scVol.PVCName == "" || scVol.Namespace == "" {
+		return nil
+	}
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]string{copyProgressAnnotation: progress},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = cs.Driver.clientset.CoreV1().PersistentVolumeClaims(scVol.Namespace).Patch(ctx, scVol.PVCName, types.MergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		klog.Errorf("[%s] volume:[%v] - unable to set copy progress on PVC [%s/%s]. Error: %v", utils.GetLoggerId(ctx), scVol.VolName, scVol.Namespace, scVol.PVCName, err)
+		return fmt.Errorf("unable to set copy progress on PVC [%s/%s]: %w", scVol.Namespace, scVol.PVCName, err)
+	}
+	return nil
+}
+
+// cancelCopyJob cancels the copy job of a volume which is deleted while its
+// content is still copied. The job is found through the operation journal of
+// the CreateVolume of the volume.
+func (cs *ScaleControllerServer) cancelCopyJob(ctx context.Context, volName string) {
+	loggerId := utils.GetLoggerId(ctx)
+	if cs.Driver.journal == nil || volName == "" {
+		return
+	}
+	rec, found, err := cs.Driver.journal.Get(volName)
+	if err != nil {
+		klog.Errorf("[%s] unable to read operation journal of volume [%s]. Error: %v", loggerId, volName, err)
+		return
+	}
+	if !found || rec.CopyJob == nil {
+		return
+	}
+
+	running := false
+	if jobDetails, ok := cs.Driver.snapjobstatusmap.Load(volName); ok {
+		running = jobDetails.(SnapCopyJobDetails).jobStatus == SNAP_JOB_RUNNING
+	}
+	if jobDetails, ok := cs.Driver.volcopyjobstatusmap.Load(volName); ok {
+		running = running || jobDetails.(VolCopyJobDetails).jobStatus == VOLCOPY_JOB_RUNNING
+	}
+	if !running {
+		return
+	}
+
+	conn, err := cs.getConnFromClusterID(ctx, rec.CopyJob.ClusterID)
+	if err != nil {
+		klog.Errorf("[%s] unable to cancel copy job %d of volume [%s]. Error: %v", loggerId, rec.CopyJob.JobID, volName, err)
+		return
+	}
+	klog.Infof("[%s] volume [%s] is deleted while copy job %d is running, cancelling the job", loggerId, volName, rec.CopyJob.JobID)
+	if err := conn.CancelJob(ctx, rec.CopyJob.JobID); err != nil {
+		klog.Errorf("[%s] unable to cancel copy job %d of volume [%s]. Error: %v", loggerId, rec.CopyJob.JobID, volName, err)
+		return
+	}
+	// the volume is deleted, it must not be rolled back on the next start
+	cs.journalComplete(ctx, volName)
+}
+
+// waitForRevertJob waits in the background for the copy job of a volume
+// revert and stores its result under jobKey, where the retried
+// ControllerModifyVolume finds it.
+func (cs *ScaleControllerServer) waitForRevertJob(ctx context.Context, conn connectors.SpectrumScaleConnector, jobKey, volumeId string, jobStatus int, jobID uint64) {
+	loggerId := utils.GetLoggerId(ctx)
+	// the copy job outlives the ControllerModifyVolume request which started it
+	ctx = context.WithoutCancel(ctx)
+	cs.Driver.asyncJobs.Add(1)
+	go func() {
+		defer cs.Driver.asyncJobs.Done()
+		jobDetails := SnapCopyJobDetails{SNAP_JOB_COMPLETED, volumeId}
+		response, err := conn.WaitForJobCompletionWithResp(ctx, jobStatus, jobID)
+		if err != nil || (len(response.Jobs) != 0 && response.Jobs[0].Status == ResponseStatusUnknown) {
+			klog.Errorf("[%s] copy job %d reverting volume [%s] did not complete. Error: %v", loggerId, jobID, volumeId, err)
+			jobDetails.jobStatus = SNAP_JOB_FAILED
+		} else {
+			klog.Infof("[%s] copy job %d reverting volume [%s] completed", loggerId, jobID, volumeId)
+		}
+		cs.Driver.snapjobstatusmap.Store(jobKey, jobDetails)
+	}()
+}
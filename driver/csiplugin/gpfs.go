@@ -20,18 +20,27 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"path"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/IBM/ibm-spectrum-scale-csi/driver/csiplugin/connectors"
+	"github.com/IBM/ibm-spectrum-scale-csi/driver/csiplugin/pkg/journal"
+	"github.com/IBM/ibm-spectrum-scale-csi/driver/csiplugin/pkg/scalehandle"
 	"github.com/IBM/ibm-spectrum-scale-csi/driver/csiplugin/settings"
 	"github.com/IBM/ibm-spectrum-scale-csi/driver/csiplugin/utils"
 	"github.com/container-storage-interface/spec/lib/go/csi"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/klog/v2"
 )
 
@@ -46,22 +55,23 @@ const (
 	VOLCOPY_JOB_NOT_STARTED = 7
 	JOB_STATUS_UNKNOWN      = 8
 
-	STORAGECLASS_CLASSIC  = "0"
-	STORAGECLASS_ADVANCED = "1"
-	STORAGECLASS_CACHE    = "2"
+	STORAGECLASS_CLASSIC  = scalehandle.ClassicStorageClass
+	STORAGECLASS_ADVANCED = scalehandle.AdvancedStorageClass
+	STORAGECLASS_CACHE    = scalehandle.CacheStorageClass
 
 	// Volume types
-	FILE_DIRECTORYBASED_VOLUME     = "0"
-	FILE_DEPENDENTFILESET_VOLUME   = "1"
-	FILE_INDEPENDENTFILESET_VOLUME = "2"
-	FILE_SHALLOWCOPY_VOLUME        = "3"
-	FILE_VMDISKOPTIMIZED_VOLUME    = "4"
+	FILE_DIRECTORYBASED_VOLUME     = scalehandle.LightweightVolume
+	FILE_DEPENDENTFILESET_VOLUME   = scalehandle.DependentFilesetVolume
+	FILE_INDEPENDENTFILESET_VOLUME = scalehandle.IndependentFilesetVolume
+	FILE_SHALLOWCOPY_VOLUME        = scalehandle.ShallowCopyVolume
+	FILE_VMDISKOPTIMIZED_VOLUME    = scalehandle.VMDiskOptimizedVolume
 
 	//	BLOCK_FILESET_VOLUME = 3
 
 	// defaultPrimaryFileset = "spectrum-scale-csi-volume-store"
 	// symlinkDir            = ".volumes"
 	volumeStatsCapability = "VOLUME_STATS_CAPABILITY"
+	snapshotDiffTreeWalk  = "SNAPSHOT_DIFF_TREE_WALK"
 )
 
 type SnapCopyJobDetails struct {
@@ -106,15 +116,28 @@ type ScaleDriver struct {
 	ids *ScaleIdentityServer
 	ns  *ScaleNodeServer
 	cs  *ScaleControllerServer
+	sds *ScaleSnapshotDiffServer
+	rs  *ScaleReplicationServer
 
 	connmap map[string]connectors.SpectrumScaleConnector
 	cmap    settings.ScaleSettingsConfigMap
 	primary settings.Primary
-	reqmap  map[string]int64
 
+	// optracker tracks the volume and snapshot operations in progress
+	optracker *operationTracker
+
+	// journal records the steps of CreateVolume and DeleteVolume operations
+	journal *journal.Journal
+
+	// snapjobstatusmap and volcopyjobstatusmap track the copy jobs of volumes
+	// created from a snapshot or volume, running jobs are restored from the
+	// operation journal on start
 	snapjobstatusmap    sync.Map
 	volcopyjobstatusmap sync.Map
 
+	// warmpoolmap stores the warm pools of pre-created filesets by pool id
+	warmpoolmap sync.Map
+
 	// clusterMap map stores the cluster name as key and cluster details as value.
 	clusterMap sync.Map
 
@@ -122,12 +145,27 @@ type ScaleDriver struct {
 	cscap []*csi.ControllerServiceCapability
 	nscap []*csi.NodeServiceCapability
 
-	clientset *kubernetes.Clientset
+	clientset     kubernetes.Interface
+	dynamicClient dynamic.Interface
+	// recorder emits events on Kubernetes objects, like the snapshots pruned
+	// by CreateSnapshot
+	recorder record.EventRecorder
+
+	// asyncJobs tracks the goroutines waiting for IBM Storage Scale jobs
+	// outside of a request, they are drained on shutdown
+	asyncJobs sync.WaitGroup
+	// stopping is closed when the driver starts to shut down
+	stopping chan struct{}
+
+	// configLoaded is set once SetupScaleDriver completed, health holds the
+	// results of the readiness checks served on /readyz
+	configLoaded atomic.Bool
+	health       healthState
 }
 
 func GetScaleDriver(ctx context.Context) *ScaleDriver {
 	klog.V(4).Infof("[%s] IBM Storage Scale GetScaleDriver", utils.GetLoggerId(ctx))
-	return &ScaleDriver{}
+	return &ScaleDriver{stopping: make(chan struct{})}
 }
 
 func NewIdentityServer(ctx context.Context, d *ScaleDriver) *ScaleIdentityServer {
@@ -142,7 +180,7 @@ func NewControllerServer(ctx context.Context, d *ScaleDriver, connMap map[string
 	d.connmap = connMap
 	d.cmap = cmap
 	d.primary = primary
-	d.reqmap = make(map[string]int64)
+	d.optracker = newOperationTracker()
 	return &ScaleControllerServer{
 		Driver: d,
 	}
@@ -201,8 +239,10 @@ func (driver *ScaleDriver) ValidateControllerServiceRequest(ctx context.Context,
 	return status.Error(codes.InvalidArgument, "Invalid controller service request")
 }
 
-func (driver *ScaleDriver) SetupScaleDriver(ctx context.Context, name, vendorVersion, nodeID string) error {
-	klog.Infof("[%s] SetupScaleDriver. name: %s, version: %v, nodeID: %s", utils.GetLoggerId(ctx), name, vendorVersion, nodeID)
+// SetupScaleDriver initializes the driver, stateDir is the persistent directory
+// which holds the operation journal of the controller.
+func (driver *ScaleDriver) SetupScaleDriver(ctx context.Context, name, vendorVersion, nodeID, stateDir string) error {
+	klog.Infof("[%s] SetupScaleDriver. name: %s, version: %v, nodeID: %s, stateDir: %s", utils.GetLoggerId(ctx), name, vendorVersion, nodeID, stateDir)
 	if name == "" {
 		return fmt.Errorf("driver name missing")
 	}
@@ -230,6 +270,7 @@ func (driver *ScaleDriver) SetupScaleDriver(ctx context.Context, name, vendorVer
 		csi.ControllerServiceCapability_RPC_EXPAND_VOLUME,
 		csi.ControllerServiceCapability_RPC_CLONE_VOLUME,
 		csi.ControllerServiceCapability_RPC_MODIFY_VOLUME,
+		csi.ControllerServiceCapability_RPC_GET_VOLUME,
 	}
 	_ = driver.AddControllerServiceCapabilities(ctx, csc)
 
@@ -246,34 +287,65 @@ func (driver *ScaleDriver) SetupScaleDriver(ctx context.Context, name, vendorVer
 	driver.ids = NewIdentityServer(ctx, driver)
 	driver.ns = NewNodeServer(ctx, driver)
 	driver.cs = NewControllerServer(ctx, driver, scmap, cmap, primary)
-	driver.clientset, err = initKubeClient(ctx)
+	driver.sds = NewSnapshotDiffServer(driver)
+	driver.rs = NewReplicationServer(driver)
+	driver.clientset, driver.dynamicClient, err = initKubeClient(ctx)
 	if err != nil {
 		klog.Errorf("[%s] failed to initialize kube client: %v", utils.GetLoggerId(ctx), err)
 		return err
 	}
+	driver.recorder = newEventRecorder(driver.clientset, name, nodeID)
+
+	driver.journal, err = journal.Open(path.Join(stateDir, "journal"))
+	if err != nil {
+		klog.Errorf("[%s] failed to open operation journal: %v", utils.GetLoggerId(ctx), err)
+		return err
+	}
+	driver.configLoaded.Store(true)
 	return nil
 }
 
-func initKubeClient(ctx context.Context) (*kubernetes.Clientset, error) {
+func initKubeClient(ctx context.Context) (kubernetes.Interface, dynamic.Interface, error) {
 	loggerId := utils.GetLoggerId(ctx)
 	klog.Infof("[%s] Initialize IBM Storage Scale CSI Kubernetes client", loggerId)
 	config, err := rest.InClusterConfig()
 	if err != nil {
 		klog.Errorf("[%s] Unable to get incluster config", loggerId)
-		return nil, fmt.Errorf("unable to get incluster config: %v", err)
+		return nil, nil, fmt.Errorf("unable to get incluster config: %v", err)
 	}
 
 	clientset, err := kubernetes.NewForConfig(config)
 	if err != nil {
 		klog.Errorf("[%s] Unable to initialize Kubernetes client", loggerId)
-		return nil, fmt.Errorf("unable to initialize Kubernetes client: %v", err)
+		return nil, nil, fmt.Errorf("unable to initialize Kubernetes client: %v", err)
+	}
+
+	// dynamic client is used for resources of other CSI sidecars like VolumeSnapshotContent
+	dynamicClient, err := dynamic.NewForConfig(config)
+	if err != nil {
+		klog.Errorf("[%s] Unable to initialize Kubernetes dynamic client", loggerId)
+		return nil, nil, fmt.Errorf("unable to initialize Kubernetes dynamic client: %v", err)
 	}
-	return clientset, nil
+	return clientset, dynamicClient, nil
+}
+
+// newEventRecorder returns a recorder whose events are created through
+// clientset with the driver as source.
+func newEventRecorder(clientset kubernetes.Interface, driverName, nodeID string) record.EventRecorder {
+	broadcaster := record.NewBroadcaster()
+	broadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: clientset.CoreV1().Events("")})
+	return broadcaster.NewRecorder(scheme.Scheme, corev1.EventSource{Component: driverName, Host: nodeID})
 }
 
 func (driver *ScaleDriver) PluginInitialize(ctx context.Context) (map[string]connectors.SpectrumScaleConnector, settings.ScaleSettingsConfigMap, settings.Primary, error) { //nolint:funlen
 	loggerId := utils.GetLoggerId(ctx)
 	klog.Infof("[%s] Initialize IBM Storage Scale CSI driver", loggerId)
+	// fail with every problem of the configuration instead of with later
+	// errors caused by an empty one
+	if err := settings.ValidateScaleConfigFile(ctx, settings.ConfigMapFile); err != nil {
+		klog.Errorf("[%s] %v", loggerId, err)
+		return nil, settings.ScaleSettingsConfigMap{}, settings.Primary{}, err
+	}
 	scaleConfig := settings.LoadScaleConfigSettings(ctx)
 	scaleConnMap := make(map[string]connectors.SpectrumScaleConnector)
 	primaryInfo := settings.Primary{}
@@ -308,10 +380,59 @@ func (driver *ScaleDriver) PluginInitialize(ctx context.Context) (map[string]con
 	return scaleConnMap, scaleConfig, primaryInfo, nil
 }
 
-func (driver *ScaleDriver) Run(ctx context.Context, endpoint string) {
-	s := NewNonBlockingGRPCServer()
-	s.Start(endpoint, driver.ids, driver.cs, driver.ns)
-	s.Wait()
+// Run serves the CSI endpoint until the server stops or stop is closed, a tcp
+// endpoint is served with TLS if tlsFiles are set. On
+// stop no new requests are accepted and Run waits up to drainTimeout for the
+// requests and jobs in progress before the server is stopped forcefully.
+func (driver *ScaleDriver) Run(ctx context.Context, endpoint string, tlsFiles TLSFiles, stop <-chan struct{}, drainTimeout time.Duration) {
+	loggerId := utils.GetLoggerId(ctx)
+	// the interrupted operations are finished before a retried request of
+	// the same volume is served
+	driver.cs.recoverJournal(ctx)
+
+	s := NewNonBlockingGRPCServer(tlsFiles)
+	s.Start(endpoint, driver.ids, driver.cs, driver.ns, driver.sds, driver.rs)
+
+	stopped := make(chan struct{})
+	go func() {
+		s.Wait()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+		return
+	case <-stop:
+	}
+
+	klog.Infof("[%s] shutting down, waiting up to %v for requests and jobs in progress", loggerId, drainTimeout)
+	close(driver.stopping)
+	drained := make(chan struct{})
+	go func() {
+		s.Stop()
+		driver.asyncJobs.Wait()
+		close(drained)
+	}()
+	select {
+	case <-drained:
+		klog.Infof("[%s] all requests and jobs in progress completed", loggerId)
+	case <-time.After(drainTimeout):
+		// interrupted volume operations are recovered from the operation
+		// journal on the next start
+		klog.Warningf("[%s] requests or jobs still in progress after %v, stopping the server", loggerId, drainTimeout)
+		s.ForceStop()
+	}
+	<-stopped
+}
+
+// isStopping reports whether the driver shuts down, background work should
+// not be started then.
+func (driver *ScaleDriver) isStopping() bool {
+	select {
+	case <-driver.stopping:
+		return true
+	default:
+		return false
+	}
 }
 
 func (driver *ScaleDriver) PrintDriverInit(ctx context.Context) {
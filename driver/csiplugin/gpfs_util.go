@@ -26,6 +26,7 @@ import (
 	"k8s.io/klog/v2"
 
 	"github.com/IBM/ibm-spectrum-scale-csi/driver/csiplugin/connectors"
+	"github.com/IBM/ibm-spectrum-scale-csi/driver/csiplugin/pkg/scalehandle"
 	"github.com/IBM/ibm-spectrum-scale-csi/driver/csiplugin/utils"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -47,6 +48,9 @@ const (
 	StaticFilesetNameAnnotationKey = "spectrumscale.csi.ibm.com/filesetName"
 	StaticFilesetNameKey           = "filesetName"
 	vmdiskCloning                  = "vmdisk"
+	defaultEncryptionAlgo          = "DEFAULTNISTSP800131A"
+	maxReplicas                    = 3
+	maxWarmPoolSize                = 100
 )
 
 // AFM caching constants
@@ -112,6 +116,12 @@ type scaleVolume struct {
 	PVCName            string                            `json:"pvcName"`
 	Namespace          string                            `json:"namespace"`
 	VmDiskOptimized    bool                              `json:"vmDiskOptimized"`
+	EncryptionKey      string                            `json:"encryptionKey"`
+	EncryptionAlgo     string                            `json:"encryptionAlgorithm"`
+	DataReplicas       int                               `json:"dataReplicas"`
+	MetadataReplicas   int                               `json:"metadataReplicas"`
+	Replication        *volumeReplication                `json:"replication"`
+	WarmPoolSize       int                               `json:"warmPoolSize"`
 }
 
 type cacheVolumeId struct {
@@ -165,6 +175,30 @@ func IsValidCompressionAlgorithm(input string) bool {
 	return false
 }
 
+// isValidEncryptionKey checks that the key reference is of the form
+// <keyId>:<rkmId> as expected by the ENCRYPTION policy rule KEYS clause.
+func isValidEncryptionKey(key string) bool {
+	parts := strings.Split(key, ":")
+	if len(parts) != 2 {
+		return false
+	}
+	return isValidPolicyToken(parts[0]) && isValidPolicyToken(parts[1])
+}
+
+// isValidPolicyToken checks that the value can be safely embedded in a
+// quoted policy rule.
+func isValidPolicyToken(token string) bool {
+	if token == "" {
+		return false
+	}
+	for _, c := range token {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-' || c == '_' || c == '.' || c == ':' || c == ',') {
+			return false
+		}
+	}
+	return true
+}
+
 func getRemoteFsName(remoteDeviceName string) string {
 	splitDevName := strings.Split(remoteDeviceName, ":")
 	remDevFs := splitDevName[len(splitDevName)-1]
@@ -192,6 +226,10 @@ func getScaleVolumeOptions(ctx context.Context, volOptions map[string]string) (*
 	cg, isCGSpecified := volOptions[connectors.UserSpecifiedConsistencyGroup]
 	shared, isSharedSpecified := volOptions[connectors.UserSpecifiedShared]
 	volNamePrefix, isVolNamePrefixSpecified := volOptions[connectors.UserSpecifiedVolNamePrefix]
+	encryptionKey, isEncryptionKeySpecified := volOptions[connectors.UserSpecifiedEncryptionKey]
+	encryptionAlgo, isEncryptionAlgoSpecified := volOptions[connectors.UserSpecifiedEncryptionAlgo]
+	dataReplicas, isDataReplicasSpecified := volOptions[connectors.UserSpecifiedDataReplicas]
+	metadataReplicas, isMetadataReplicasSpecified := volOptions[connectors.UserSpecifiedMetadataReplicas]
 
 	volumeType, volumeTypeSpecified := volOptions[connectors.UserSpecifiedVolumeType]
 	cacheMode, cacheModeSpecified := volOptions[connectors.UserSpecifiedCacheMode]
@@ -396,7 +434,63 @@ func getScaleVolumeOptions(ctx context.Context, volOptions map[string]string) (*
 		}
 	}
 
+	if isEncryptionKeySpecified && encryptionKey == "" {
+		isEncryptionKeySpecified = false
+	}
+	if isEncryptionAlgoSpecified && encryptionAlgo == "" {
+		isEncryptionAlgoSpecified = false
+	}
+	if isEncryptionAlgoSpecified && !isEncryptionKeySpecified {
+		return &scaleVolume{}, status.Error(codes.InvalidArgument, "The parameter \"encryptionAlgorithm\" must be specified together with \"encryptionKey\" in storageClass")
+	}
+	if isEncryptionKeySpecified {
+		if !scaleVol.IsFilesetBased {
+			return &scaleVolume{}, status.Error(codes.InvalidArgument, "The parameter \"encryptionKey\" is not supported in storageClass for lightweight volumes")
+		}
+		if !isValidEncryptionKey(encryptionKey) {
+			return &scaleVolume{}, status.Errorf(codes.InvalidArgument, "invalid value specified for encryptionKey: %s, expected format is <keyId>:<rkmId>", encryptionKey)
+		}
+		scaleVol.EncryptionKey = encryptionKey
+		scaleVol.EncryptionAlgo = defaultEncryptionAlgo
+		if isEncryptionAlgoSpecified {
+			if !isValidPolicyToken(encryptionAlgo) {
+				return &scaleVolume{}, status.Errorf(codes.InvalidArgument, "invalid value specified for encryptionAlgorithm: %s", encryptionAlgo)
+			}
+			scaleVol.EncryptionAlgo = encryptionAlgo
+		}
+	}
+
+	if isDataReplicasSpecified && dataReplicas == "" {
+		isDataReplicasSpecified = false
+	}
+	if isMetadataReplicasSpecified && metadataReplicas == "" {
+		isMetadataReplicasSpecified = false
+	}
+	if (isDataReplicasSpecified || isMetadataReplicasSpecified) && !scaleVol.IsFilesetBased {
+		return &scaleVolume{}, status.Error(codes.InvalidArgument, "The parameters \"dataReplicas\" and \"metadataReplicas\" are not supported in storageClass for lightweight volumes")
+	}
+	if isDataReplicasSpecified {
+		replicas, err := strconv.Atoi(dataReplicas)
+		if err != nil || replicas < 1 || replicas > maxReplicas {
+			return &scaleVolume{}, status.Errorf(codes.InvalidArgument, "invalid value specified for dataReplicas: %s, allowed values are 1 to %d", dataReplicas, maxReplicas)
+		}
+		scaleVol.DataReplicas = replicas
+	}
+	if isMetadataReplicasSpecified {
+		replicas, err := strconv.Atoi(metadataReplicas)
+		if err != nil || replicas < 1 || replicas > maxReplicas {
+			return &scaleVolume{}, status.Errorf(codes.InvalidArgument, "invalid value specified for metadataReplicas: %s, allowed values are 1 to %d", metadataReplicas, maxReplicas)
+		}
+		scaleVol.MetadataReplicas = replicas
+	}
+
 	if scaleVol.IsStaticPVBased {
+		if isDataReplicasSpecified || isMetadataReplicasSpecified {
+			return &scaleVolume{}, status.Error(codes.InvalidArgument, "The parameters \"dataReplicas\" and \"metadataReplicas\" are not supported in storageClass for static volumes i.e. with \"existingVolume\"")
+		}
+		if isEncryptionKeySpecified {
+			return &scaleVolume{}, status.Error(codes.InvalidArgument, "The parameter \"encryptionKey\" is not supported in storageClass for static volumes i.e. with \"existingVolume\"")
+		}
 		if uidSpecified || gidSpecified || isSharedSpecified || inodeLimSpecified || isPermissionsSpecified || isNodeClassSpecified {
 			return &scaleVolume{}, status.Error(codes.InvalidArgument, "The parameters \"uid\" , \"gid\" , \"inodeLimit\" , \"shared\" , \"nodeClass\" and \"permissions\" are not allowed in storageClass for static volumes i.e. with \"existingVolume\"")
 		}
@@ -553,6 +647,33 @@ func getScaleVolumeOptions(ctx context.Context, volOptions map[string]string) (*
 		}
 	}
 
+	replication, isReplicationSpecified, err := getVolumeReplicationOptions(volOptions, scaleVol.VolBackendFs)
+	if err != nil {
+		return &scaleVolume{}, err
+	}
+	if isReplicationSpecified {
+		if !scaleVol.IsFilesetBased || scaleVol.StorageClassType != STORAGECLASS_CLASSIC || scaleVol.FilesetType == dependentFileset ||
+			scaleVol.VolumeType == cacheVolume || scaleVol.IsStaticPVBased || scaleVol.VmDiskOptimized {
+			return &scaleVolume{}, status.Error(codes.InvalidArgument, "The parameter \"replicationClusterId\" is supported in storageClass only for independent fileset based volumes of version \""+scversion1+"\"")
+		}
+		scaleVol.Replication = replication
+	}
+
+	warmPoolSize, isWarmPoolSizeSpecified := volOptions[connectors.UserSpecifiedWarmPoolSize]
+	if isWarmPoolSizeSpecified && warmPoolSize != "" {
+		size, err := strconv.Atoi(warmPoolSize)
+		if err != nil || size < 0 || size > maxWarmPoolSize {
+			return &scaleVolume{}, status.Error(codes.InvalidArgument, fmt.Sprintf("Invalid value specified for warmPoolSize in storageClass, it must be a number from 0 to %d", maxWarmPoolSize))
+		}
+		if size > 0 && (!scaleVol.IsFilesetBased || scaleVol.StorageClassType != STORAGECLASS_CLASSIC || scaleVol.VolumeType == cacheVolume || scaleVol.IsStaticPVBased) {
+			return &scaleVolume{}, status.Error(codes.InvalidArgument, "The parameter \"warmPoolSize\" is supported in storageClass only for fileset based volumes of version \""+scversion1+"\"")
+		}
+		if size > 0 && (scaleVol.VolUid != "" || scaleVol.VolGid != "" || scaleVol.VolPermissions != "") {
+			return &scaleVolume{}, status.Error(codes.InvalidArgument, "The parameters \"uid\", \"gid\" and \"permissions\" must not be specified together with \"warmPoolSize\" in storageClass")
+		}
+		scaleVol.WarmPoolSize = size
+	}
+
 	return scaleVol, nil
 }
 
@@ -672,76 +793,21 @@ func numberInSlice(a int, list []int) bool {
 }
 
 func getVolIDMembers(vID string) (scaleVolId, error) {
-	splitVid := strings.Split(vID, ";")
-	var vIdMem scaleVolId
-
-	if len(splitVid) == 3 {
-		/* This is LW volume */
-		/* <cluster_id>;<filesystem_uuid>;path=<symlink_path> */
-		vIdMem.ClusterId = splitVid[0]
-		vIdMem.FsUUID = splitVid[1]
-		SlnkPart := splitVid[2]
-		slnkSplit := strings.Split(SlnkPart, "=")
-		if len(slnkSplit) < 2 {
-			return scaleVolId{}, status.Error(codes.Internal, fmt.Sprintf("Invalid Volume Id : [%v]", vID))
-		}
-		vIdMem.Path = slnkSplit[1]
-		vIdMem.IsFilesetBased = false
-		return vIdMem, nil
-	}
-
-	if len(splitVid) == 4 {
-		/* This is fileset Based volume */
-		/* <cluster_id>;<filesystem_uuid>;fileset=<fileset_id>;path=<symlink_path> */
-		vIdMem.ClusterId = splitVid[0]
-		vIdMem.FsUUID = splitVid[1]
-		fileSetPart := splitVid[2]
-		fileSetSplit := strings.Split(fileSetPart, "=")
-		if len(fileSetSplit) < 2 {
-			return scaleVolId{}, status.Error(codes.Internal, fmt.Sprintf("Invalid Volume Id : [%v]", vID))
-		}
-
-		if fileSetSplit[0] == "filesetName" {
-			vIdMem.FsetName = fileSetSplit[1]
-		} else {
-			vIdMem.FsetId = fileSetSplit[1]
-		}
-
-		SlnkPart := splitVid[3]
-		slnkSplit := strings.Split(SlnkPart, "=")
-		if len(slnkSplit) < 2 {
-			return scaleVolId{}, status.Error(codes.Internal, fmt.Sprintf("Invalid Volume Id : [%v]", vID))
-		}
-		vIdMem.Path = slnkSplit[1]
-		vIdMem.IsFilesetBased = true
-		return vIdMem, nil
-	}
-
-	if len(splitVid) == 7 {
-		/* Volume ID created from CSI 2.5.0 onwards  */
-		/* VolID: <storageclass_type>;<type_of_volume>;<cluster_id>;<filesystem_uuid>;<consistency_group>;<fileset_name>;<path> */
-		vIdMem.StorageClassType = splitVid[0]
-		vIdMem.VolType = splitVid[1]
-		vIdMem.ClusterId = splitVid[2]
-		vIdMem.FsUUID = splitVid[3]
-		vIdMem.ConsistencyGroup = splitVid[4]
-		vIdMem.FsetName = splitVid[5]
-		if vIdMem.StorageClassType == STORAGECLASS_CLASSIC {
-			if vIdMem.VolType == FILE_DIRECTORYBASED_VOLUME {
-				vIdMem.IsFilesetBased = false
-			} else {
-				vIdMem.IsFilesetBased = true
-			}
-
-		} else {
-			vIdMem.IsFilesetBased = true
-		}
-		vIdMem.Path = splitVid[6]
-		return vIdMem, nil
-
-	}
-
-	return scaleVolId{}, status.Error(codes.Internal, fmt.Sprintf("Invalid Volume Id : [%v]", vID))
+	handle, err := scalehandle.DecodeVolume(vID)
+	if err != nil {
+		return scaleVolId{}, status.Error(codes.Internal, fmt.Sprintf("Invalid Volume Id : [%v]", vID))
+	}
+	return scaleVolId{
+		StorageClassType: handle.StorageClassType,
+		VolType:          handle.VolumeType,
+		ClusterId:        handle.ClusterID,
+		FsUUID:           handle.FilesystemUUID,
+		ConsistencyGroup: handle.ConsistencyGroup,
+		FsetName:         handle.FilesetName,
+		FsetId:           handle.FilesetID,
+		Path:             handle.Path,
+		IsFilesetBased:   handle.IsFilesetBased(),
+	}, nil
 }
 
 // checking the fs is mounted on any 1 gateway node or not
@@ -0,0 +1,175 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package scale
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/IBM/ibm-spectrum-scale-csi/driver/csiplugin/utils"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// healthCheckInterval is the interval of the readiness checks, the checks
+	// call the GUI of each cluster so /readyz returns the last result
+	healthCheckInterval = 30 * time.Second
+
+	healthCheckConfig      = "config"
+	healthCheckConnector   = "connector"
+	healthCheckGPFS        = "gpfs"
+	healthCheckFilesystems = "filesystems"
+)
+
+// healthCheck is the result of one readiness check.
+type healthCheck struct {
+	Name    string `json:"name"`
+	Healthy bool   `json:"healthy"`
+	Message string `json:"message,omitempty"`
+}
+
+// healthStatus is the body of the /readyz response.
+type healthStatus struct {
+	Ready   bool          `json:"ready"`
+	Checks  []healthCheck `json:"checks"`
+	Updated time.Time     `json:"updated"`
+}
+
+// healthState holds the results of the last readiness checks.
+type healthState struct {
+	lock   sync.RWMutex
+	status healthStatus
+}
+
+// ServeHealth serves /healthz and /readyz on addr. /healthz succeeds while
+// the driver process is responsive, /readyz succeeds once the configuration
+// was loaded and the GUI of each cluster, the IBM Storage Scale daemon on the
+// node and the filesystem mounts are healthy.
+func (driver *ScaleDriver) ServeHealth(ctx context.Context, addr string) {
+	loggerId := utils.GetLoggerId(ctx)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("ok"))
+	})
+	mux.HandleFunc("/readyz", driver.serveReadyz)
+
+	server := &http.Server{
+		Addr:              addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		klog.Infof("[%s] serving /healthz and /readyz on %s", loggerId, addr)
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			klog.Errorf("[%s] health server on %s failed. Error: %v", loggerId, addr, err)
+		}
+	}()
+	go driver.runHealthChecks(ctx)
+}
+
+func (driver *ScaleDriver) serveReadyz(w http.ResponseWriter, r *http.Request) {
+	driver.health.lock.RLock()
+	status := driver.health.status
+	driver.health.lock.RUnlock()
+
+	if driver.isStopping() {
+		status.Ready = false
+		status.Checks = append([]healthCheck{{Name: "shutdown", Message: "driver is shutting down"}}, status.Checks...)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if status.Ready {
+		w.WriteHeader(http.StatusOK)
+	} else {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	_ = json.NewEncoder(w).Encode(status)
+}
+
+// runHealthChecks updates the readiness checks every healthCheckInterval
+// until the driver shuts down. Until the configuration was loaded it is
+// checked every second.
+func (driver *ScaleDriver) runHealthChecks(ctx context.Context) {
+	for {
+		checks := driver.checkHealth(ctx)
+		ready := true
+		for _, check := range checks {
+			if !check.Healthy {
+				ready = false
+				klog.V(4).Infof("[%s] readiness check %s failed: %s", utils.GetLoggerId(ctx), check.Name, check.Message)
+			}
+		}
+		driver.health.lock.Lock()
+		driver.health.status = healthStatus{Ready: ready, Checks: checks, Updated: time.Now().UTC()}
+		driver.health.lock.Unlock()
+
+		interval := healthCheckInterval
+		if !driver.configLoaded.Load() {
+			interval = time.Second
+		}
+		select {
+		case <-driver.stopping:
+			return
+		case <-time.After(interval):
+		}
+	}
+}
+
+func (driver *ScaleDriver) checkHealth(ctx context.Context) []healthCheck {
+	if !driver.configLoaded.Load() {
+		return []healthCheck{{Name: healthCheckConfig, Message: "driver configuration is not loaded"}}
+	}
+	checks := []healthCheck{{Name: healthCheckConfig, Healthy: true}}
+
+	// the primary connector is also stored by its cluster ID
+	clusterIDs := make([]string, 0, len(driver.connmap))
+	for clusterID := range driver.connmap {
+		if clusterID != "primary" {
+			clusterIDs = append(clusterIDs, clusterID)
+		}
+	}
+	sort.Strings(clusterIDs)
+	for _, clusterID := range clusterIDs {
+		check := healthCheck{Name: fmt.Sprintf("%s/%s", healthCheckConnector, clusterID), Healthy: true}
+		if _, err := driver.connmap[clusterID].GetClusterId(ctx); err != nil {
+			check = healthCheck{Name: check.Name, Message: fmt.Sprintf("GUI of cluster %s is not reachable: %v", clusterID, err)}
+		}
+		checks = append(checks, check)
+	}
+
+	if conn, ok := driver.connmap["primary"]; ok && driver.nodeID != "" {
+		scalenodeID := getNodeMapping(driver.nodeID)
+		check := healthCheck{Name: healthCheckGPFS, Healthy: true}
+		healthy, err := conn.IsNodeComponentHealthy(ctx, scalenodeID, "GPFS")
+		if !healthy {
+			check = healthCheck{Name: healthCheckGPFS, Message: fmt.Sprintf("IBM Storage Scale on node %s is unhealthy: %v", scalenodeID, err)}
+		}
+		checks = append(checks, check)
+	}
+
+	check := healthCheck{Name: healthCheckFilesystems, Healthy: true}
+	if len(getGpfsPaths(ctx)) == 0 {
+		check = healthCheck{Name: healthCheckFilesystems, Message: "no IBM Storage Scale filesystem is mounted on the node"}
+	}
+	return append(checks, check)
+}
@@ -0,0 +1,389 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package scale
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/IBM/ibm-spectrum-scale-csi/driver/csiplugin/connectors"
+	"github.com/IBM/ibm-spectrum-scale-csi/driver/csiplugin/pkg/journal"
+	"github.com/IBM/ibm-spectrum-scale-csi/driver/csiplugin/utils"
+	"github.com/container-storage-interface/spec/lib/go/csi"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+)
+
+// Steps of CreateVolume recorded in the operation journal
+const (
+	journalStepFilesetCreated   = "FilesetCreated"
+	journalStepFilesetLinked    = "FilesetLinked"
+	journalStepQuotaSet         = "QuotaSet"
+	journalStepDirectoryCreated = "DirectoryCreated"
+)
+
+// journalBegin records the start of an operation. Journal errors are logged
+// only, they must not fail the volume operation.
+func (cs *ScaleControllerServer) journalBegin(ctx context.Context, rec journal.Record) {
+	if cs.Driver.journal == nil {
+		return
+	}
+	if err := cs.Driver.journal.Begin(rec); err != nil {
+		klog.Errorf("[%s] unable to record %s [%s] in operation journal. Error: %v", utils.GetLoggerId(ctx), rec.Operation, rec.ID, err)
+	}
+}
+
+// journalStep records a completed step of the operation id.
+func (cs *ScaleControllerServer) journalStep(ctx context.Context, id, step string) {
+	if cs.Driver.journal == nil {
+		return
+	}
+	if err := cs.Driver.journal.Step(id, step); err != nil {
+		klog.Errorf("[%s] unable to record step %s of [%s] in operation journal. Error: %v", utils.GetLoggerId(ctx), step, id, err)
+	}
+}
+
+// journalCopyJob records the copy job started for the volume of operation id,
+// so that the job is re-attached if the driver restarts while it runs.
+func (cs *ScaleControllerServer) journalCopyJob(ctx context.Context, id string, kind journal.CopyJobKind, clusterID string, statusCode int, jobID uint64, volID string) {
+	if cs.Driver.journal == nil {
+		return
+	}
+	job := &journal.CopyJob{Kind: kind, ClusterID: clusterID, JobID: jobID, StatusCode: statusCode, VolumeID: volID, StartTime: time.Now().UTC()}
+	if err := cs.Driver.journal.SetCopyJob(id, job); err != nil {
+		klog.Errorf("[%s] unable to record copy job %d of [%s] in operation journal. Error: %v", utils.GetLoggerId(ctx), jobID, id, err)
+	}
+}
+
+// journalComplete removes the operation id from the journal.
+func (cs *ScaleControllerServer) journalComplete(ctx context.Context, id string) {
+	if cs.Driver.journal == nil {
+		return
+	}
+	if err := cs.Driver.journal.Complete(id); err != nil {
+		klog.Errorf("[%s] unable to complete [%s] in operation journal. Error: %v", utils.GetLoggerId(ctx), id, err)
+	}
+}
+
+// journalAction is the recovery of an operation found in the journal.
+type journalAction int
+
+const (
+	// journalKeep keeps the record for the retried request of the operation
+	journalKeep journalAction = iota
+	// journalReattach waits again for the copy job of the operation
+	journalReattach
+	// journalRollback removes the volume created by the operation
+	journalRollback
+	// journalCancelRollback cancels the copy job of the operation and removes
+	// its volume
+	journalCancelRollback
+	// journalRollforward repeats the operation
+	journalRollforward
+	journalUnknown
+)
+
+// journalRecoveryAction decides how an interrupted operation is recovered. A
+// CreateVolume whose claim still exists is retried by the provisioner, which
+// completes the record, so it is rolled back only once the claim was deleted
+// or if the claim is not known.
+func journalRecoveryAction(rec journal.Record, claimDeleted bool) journalAction {
+	switch rec.Operation {
+	case journal.CreateVolume:
+		switch {
+		case claimDeleted && rec.CopyJob != nil:
+			return journalCancelRollback
+		case claimDeleted:
+			return journalRollback
+		case rec.CopyJob != nil:
+			return journalReattach
+		case rec.PVCName != "":
+			return journalKeep
+		default:
+			return journalRollback
+		}
+	case journal.DeleteVolume:
+		return journalRollforward
+	}
+	return journalUnknown
+}
+
+// recoverJournal finishes the operations which were interrupted by a restart
+// of the driver. It runs once at startup, before the driver serves requests.
+// Every node pod opens the journal of its node, only the journal of a pod
+// which served the controller service holds records.
+//
+// CreateVolume operations are rolled back once the provisioner gave up the
+// volume, DeleteVolume operations are rolled forward. The recovery of a
+// volume is tracked like a request of the volume, so a retried request waits
+// for it. The secrets of the requests are not journaled, the recovery uses
+// the credentials of the cluster configuration. Operations which cannot be
+// recovered stay in the journal and are retried on the next start.
+func (cs *ScaleControllerServer) recoverJournal(ctx context.Context) {
+	loggerId := utils.GetLoggerId(ctx)
+	if cs.Driver.journal == nil {
+		return
+	}
+	records, err := cs.Driver.journal.Pending()
+	if err != nil {
+		klog.Errorf("[%s] unable to read operation journal. Error: %v", loggerId, err)
+		return
+	}
+	if len(records) == 0 {
+		return
+	}
+	klog.Infof("[%s] recovering %d interrupted operations from operation journal", loggerId, len(records))
+
+	for _, rec := range records {
+		claimDeleted := rec.Operation == journal.CreateVolume && cs.isJournalClaimDeleted(ctx, rec)
+		action := journalRecoveryAction(rec, claimDeleted)
+		switch action {
+		case journalReattach:
+			// the record is completed by the CreateVolume retried by the provisioner
+			cs.reattachCopyJob(ctx, rec)
+			continue
+		case journalRollforward:
+			// DeleteVolume tracks the operation of the volume itself
+			err = cs.rollforwardDeleteVolume(ctx, rec)
+		case journalUnknown:
+			err = fmt.Errorf("unknown operation %s", rec.Operation)
+		default:
+			_, err = trackOperation(ctx, cs.Driver.optracker, rec.ID, recoverVolume, "", func() (bool, error) {
+				return true, cs.recoverCreateVolume(ctx, rec, action)
+			})
+		}
+		if err != nil {
+			klog.Errorf("[%s] unable to recover %s [%s] from operation journal. Error: %v", loggerId, rec.Operation, rec.ID, err)
+			continue
+		}
+		klog.Infof("[%s] recovered %s [%s] from operation journal", loggerId, rec.Operation, rec.ID)
+	}
+}
+
+// recoverCreateVolume applies the recovery action of an interrupted
+// CreateVolume and completes its record, unless the record is kept for the
+// retried CreateVolume.
+func (cs *ScaleControllerServer) recoverCreateVolume(ctx context.Context, rec journal.Record, action journalAction) error {
+	if action == journalKeep {
+		inUse, err := cs.isJournalVolumeInUse(ctx, rec)
+		if err != nil {
+			return err
+		}
+		if !inUse {
+			klog.Infof("[%s] PVC [%s/%s] of volume [%s] exists, CreateVolume is completed by the provisioner", utils.GetLoggerId(ctx), rec.PVCNamespace, rec.PVCName, rec.ID)
+			return nil
+		}
+	} else {
+		if action == journalCancelRollback {
+			cs.cancelJournalCopyJob(ctx, rec)
+		}
+		if err := cs.rollbackCreateVolume(ctx, rec); err != nil {
+			return err
+		}
+	}
+	cs.journalComplete(ctx, rec.ID)
+	return nil
+}
+
+// reattachCopyJob tracks the copy job of an interrupted CreateVolume again. The
+// job is marked as running in the job status map of its kind and a goroutine
+// waits for its completion, so that the retried CreateVolume returns the volume
+// once the job completed instead of copying the content again.
+func (cs *ScaleControllerServer) reattachCopyJob(ctx context.Context, rec journal.Record) {
+	loggerId := utils.GetLoggerId(ctx)
+	job := rec.CopyJob
+	conn, err := cs.getConnFromClusterID(ctx, job.ClusterID)
+	if err != nil {
+		klog.Errorf("[%s] unable to re-attach copy job %d of volume [%s]. Error: %v", loggerId, job.JobID, rec.ID, err)
+		return
+	}
+
+	klog.Infof("[%s] re-attaching %s job %d of volume [%s] started at %v", loggerId, job.Kind, job.JobID, rec.ID, job.StartTime)
+	if job.Kind == journal.SnapshotCopy {
+		cs.Driver.snapjobstatusmap.Store(rec.ID, SnapCopyJobDetails{SNAP_JOB_RUNNING, job.VolumeID})
+	} else {
+		cs.Driver.volcopyjobstatusmap.Store(rec.ID, VolCopyJobDetails{VOLCOPY_JOB_RUNNING, job.VolumeID})
+	}
+
+	cs.Driver.asyncJobs.Add(1)
+	go func() {
+		defer cs.Driver.asyncJobs.Done()
+		response, err := conn.WaitForJobCompletionWithResp(ctx, job.StatusCode, job.JobID)
+		isResponseStatusUnknown := len(response.Jobs) != 0 && response.Jobs[0].Status == ResponseStatusUnknown
+		completed := err == nil && !isResponseStatusUnknown
+		if !completed {
+			klog.Errorf("[%s] re-attached %s job %d of volume [%s] did not complete. Error: %v", loggerId, job.Kind, job.JobID, rec.ID, err)
+			// the content is copied again by the retried CreateVolume
+			if err := cs.Driver.journal.SetCopyJob(rec.ID, nil); err != nil {
+				klog.Errorf("[%s] unable to clear copy job of [%s] in operation journal. Error: %v", loggerId, rec.ID, err)
+			}
+		} else {
+			klog.Infof("[%s] re-attached %s job %d of volume [%s] completed", loggerId, job.Kind, job.JobID, rec.ID)
+		}
+
+		if job.Kind == journal.SnapshotCopy {
+			jobDetails := SnapCopyJobDetails{SNAP_JOB_COMPLETED, job.VolumeID}
+			switch {
+			case err != nil && strings.Contains(err.Error(), "EFSSG0632C"):
+				jobDetails.jobStatus = SNAP_JOB_NOT_STARTED
+			case isResponseStatusUnknown:
+				jobDetails.jobStatus = JOB_STATUS_UNKNOWN
+			case err != nil:
+				jobDetails.jobStatus = SNAP_JOB_FAILED
+			}
+			cs.Driver.snapjobstatusmap.Store(rec.ID, jobDetails)
+			return
+		}
+		jobDetails := VolCopyJobDetails{VOLCOPY_JOB_COMPLETED, job.VolumeID}
+		switch {
+		case err != nil && strings.Contains(err.Error(), "EFSSG0632C"):
+			jobDetails.jobStatus = VOLCOPY_JOB_NOT_STARTED
+		case isResponseStatusUnknown:
+			jobDetails.jobStatus = JOB_STATUS_UNKNOWN
+		case err != nil:
+			jobDetails.jobStatus = VOLCOPY_JOB_FAILED
+		}
+		cs.Driver.volcopyjobstatusmap.Store(rec.ID, jobDetails)
+	}()
+}
+
+// isJournalClaimDeleted checks whether the PersistentVolumeClaim of a
+// journaled CreateVolume was deleted. The provisioner names the volume after
+// the UID of the claim, so a claim created again with the same name is not
+// the claim of the volume.
+func (cs *ScaleControllerServer) isJournalClaimDeleted(ctx context.Context, rec journal.Record) bool {
+	if cs.Driver.clientset == nil || rec.PVCName == "" || rec.PVCNamespace == "" {
+		return false
+	}
+	pvc, err := cs.Driver.clientset.CoreV1().PersistentVolumeClaims(rec.PVCNamespace).Get(ctx, rec.PVCName, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return true
+	} else if err != nil {
+		klog.Errorf("[%s] unable to get PVC [%s/%s] of volume [%s]. Error: %v", utils.GetLoggerId(ctx), rec.PVCNamespace, rec.PVCName, rec.ID, err)
+		return false
+	}
+	return pvc.DeletionTimestamp != nil || !strings.HasSuffix(rec.ID, string(pvc.UID))
+}
+
+// cancelJournalCopyJob cancels the copy job of an interrupted CreateVolume
+// whose claim was deleted, before the volume is rolled back.
+func (cs *ScaleControllerServer) cancelJournalCopyJob(ctx context.Context, rec journal.Record) {
+	loggerId := utils.GetLoggerId(ctx)
+	job := rec.CopyJob
+	klog.Infof("[%s] PVC [%s/%s] of volume [%s] was deleted, cancelling %s job %d", loggerId, rec.PVCNamespace, rec.PVCName, rec.ID, job.Kind, job.JobID)
+	conn, err := cs.getConnFromClusterID(ctx, job.ClusterID)
+	if err == nil {
+		err = conn.CancelJob(ctx, job.JobID)
+	}
+	if err != nil {
+		klog.Errorf("[%s] unable to cancel copy job %d of volume [%s]. Error: %v", loggerId, job.JobID, rec.ID, err)
+	}
+}
+
+// rollbackCreateVolume removes the fileset or directory created by an
+// interrupted CreateVolume unless a PersistentVolume uses it.
+func (cs *ScaleControllerServer) rollbackCreateVolume(ctx context.Context, rec journal.Record) error {
+	loggerId := utils.GetLoggerId(ctx)
+	if !rec.HasStep(journalStepFilesetCreated) && !rec.HasStep(journalStepDirectoryCreated) {
+		klog.V(4).Infof("[%s] CreateVolume [%s] did not create a fileset or directory, nothing to roll back", loggerId, rec.ID)
+		return nil
+	}
+
+	inUse, err := cs.isJournalVolumeInUse(ctx, rec)
+	if err != nil {
+		return err
+	}
+	if inUse {
+		klog.Infof("[%s] volume [%s] is used by a PersistentVolume, CreateVolume is not rolled back", loggerId, rec.ID)
+		return nil
+	}
+
+	conn, err := cs.getConnFromClusterID(ctx, rec.ClusterID)
+	if err != nil {
+		return err
+	}
+
+	if rec.Fileset != "" {
+		if !rec.HasStep(journalStepFilesetCreated) {
+			return nil
+		}
+		filesetInfo, err := conn.ListFileset(ctx, rec.Filesystem, rec.Fileset)
+		if err != nil {
+			if strings.Contains(err.Error(), fsetNotFoundErrCode) || strings.Contains(err.Error(), fsetNotFoundErrMsg) {
+				return nil
+			}
+			return fmt.Errorf("unable to list fileset [%v] in filesystem [%v]. Error: %v", rec.Fileset, rec.Filesystem, err)
+		}
+		if !strings.Contains(filesetInfo.Config.Comment, connectors.FilesetComment) {
+			klog.Infof("[%s] fileset [%v] is not created by IBM Storage Scale CSI driver, skipping the rollback", loggerId, rec.Fileset)
+			return nil
+		}
+		klog.Infof("[%s] rolling back CreateVolume [%s]: deleting fileset [%v] in filesystem [%v]", loggerId, rec.ID, rec.Fileset, rec.Filesystem)
+		if _, err = cs.DeleteFilesetVol(ctx, rec.Filesystem, rec.Fileset, scaleVolId{ClusterId: rec.ClusterID}, conn, false); err != nil {
+			return err
+		}
+		return cs.deleteVolPolicyPartitions(ctx, conn, rec.Filesystem, rec.Fileset)
+	}
+
+	if rec.Path == "" {
+		return nil
+	}
+	klog.Infof("[%s] rolling back CreateVolume [%s]: deleting directory [%v] in filesystem [%v]", loggerId, rec.ID, rec.Path, rec.Filesystem)
+	if err := conn.DeleteDirectory(ctx, rec.Filesystem, rec.Path, false); err != nil {
+		return fmt.Errorf("unable to delete directory [%v] in filesystem [%v]. Error: %v", rec.Path, rec.Filesystem, err)
+	}
+	return nil
+}
+
+// rollforwardDeleteVolume repeats an interrupted DeleteVolume, which is
+// idempotent and completes the journal record on success.
+func (cs *ScaleControllerServer) rollforwardDeleteVolume(ctx context.Context, rec journal.Record) error {
+	klog.Infof("[%s] rolling forward DeleteVolume [%s]", utils.GetLoggerId(ctx), rec.VolumeID)
+	_, err := cs.DeleteVolume(ctx, &csi.DeleteVolumeRequest{VolumeId: rec.VolumeID})
+	return err
+}
+
+// isJournalVolumeInUse checks whether a PersistentVolume of the driver refers
+// to the fileset or directory of a journaled CreateVolume.
+func (cs *ScaleControllerServer) isJournalVolumeInUse(ctx context.Context, rec journal.Record) (bool, error) {
+	if cs.Driver.clientset == nil {
+		return false, fmt.Errorf("kubernetes client is not initialized")
+	}
+	pvList, err := cs.Driver.clientset.CoreV1().PersistentVolumes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return false, fmt.Errorf("unable to list PersistentVolumes. Error: %v", err)
+	}
+	for _, pv := range pvList.Items {
+		if pv.Spec.CSI == nil || pv.Spec.CSI.Driver != cs.Driver.name {
+			continue
+		}
+		volumeIdMembers, err := getVolIDMembers(pv.Spec.CSI.VolumeHandle)
+		if err != nil {
+			continue
+		}
+		if rec.Fileset != "" && volumeIdMembers.FsetName == rec.Fileset {
+			return true, nil
+		}
+		if rec.Path != "" && strings.HasSuffix(volumeIdMembers.Path, "/"+rec.Path) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,177 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package scale
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/IBM/ibm-spectrum-scale-csi/driver/csiplugin/pkg/journal"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+const testClaimUID = "0b5e4c7a-1f8e-4c55-9a3e-2c1d6f0e9b11"
+
+func testPVC(namespace, name, uid string, deleting bool) *corev1.PersistentVolumeClaim {
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, UID: types.UID(uid)},
+	}
+	if deleting {
+		now := metav1.Now()
+		pvc.DeletionTimestamp = &now
+		pvc.Finalizers = []string{"kubernetes.io/pvc-protection"}
+	}
+	return pvc
+}
+
+func TestJournalRecoveryAction(t *testing.T) {
+	copyJob := &journal.CopyJob{Kind: journal.VolumeCopy, JobID: 7}
+	tests := []struct {
+		name         string
+		rec          journal.Record
+		claimDeleted bool
+		want         journalAction
+	}{
+		{"create of an existing claim", journal.Record{Operation: journal.CreateVolume, PVCName: "data"}, false, journalKeep},
+		{"create of a deleted claim", journal.Record{Operation: journal.CreateVolume, PVCName: "data"}, true, journalRollback},
+		{"create without claim", journal.Record{Operation: journal.CreateVolume}, false, journalRollback},
+		{"copy of an existing claim", journal.Record{Operation: journal.CreateVolume, PVCName: "data", CopyJob: copyJob}, false, journalReattach},
+		{"copy without claim", journal.Record{Operation: journal.CreateVolume, CopyJob: copyJob}, false, journalReattach},
+		{"copy of a deleted claim", journal.Record{Operation: journal.CreateVolume, PVCName: "data", CopyJob: copyJob}, true, journalCancelRollback},
+		{"delete", journal.Record{Operation: journal.DeleteVolume}, false, journalRollforward},
+		{"unknown operation", journal.Record{Operation: "ExpandVolume"}, false, journalUnknown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := journalRecoveryAction(tt.rec, tt.claimDeleted); got != tt.want {
+				t.Errorf("journalRecoveryAction() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsJournalClaimDeleted(t *testing.T) {
+	clientset := fake.NewClientset(
+		testPVC("ns", "bound", testClaimUID, false),
+		testPVC("ns", "deleting", testClaimUID, true),
+		testPVC("ns", "recreated", "6f1c2d3e-0000-4000-8000-000000000000", false),
+	)
+	cs := &ScaleControllerServer{Driver: &ScaleDriver{name: testDriverName, clientset: clientset}}
+
+	tests := []struct {
+		name    string
+		pvcName string
+		want    bool
+	}{
+		{"existing claim", "bound", false},
+		{"claim being deleted", "deleting", true},
+		{"deleted claim", "missing", true},
+		{"claim created again", "recreated", true},
+		{"unknown claim", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := journal.Record{ID: "pvc-" + testClaimUID, Operation: journal.CreateVolume, PVCName: tt.pvcName, PVCNamespace: "ns"}
+			if got := cs.isJournalClaimDeleted(context.Background(), rec); got != tt.want {
+				t.Errorf("isJournalClaimDeleted() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecoverJournal(t *testing.T) {
+	volumeName := "pvc-" + testClaimUID
+	tests := []struct {
+		name       string
+		objects    []runtime.Object
+		inProgress bool
+		completed  bool
+	}{
+		{
+			name:      "claim deleted before the volume was created",
+			completed: true,
+		},
+		{
+			name:      "claim exists, CreateVolume is retried",
+			objects:   []runtime.Object{testPVC("ns", "data", testClaimUID, false)},
+			completed: false,
+		},
+		{
+			name: "claim exists, volume was returned",
+			objects: []runtime.Object{
+				testPVC("ns", "data", testClaimUID, false),
+				testPV(volumeName, "0;1;123;uuid;;"+volumeName+";/ibm/gpfs0/"+volumeName),
+			},
+			completed: true,
+		},
+		{
+			name:       "retried CreateVolume in progress",
+			inProgress: true,
+			completed:  false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			j, err := journal.Open(filepath.Join(t.TempDir(), "journal"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			clientset := fake.NewClientset(tt.objects...)
+			cs := &ScaleControllerServer{Driver: &ScaleDriver{name: testDriverName, clientset: clientset, journal: j, optracker: newOperationTracker()}}
+
+			// the record has no steps, nothing is rolled back on the cluster
+			rec := journal.Record{ID: volumeName, Operation: journal.CreateVolume, ClusterID: "123", Filesystem: "gpfs0", Fileset: volumeName, PVCName: "data", PVCNamespace: "ns"}
+			if err := j.Begin(rec); err != nil {
+				t.Fatal(err)
+			}
+
+			if tt.inProgress {
+				done := make(chan struct{})
+				started := make(chan struct{})
+				go func() {
+					_, _ = trackOperation(context.Background(), cs.Driver.optracker, volumeName, createVolume, "", func() (bool, error) {
+						close(started)
+						<-done
+						return true, nil
+					})
+				}()
+				<-started
+				defer close(done)
+				// the claim is gone, but the volume is busy with the retried request
+				rec.PVCName = "missing"
+				if err := j.Begin(rec); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			cs.recoverJournal(context.Background())
+
+			_, pending, err := j.Get(volumeName)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if pending == tt.completed {
+				t.Errorf("record pending = %v, want completed = %v", pending, tt.completed)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package scale
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/IBM/ibm-spectrum-scale-csi/driver/csiplugin/utils"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+)
+
+// migrationCutoverAnnotation is set by the operator on the PVC of a volume
+// whose data is synchronized to another filesystem for the cutover of a
+// CSIScaleVolumeMigration. Its value is the name of the migration.
+const migrationCutoverAnnotation = "spectrumscale.csi.ibm.com/migration-cutover"
+
+// checkMigrationCutover returns a FailedPrecondition error if the PVC of a
+// volume is in the cutover of a migration, as data written by a new pod would
+// not be carried over to the migrated volume. The PVC is taken from the volume
+// context, volumes without the PVC in their context are not checked.
+func checkMigrationCutover(ctx context.Context, clientset kubernetes.Interface, volumeID string, volumeContext map[string]string) error {
+	pvcName, namespace := volumeContext[PvcNameKey], volumeContext[PvcNamespaceKey]
+	if clientset == nil || pvcName == "" || namespace == "" {
+		return nil
+	}
+	loggerId := utils.GetLoggerId(ctx)
+
+	pvc, err := clientset.CoreV1().PersistentVolumeClaims(namespace).Get(ctx, pvcName, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		klog.Errorf("[%s] NodePublishVolume - unable to get PVC [%s/%s] of volume [%s]. Error: %v", loggerId, namespace, pvcName, volumeID, err)
+		return status.Error(codes.Unavailable, fmt.Sprintf("NodePublishVolume - unable to check if PVC [%s/%s] is being migrated: %v", namespace, pvcName, err))
+	}
+	if migration, ok := pvc.Annotations[migrationCutoverAnnotation]; ok {
+		klog.Errorf("[%s] NodePublishVolume - PVC [%s/%s] of volume [%s] is in the cutover of migration [%s]", loggerId, namespace, pvcName, volumeID, migration)
+		return status.Error(codes.FailedPrecondition, fmt.Sprintf("NodePublishVolume - PVC [%s/%s] is in the cutover of migration [%s], retry after the migration completed", namespace, pvcName, migration))
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package scale
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestCheckMigrationCutover(t *testing.T) {
+	clientset := fake.NewClientset(
+		&corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "data"}},
+		&corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "migrated",
+			Annotations: map[string]string{migrationCutoverAnnotation: "move-data"}}},
+	)
+	volumeContext := func(name string) map[string]string {
+		return map[string]string{PvcNameKey: name, PvcNamespaceKey: "ns"}
+	}
+	tests := []struct {
+		name          string
+		volumeContext map[string]string
+		want          codes.Code
+	}{
+		{"not migrated", volumeContext("data"), codes.OK},
+		{"in cutover", volumeContext("migrated"), codes.FailedPrecondition},
+		{"deleted claim", volumeContext("deleted"), codes.OK},
+		{"static volume", map[string]string{}, codes.OK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkMigrationCutover(context.Background(), clientset, "volume-1", tt.volumeContext)
+			if got := status.Code(err); got != tt.want {
+				t.Errorf("checkMigrationCutover() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"path"
 	"strings"
 	"sync"
 
@@ -199,6 +200,17 @@ func (ns *ScaleNodeServer) NodePublishVolume(ctx context.Context, req *csi.NodeP
 		return nil, err
 	}
 
+	// the data of a volume whose revert to a snapshot did not complete yet is partial
+	if volumeIDMembers.IsFilesetBased {
+		if _, err := os.Stat(path.Join(path.Dir(volScalePathInContainer), revertMarkerDir)); err == nil {
+			klog.Errorf("[%s] NodePublishVolume - volume [%s] is being reverted to a snapshot", loggerId, volumeID)
+			return nil, status.Error(codes.FailedPrecondition, fmt.Sprintf("NodePublishVolume - volume [%s] is being reverted to a snapshot, retry after the revert completed", volumeID))
+		}
+	}
+	if err := checkMigrationCutover(ctx, ns.Driver.clientset, volumeID, req.GetVolumeContext()); err != nil {
+		return nil, err
+	}
+
 	method := strings.ToUpper(os.Getenv(nodePublishMethod))
 	klog.V(4).Infof("[%s] NodePublishVolume - NodePublishVolume method used: %s", loggerId, method)
 
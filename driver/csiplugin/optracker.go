@@ -0,0 +1,179 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package scale
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/IBM/ibm-spectrum-scale-csi/driver/csiplugin/pkg/scalehandle"
+	"github.com/IBM/ibm-spectrum-scale-csi/driver/csiplugin/utils"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"k8s.io/klog/v2"
+)
+
+const (
+	expandVolume = "ControllerExpandVolume"
+	modifyVolume = "ControllerModifyVolume"
+	// recoverVolume is the recovery of an interrupted operation from the
+	// operation journal
+	recoverVolume = "RecoverVolume"
+
+	// operationRetryDelay is the retry hint returned with requests which
+	// conflict with an operation in progress
+	operationRetryDelay = 10 * time.Second
+)
+
+// operationTracker tracks the controller operations in progress by the name of
+// their volume or snapshot.
+type operationTracker struct {
+	lock sync.Mutex
+	ops  map[string]*trackedOperation
+}
+
+type trackedOperation struct {
+	kind   string
+	params string
+	done   chan struct{}
+	resp   interface{}
+	err    error
+}
+
+// volumeNameSuffix matches the suffixes "-COMPRESS<algorithm>csi" and
+// "-T<tier>csi" CreateVolume appends to the name of a fileset based volume.
+// A tier may contain any character, but the names of the provisioner are
+// lower case, so the first "-COMPRESS" or "-T" starts the suffixes.
+var volumeNameSuffix = regexp.MustCompile(`(-COMPRESS[A-Z0-9]+csi)?(-T.+csi)?$`)
+
+// volumeOperationKey returns the key of the operations on the volume with
+// volumeID. It is the name the volume was created with, so that the
+// operations on a volume conflict with a CreateVolume of the same volume.
+func volumeOperationKey(volumeID string) string {
+	volumeIdMembers, err := getVolIDMembers(volumeID)
+	if err != nil {
+		// the request is rejected as invalid by the operation
+		return volumeID
+	}
+	name := volumeIdMembers.FsetName
+	if !volumeIdMembers.IsFilesetBased || name == "" {
+		// the handles of version 1 name the fileset by its ID, its
+		// junction path ends with the fileset name
+		name = path.Base(volumeIdMembers.Path)
+	}
+	return volumeNameSuffix.ReplaceAllString(name, "")
+}
+
+// snapshotOperationKey returns the key of the operations on the snapshot with
+// snapshotID. It is the name the snapshot was created with, so that a
+// DeleteSnapshot conflicts with a CreateSnapshot of the same snapshot. The
+// snapshot of a consistency group is shared by the snapshots of its volumes,
+// their names are kept as the meta snapshot name.
+func snapshotOperationKey(snapshotID string) string {
+	handle, err := scalehandle.DecodeSnapshot(snapshotID)
+	if err != nil {
+		// the request is rejected as invalid by the operation
+		return snapshotID
+	}
+	if handle.MetaSnapshotName != "" {
+		return handle.MetaSnapshotName
+	}
+	return handle.SnapshotName
+}
+
+func newOperationTracker() *operationTracker {
+	return &operationTracker{ops: make(map[string]*trackedOperation)}
+}
+
+// trackOperation runs fn as operation kind on key, unless an operation on key
+// is in progress. A duplicate request, with the same kind and parameters,
+// waits for the operation in progress and returns its result. Any other
+// request fails with Aborted and a retry hint.
+func trackOperation[T any](ctx context.Context, t *operationTracker, key, kind, params string, fn func() (T, error)) (T, error) {
+	if key == "" {
+		// the request is rejected as invalid by fn
+		return fn()
+	}
+	loggerId := utils.GetLoggerId(ctx)
+	var empty T
+
+	t.lock.Lock()
+	if op, found := t.ops[key]; found {
+		t.lock.Unlock()
+		if op.kind != kind || op.params != params {
+			klog.Errorf("[%s] %s of [%s] conflicts with %s in progress", loggerId, kind, key, op.kind)
+			return empty, operationInProgressError(fmt.Sprintf("%s of [%s] is in progress, retry %s later", op.kind, key, kind))
+		}
+
+		klog.Infof("[%s] %s of [%s] is already in progress, waiting for its result", loggerId, kind, key)
+		select {
+		case <-op.done:
+			resp, _ := op.resp.(T)
+			return resp, op.err
+		case <-ctx.Done():
+			return empty, operationInProgressError(fmt.Sprintf("%s of [%s] is still in progress, retry later", kind, key))
+		}
+	}
+	op := &trackedOperation{kind: kind, params: params, done: make(chan struct{})}
+	t.ops[key] = op
+	t.lock.Unlock()
+
+	defer func() {
+		t.lock.Lock()
+		delete(t.ops, key)
+		t.lock.Unlock()
+		close(op.done)
+	}()
+
+	resp, err := fn()
+	op.resp, op.err = resp, err
+	return resp, err
+}
+
+// operationInProgressError returns an Aborted error with msg and a RetryInfo
+// detail.
+func operationInProgressError(msg string) error {
+	st := status.New(codes.Aborted, msg)
+	if detailed, err := st.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(operationRetryDelay)}); err == nil {
+		st = detailed
+	}
+	return st.Err()
+}
+
+// operationParams returns the parameters of a request which must match for
+// duplicate requests in a canonical form.
+func operationParams(params map[string]string) string {
+	keys := make([]string, 0, len(params))
+	for key := range params {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	for _, key := range keys {
+		fmt.Fprintf(&b, "%s=%s;", key, params[key])
+	}
+	return b.String()
+}
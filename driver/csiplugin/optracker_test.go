@@ -0,0 +1,216 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package scale
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestVolumeOperationKey(t *testing.T) {
+	const volName = "pvc-0b5e4c7a-1f8e-4c55-9a3e-2c1d6f0e9b11"
+	tests := []struct {
+		name     string
+		volumeID string
+		want     string
+	}{
+		{"independent fileset", "0;2;123;uuid;;" + volName + ";/ibm/gpfs0/" + volName + "/" + volName + "-data", volName},
+		{"dependent fileset", "1;1;123;uuid;cg;" + volName + ";/ibm/gpfs0/cg/" + volName, volName},
+		{"lightweight", "0;0;123;uuid;;;/ibm/gpfs0/lw/" + volName, volName},
+		{"compressed", "0;2;123;uuid;;" + volName + "-COMPRESSLZ4csi;/ibm/gpfs0/x", volName},
+		{"tiered", "0;2;123;uuid;;" + volName + "-Tgoldcsi;/ibm/gpfs0/x", volName},
+		{"tier with hyphens", "0;2;123;uuid;;" + volName + "-Tgold-ssd-Tier_1csi;/ibm/gpfs0/x", volName},
+		{"tier ending like a suffix", "0;2;123;uuid;;" + volName + "-Tcsi-csi;/ibm/gpfs0/x", volName},
+		{"compressed and tiered", "0;2;123;uuid;;" + volName + "-COMPRESSZcsi-Tsystem-2csi;/ibm/gpfs0/x", volName},
+		{"static fileset", "0;2;123;uuid;;data-Test;/ibm/gpfs0/data-Test", "data-Test"},
+		{"version 1", "123;uuid;fileset=12;path=/ibm/gpfs0/" + volName, volName},
+		{"invalid", "invalid", "invalid"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := volumeOperationKey(tt.volumeID); got != tt.want {
+				t.Errorf("volumeOperationKey(%q) = %q, want %q", tt.volumeID, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSnapshotOperationKey(t *testing.T) {
+	tests := []struct {
+		name       string
+		snapshotID string
+		want       string
+	}{
+		{"classic", "0;2;123;uuid;;pvc-1;snapshot-1;;pvc-1-data", "snapshot-1"},
+		{"consistency group", "1;1;123;uuid;cg;pvc-2;snapshot-cg-1;snapshot-2", "snapshot-2"},
+		{"version 1", "123;uuid;pvc-3;snapshot-3", "snapshot-3"},
+		{"invalid", "invalid", "invalid"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := snapshotOperationKey(tt.snapshotID); got != tt.want {
+				t.Errorf("snapshotOperationKey(%q) = %q, want %q", tt.snapshotID, got, tt.want)
+			}
+		})
+	}
+}
+
+// startOperation starts an operation on key which runs until the returned
+// function is called.
+func startOperation(t *testing.T, tracker *operationTracker, key, kind, params string) (func(), <-chan error) {
+	t.Helper()
+	started, release, result := make(chan struct{}), make(chan struct{}), make(chan error, 1)
+	go func() {
+		_, err := trackOperation(context.Background(), tracker, key, kind, params, func() (string, error) {
+			close(started)
+			<-release
+			return "created", nil
+		})
+		result <- err
+	}()
+	<-started
+	return func() { close(release) }, result
+}
+
+func TestTrackOperationCoalescing(t *testing.T) {
+	tracker := newOperationTracker()
+	release, result := startOperation(t, tracker, "pvc-1", createVolume, "size=1")
+
+	const duplicates = 5
+	var calls atomic.Int32
+	var wg, waiting sync.WaitGroup
+	responses := make([]string, duplicates)
+	errs := make([]error, duplicates)
+	for i := 0; i < duplicates; i++ {
+		wg.Add(1)
+		waiting.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			waiting.Done()
+			responses[i], errs[i] = trackOperation(context.Background(), tracker, "pvc-1", createVolume, "size=1", func() (string, error) {
+				calls.Add(1)
+				return "duplicate", nil
+			})
+		}(i)
+	}
+	// give the duplicates time to find the operation in progress
+	waiting.Wait()
+	time.Sleep(100 * time.Millisecond)
+	release()
+	wg.Wait()
+
+	if err := <-result; err != nil {
+		t.Fatalf("operation error = %v", err)
+	}
+	if calls.Load() != 0 {
+		t.Errorf("duplicate requests ran the operation %d times, want 0", calls.Load())
+	}
+	for i := 0; i < duplicates; i++ {
+		if errs[i] != nil || responses[i] != "created" {
+			t.Errorf("duplicate %d = %q, %v, want the result of the operation in progress", i, responses[i], errs[i])
+		}
+	}
+
+	// the key is free when the operation is done
+	resp, err := trackOperation(context.Background(), tracker, "pvc-1", deleteVolume, "", func() (string, error) {
+		return "deleted", nil
+	})
+	if err != nil || resp != "deleted" {
+		t.Errorf("trackOperation() after completion = %q, %v, want deleted", resp, err)
+	}
+}
+
+func TestTrackOperationAborted(t *testing.T) {
+	tests := []struct {
+		name   string
+		kind   string
+		params string
+	}{
+		{"other operation", deleteVolume, ""},
+		{"other parameters", createVolume, "size=2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracker := newOperationTracker()
+			release, result := startOperation(t, tracker, "pvc-1", createVolume, "size=1")
+			defer func() {
+				release()
+				<-result
+			}()
+
+			called := false
+			_, err := trackOperation(context.Background(), tracker, "pvc-1", tt.kind, tt.params, func() (string, error) {
+				called = true
+				return "", nil
+			})
+			if called {
+				t.Errorf("conflicting request ran the operation")
+			}
+			checkAborted(t, err)
+		})
+	}
+}
+
+func TestTrackOperationCanceled(t *testing.T) {
+	tracker := newOperationTracker()
+	release, result := startOperation(t, tracker, "pvc-1", createVolume, "size=1")
+	defer func() {
+		release()
+		<-result
+	}()
+
+	// a duplicate request stops waiting when its context is done
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := trackOperation(ctx, tracker, "pvc-1", createVolume, "size=1", func() (string, error) {
+		return "", errors.New("duplicate request ran the operation")
+	})
+	checkAborted(t, err)
+}
+
+func checkAborted(t *testing.T, err error) {
+	t.Helper()
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != codes.Aborted {
+		t.Fatalf("error = %v, want Aborted", err)
+	}
+	for _, detail := range st.Details() {
+		if retryInfo, ok := detail.(*errdetails.RetryInfo); ok && retryInfo.GetRetryDelay().AsDuration() == operationRetryDelay {
+			return
+		}
+	}
+	t.Errorf("error details = %v, want a RetryInfo of %v", st.Details(), operationRetryDelay)
+}
+
+func TestOperationParams(t *testing.T) {
+	a := operationParams(map[string]string{"tier": "gold", "compression": "z"})
+	b := operationParams(map[string]string{"compression": "z", "tier": "gold"})
+	if a != b {
+		t.Errorf("operationParams() = %q and %q for the same parameters", a, b)
+	}
+	if a == operationParams(map[string]string{"tier": "silver", "compression": "z"}) {
+		t.Errorf("operationParams() is the same for different parameters")
+	}
+}
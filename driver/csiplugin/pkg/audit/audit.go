@@ -0,0 +1,170 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package audit writes a record of every mutating call of IBM Storage Scale
+// as a JSON line to a rotating file and optionally posts it to a webhook.
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/IBM/ibm-spectrum-scale-csi/driver/csiplugin/utils"
+	"github.com/natefinch/lumberjack"
+	"k8s.io/klog/v2"
+)
+
+const (
+	OutcomeSuccess = "success"
+	OutcomeFailure = "failure"
+
+	// rotation of the audit log file
+	maxFileSizeMB = 100
+	maxBackups    = 10
+)
+
+// Record is the audit record of a mutating call of IBM Storage Scale.
+type Record struct {
+	Time      time.Time `json:"time"`
+	LoggerID  string    `json:"loggerId,omitempty"`
+	Operation string    `json:"operation"`
+	Cluster   string    `json:"cluster"`
+	// User is the GUI user of the call
+	User      string            `json:"user,omitempty"`
+	Arguments map[string]string `json:"arguments,omitempty"`
+
+	// the CSI request the call was made for, empty for background work
+	Request      string `json:"request,omitempty"`
+	RequestName  string `json:"requestName,omitempty"`
+	PVCName      string `json:"pvcName,omitempty"`
+	PVCNamespace string `json:"pvcNamespace,omitempty"`
+
+	Outcome    string `json:"outcome"`
+	Error      string `json:"error,omitempty"`
+	DurationMs int64  `json:"durationMs"`
+}
+
+// Request identifies the CSI request of the calls made with a context.
+type Request struct {
+	// Operation is the CSI RPC, e.g. CreateVolume
+	Operation string
+	// Name is the name or ID of the volume or snapshot of the request
+	Name         string
+	PVCName      string
+	PVCNamespace string
+}
+
+type requestKey struct{}
+
+// WithRequest returns a context whose calls are recorded for r.
+func WithRequest(ctx context.Context, r Request) context.Context {
+	return context.WithValue(ctx, requestKey{}, r)
+}
+
+// Logger writes audit records to a file and a webhook.
+type Logger struct {
+	lock    sync.Mutex
+	file    io.WriteCloser
+	webhook *webhook
+}
+
+var logger atomic.Pointer[Logger]
+
+// NewLogger returns a logger writing to file, rotated by size, and posting
+// to webhookURL. Either of them may be empty.
+func NewLogger(file, webhookURL string) (*Logger, error) {
+	l := &Logger{}
+	if file != "" {
+		l.file = &lumberjack.Logger{
+			Filename:   filepath.Clean(file),
+			MaxSize:    maxFileSizeMB,
+			MaxBackups: maxBackups,
+			Compress:   true,
+		}
+	}
+	if webhookURL != "" {
+		w, err := newWebhook(webhookURL)
+		if err != nil {
+			return nil, err
+		}
+		l.webhook = w
+	}
+	return l, nil
+}
+
+// SetLogger sets the logger of Log, records are dropped if it is nil.
+func SetLogger(l *Logger) {
+	logger.Store(l)
+}
+
+// Log completes r with the time, logger ID and request of ctx and writes it
+// with the logger set by SetLogger.
+func Log(ctx context.Context, r Record) {
+	l := logger.Load()
+	if l == nil {
+		return
+	}
+	if r.Time.IsZero() {
+		r.Time = time.Now()
+	}
+	r.LoggerID = utils.GetLoggerId(ctx)
+	if req, ok := ctx.Value(requestKey{}).(Request); ok {
+		r.Request, r.RequestName, r.PVCName, r.PVCNamespace = req.Operation, req.Name, req.PVCName, req.PVCNamespace
+	}
+	l.Log(r)
+}
+
+// Log writes r, a failure is logged but not returned as the audited call
+// completed already.
+func (l *Logger) Log(r Record) {
+	data, err := json.Marshal(r)
+	if err != nil {
+		klog.Errorf("[%s] unable to encode audit record of %s: %v", r.LoggerID, r.Operation, err)
+		return
+	}
+	if l.file != nil {
+		l.lock.Lock()
+		_, err = l.file.Write(append(data, '\n'))
+		l.lock.Unlock()
+		if err != nil {
+			klog.Errorf("[%s] unable to write audit record of %s: %v", r.LoggerID, r.Operation, err)
+		}
+	}
+	if l.webhook != nil {
+		l.webhook.send(r.LoggerID, data)
+	}
+}
+
+// Close sends the queued records to the webhook and closes the file.
+func (l *Logger) Close() error {
+	if l.webhook != nil {
+		l.webhook.close()
+	}
+	if l.file != nil {
+		l.lock.Lock()
+		defer l.lock.Unlock()
+		if err := l.file.Close(); err != nil {
+			return fmt.Errorf("unable to close audit log: %v", err)
+		}
+	}
+	return nil
+}
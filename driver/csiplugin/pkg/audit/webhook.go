@@ -0,0 +1,114 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package audit
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+const (
+	// webhookQueueSize is the number of records waiting for the webhook,
+	// further records are dropped from the webhook but still written to the
+	// file
+	webhookQueueSize = 1024
+	webhookTimeout   = 10 * time.Second
+)
+
+// webhook posts every record as JSON in the background, so a slow or
+// unavailable receiver never delays a volume operation.
+type webhook struct {
+	url    string
+	client *http.Client
+	queue  chan []byte
+	done   chan struct{}
+
+	lock   sync.Mutex
+	closed bool
+}
+
+func newWebhook(webhookURL string) (*webhook, error) {
+	u, err := url.Parse(webhookURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("invalid audit webhook URL %q, expected http:// or https://", webhookURL)
+	}
+	w := &webhook{
+		url:    webhookURL,
+		client: &http.Client{Timeout: webhookTimeout},
+		queue:  make(chan []byte, webhookQueueSize),
+		done:   make(chan struct{}),
+	}
+	go w.run()
+	return w, nil
+}
+
+func (w *webhook) send(loggerId string, data []byte) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if w.closed {
+		return
+	}
+	select {
+	case w.queue <- data:
+	default:
+		klog.Warningf("[%s] audit webhook queue is full, record not sent: %s", loggerId, data)
+	}
+}
+
+func (w *webhook) run() {
+	defer close(w.done)
+	for data := range w.queue {
+		if err := w.post(data); err != nil {
+			klog.Errorf("unable to send audit record to webhook: %v, record: %s", err, data)
+		}
+	}
+}
+
+func (w *webhook) post(data []byte) error {
+	response, err := w.client.Post(w.url, "application/json", bytes.NewReader(data)) // #nosec G107 the URL is configured by the administrator
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	_, _ = io.Copy(io.Discard, response.Body)
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return fmt.Errorf("webhook returned %s", response.Status)
+	}
+	return nil
+}
+
+// close waits up to webhookTimeout for the queued records to be sent.
+func (w *webhook) close() {
+	w.lock.Lock()
+	if !w.closed {
+		w.closed = true
+		close(w.queue)
+	}
+	w.lock.Unlock()
+	select {
+	case <-w.done:
+	case <-time.After(webhookTimeout):
+		klog.Warningf("audit records still queued for the webhook after %v, they are not sent", webhookTimeout)
+	}
+}
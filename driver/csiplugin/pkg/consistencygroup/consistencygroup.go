@@ -22,6 +22,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/IBM/ibm-spectrum-scale-csi/driver/csiplugin/pkg/scalehandle"
 	corev1 "k8s.io/api/core/v1"
 )
 
@@ -42,7 +43,7 @@ const (
 
 var (
 	ErrNoCsiVolume            = errors.New("no CSI volume")
-	ErrInvalidCsiVolumeHandle = errors.New("invalid CSI volume handle format")
+	ErrInvalidCsiVolumeHandle = scalehandle.ErrInvalidVolumeHandle
 )
 
 // VolumeHandle represents the VolumeHandle parameter that exists in the CSI PV spec.
@@ -64,25 +65,25 @@ func GetVolumeHandle(pvs *corev1.CSIPersistentVolumeSource) (VolumeHandle, error
 	if pvs == nil {
 		return vh, ErrNoCsiVolume
 	}
-	split := strings.Split(pvs.VolumeHandle, ";")
-	if len(split) < 7 {
+	handle, err := scalehandle.DecodeVolume(pvs.VolumeHandle)
+	if err != nil || handle.Version != scalehandle.V2 {
 		return vh, ErrInvalidCsiVolumeHandle
 	}
-	i, err := strconv.Atoi(split[0])
+	i, err := strconv.Atoi(handle.StorageClassType)
 	if err != nil {
 		return vh, err
 	}
 	vh.StorageClassType = StorageClassType(i)
-	i, err = strconv.Atoi(split[1])
+	i, err = strconv.Atoi(handle.VolumeType)
 	if err != nil {
 		return vh, err
 	}
 	vh.VolumeType = VolumeType(i)
-	vh.ClusterID = split[2]
-	vh.FilesystemUID = split[3]
-	vh.ConsistencyGroup = split[4]
-	vh.FilesetName = split[5]
-	vh.FilesetLinkPath = split[6]
+	vh.ClusterID = handle.ClusterID
+	vh.FilesystemUID = handle.FilesystemUUID
+	vh.ConsistencyGroup = handle.ConsistencyGroup
+	vh.FilesetName = handle.FilesetName
+	vh.FilesetLinkPath = handle.Path
 	return vh, nil
 }
 
@@ -122,5 +123,16 @@ var _ fmt.Stringer = VolumeHandle{}
 
 // VolumeHandle implements fmt.Stringer interface to return the volume handle in string format
 func (vh VolumeHandle) String() string {
-	return fmt.Sprintf("%x;%x;%s;%s;%s;%s;%s", vh.StorageClassType, vh.VolumeType, vh.ClusterID, vh.FilesystemUID, vh.ConsistencyGroup, vh.FilesetName, vh.FilesetLinkPath)
+	// a version 2 handle without fileset ID always encodes
+	handle, _ := scalehandle.Volume{
+		Version:          scalehandle.V2,
+		StorageClassType: strconv.Itoa(int(vh.StorageClassType)),
+		VolumeType:       strconv.Itoa(int(vh.VolumeType)),
+		ClusterID:        vh.ClusterID,
+		FilesystemUUID:   vh.FilesystemUID,
+		ConsistencyGroup: vh.ConsistencyGroup,
+		FilesetName:      vh.FilesetName,
+		Path:             vh.FilesetLinkPath,
+	}.Encode()
+	return handle
 }
@@ -0,0 +1,311 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package filesetadoption generates statically provisioned PersistentVolumes
+// and PersistentVolumeClaims for existing filesets, with the volume handle
+// the driver generates for static fileset based volumes.
+package filesetadoption
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/IBM/ibm-spectrum-scale-csi/driver/csiplugin/connectors"
+	"github.com/IBM/ibm-spectrum-scale-csi/driver/csiplugin/pkg/scaleconfig"
+	"github.com/IBM/ibm-spectrum-scale-csi/driver/csiplugin/pkg/scalehandle"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const (
+	filesystemTypeRemote = "remote"
+	filesystemMounted    = "mounted"
+	filesetUnlinkedPath  = "--"
+	rootFileset          = "root"
+	// quota block limits are reported in KiB
+	quotaBlockUnit = 1024
+)
+
+var invalidNameChars = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// Options configures the adoption of filesets.
+type Options struct {
+	// Filesystem is the name of the filesystem on the primary cluster.
+	Filesystem string
+	// DriverName is set as the CSI driver of the PersistentVolumes.
+	DriverName string
+	// Namespace of the PersistentVolumeClaims.
+	Namespace    string
+	StorageClass string
+	AccessMode   corev1.PersistentVolumeAccessMode
+	// DefaultSize is the capacity of the volumes of filesets without a block quota.
+	DefaultSize *resource.Quantity
+}
+
+// Volume is an adopted fileset.
+type Volume struct {
+	Fileset               string
+	PersistentVolume      *corev1.PersistentVolume
+	PersistentVolumeClaim *corev1.PersistentVolumeClaim
+
+	conn connectors.SpectrumScaleConnector
+	// filesystem is the name of the filesystem on the owning cluster
+	filesystem string
+}
+
+// Adopter generates the PersistentVolumes and PersistentVolumeClaims of
+// existing filesets of a filesystem.
+type Adopter struct {
+	conns map[string]connectors.SpectrumScaleConnector
+	opts  Options
+
+	// resolved from the filesystem by resolve
+	conn            connectors.SpectrumScaleConnector
+	clusterID       string
+	fsUUID          string
+	owningFs        string
+	owningMount     string
+	localMountPoint string
+}
+
+// NewAdopter returns an Adopter for the filesystem of opts. conns are the
+// connectors returned by scaleconfig.NewConnectors.
+func NewAdopter(ctx context.Context, conns map[string]connectors.SpectrumScaleConnector, opts Options) (*Adopter, error) {
+	a := &Adopter{conns: conns, opts: opts}
+	if err := a.resolve(ctx); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// resolve finds the cluster owning the filesystem and the mount points of the
+// filesystem on the primary and the owning cluster.
+func (a *Adopter) resolve(ctx context.Context) error {
+	primaryConn, ok := a.conns[scaleconfig.Primary]
+	if !ok {
+		return fmt.Errorf("unable to find connector for primary cluster")
+	}
+	fsDetails, err := primaryConn.GetFilesystemDetails(ctx, a.opts.Filesystem)
+	if err != nil {
+		return fmt.Errorf("unable to get details of filesystem %s on primary cluster: %w", a.opts.Filesystem, err)
+	}
+	if fsDetails.Mount.Status != filesystemMounted {
+		return fmt.Errorf("filesystem %s is not mounted on GUI node of primary cluster", a.opts.Filesystem)
+	}
+	a.localMountPoint = fsDetails.Mount.MountPoint
+
+	if fsDetails.Type != filesystemTypeRemote {
+		a.conn = primaryConn
+		a.owningFs = a.opts.Filesystem
+		a.clusterID, err = primaryConn.GetClusterId(ctx)
+		if err != nil {
+			return fmt.Errorf("unable to get cluster ID of primary cluster: %w", err)
+		}
+	} else {
+		// the remote device name is <owning cluster name>:<filesystem name on owning cluster>
+		remoteDevice := strings.Split(fsDetails.Mount.RemoteDeviceName, ":")
+		clusterName := remoteDevice[0]
+		a.owningFs = remoteDevice[len(remoteDevice)-1]
+		for id, conn := range a.conns {
+			if id == scaleconfig.Primary {
+				continue
+			}
+			summary, err := conn.GetClusterSummary(ctx)
+			if err != nil {
+				return fmt.Errorf("unable to get cluster summary of cluster %s: %w", id, err)
+			}
+			if summary.ClusterName == clusterName {
+				a.conn, a.clusterID = conn, id
+				break
+			}
+		}
+		if a.conn == nil {
+			return fmt.Errorf("owning cluster %s of filesystem %s is not configured for the driver", clusterName, a.opts.Filesystem)
+		}
+	}
+
+	owningDetails, err := a.conn.GetFilesystemDetails(ctx, a.owningFs)
+	if err != nil {
+		return fmt.Errorf("unable to get details of filesystem %s on cluster %s: %w", a.owningFs, a.clusterID, err)
+	}
+	a.fsUUID = owningDetails.UUID
+	a.owningMount = owningDetails.Mount.MountPoint
+	return nil
+}
+
+// Candidates returns the linked filesets of the filesystem which are not
+// created by the driver and can be adopted.
+func (a *Adopter) Candidates(ctx context.Context) ([]string, error) {
+	filesets, err := a.conn.ListFilesets(ctx, a.owningFs)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list filesets of filesystem %s: %w", a.owningFs, err)
+	}
+	names := []string{}
+	for _, fileset := range filesets {
+		if fileset.FilesetName == rootFileset || fileset.Config.Path == "" || fileset.Config.Path == filesetUnlinkedPath {
+			continue
+		}
+		if strings.Contains(fileset.Config.Comment, connectors.FilesetComment) {
+			continue
+		}
+		names = append(names, fileset.FilesetName)
+	}
+	return names, nil
+}
+
+// Adopt generates the PersistentVolume and the PersistentVolumeClaim of a fileset.
+func (a *Adopter) Adopt(ctx context.Context, filesetName string) (*Volume, error) {
+	fileset, err := a.conn.ListFileset(ctx, a.owningFs, filesetName)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list fileset %s in filesystem %s: %w", filesetName, a.owningFs, err)
+	}
+	if fileset.FilesetName == "" && fileset.Config.FilesetName == "" {
+		return nil, fmt.Errorf("fileset %s does not exist in filesystem %s", filesetName, a.owningFs)
+	}
+	if filesetName == rootFileset {
+		return nil, fmt.Errorf("root fileset of filesystem %s can not be adopted", a.owningFs)
+	}
+	if fileset.Config.Path == "" || fileset.Config.Path == filesetUnlinkedPath {
+		return nil, fmt.Errorf("fileset %s of filesystem %s is not linked", filesetName, a.owningFs)
+	}
+	if strings.Contains(fileset.Config.Comment, connectors.FilesetComment) {
+		return nil, fmt.Errorf("fileset %s of filesystem %s is already managed by IBM Storage Scale CSI driver", filesetName, a.owningFs)
+	}
+	if !strings.HasPrefix(fileset.Config.Path, a.owningMount) {
+		return nil, fmt.Errorf("fileset %s is linked at %s, outside of mount point %s of filesystem %s", filesetName, fileset.Config.Path, a.owningMount, a.owningFs)
+	}
+
+	volumeType := scalehandle.DependentFilesetVolume
+	if fileset.Config.IsInodeSpaceOwner {
+		volumeType = scalehandle.IndependentFilesetVolume
+	}
+	// path of the fileset on the primary cluster, as generated by the driver
+	relativePath := strings.Trim(strings.Replace(fileset.Config.Path, a.owningMount, "", 1), "!/")
+	volumePath := fmt.Sprintf("%s/%s", a.localMountPoint, relativePath)
+	volumeHandle, err := scalehandle.Volume{
+		Version:          scalehandle.Latest,
+		StorageClassType: scalehandle.ClassicStorageClass,
+		VolumeType:       volumeType,
+		ClusterID:        a.clusterID,
+		FilesystemUUID:   a.fsUUID,
+		FilesetName:      filesetName,
+		Path:             volumePath,
+	}.Encode()
+	if err != nil {
+		return nil, fmt.Errorf("fileset %s: %w", filesetName, err)
+	}
+
+	capacity, err := a.capacity(ctx, filesetName)
+	if err != nil {
+		return nil, err
+	}
+
+	pvName, err := objectName("pv-" + a.opts.Filesystem + "-" + filesetName)
+	if err != nil {
+		return nil, fmt.Errorf("fileset %s: %w", filesetName, err)
+	}
+	pvcName, err := objectName(filesetName)
+	if err != nil {
+		return nil, fmt.Errorf("fileset %s: %w", filesetName, err)
+	}
+
+	return &Volume{
+		Fileset:               filesetName,
+		PersistentVolume:      a.persistentVolume(pvName, pvcName, volumeHandle, capacity),
+		PersistentVolumeClaim: a.persistentVolumeClaim(pvName, pvcName, capacity),
+		conn:                  a.conn,
+		filesystem:            a.owningFs,
+	}, nil
+}
+
+// Tag sets the comment of adopted filesets on the fileset of the volume, so
+// that the driver manages it like a dynamically provisioned fileset
+// afterwards. Unlike a dynamically provisioned fileset, an adopted fileset is
+// neither deleted with its volume nor collected as an orphan.
+func (v *Volume) Tag(ctx context.Context) error {
+	opts := map[string]interface{}{
+		connectors.FilesetCommentKey: fmt.Sprintf(connectors.AdoptedFilesetComment, v.PersistentVolumeClaim.Name, v.PersistentVolumeClaim.Namespace),
+	}
+	if err := v.conn.UpdateFileset(ctx, v.filesystem, "", v.Fileset, opts, ""); err != nil {
+		return fmt.Errorf("unable to set comment of fileset %s in filesystem %s: %w", v.Fileset, v.filesystem, err)
+	}
+	return nil
+}
+
+// capacity returns the block quota of a fileset, or the default size if the
+// fileset has no block quota.
+func (a *Adopter) capacity(ctx context.Context, filesetName string) (resource.Quantity, error) {
+	quota, err := a.conn.GetFilesetQuotaDetails(ctx, a.owningFs, filesetName)
+	if err != nil {
+		return resource.Quantity{}, fmt.Errorf("unable to get quota of fileset %s in filesystem %s: %w", filesetName, a.owningFs, err)
+	}
+	if quota.BlockLimit > 0 {
+		return *resource.NewQuantity(int64(quota.BlockLimit)*quotaBlockUnit, resource.BinarySI), nil
+	}
+	if a.opts.DefaultSize == nil {
+		return resource.Quantity{}, fmt.Errorf("fileset %s in filesystem %s has no block quota, specify the size of the volume", filesetName, a.owningFs)
+	}
+	return a.opts.DefaultSize.DeepCopy(), nil
+}
+
+func (a *Adopter) persistentVolume(pvName, pvcName, volumeHandle string, capacity resource.Quantity) *corev1.PersistentVolume {
+	return &corev1.PersistentVolume{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "PersistentVolume"},
+		ObjectMeta: metav1.ObjectMeta{Name: pvName},
+		Spec: corev1.PersistentVolumeSpec{
+			Capacity:                      corev1.ResourceList{corev1.ResourceStorage: capacity},
+			AccessModes:                   []corev1.PersistentVolumeAccessMode{a.opts.AccessMode},
+			PersistentVolumeReclaimPolicy: corev1.PersistentVolumeReclaimRetain,
+			StorageClassName:              a.opts.StorageClass,
+			ClaimRef:                      &corev1.ObjectReference{Namespace: a.opts.Namespace, Name: pvcName},
+			PersistentVolumeSource: corev1.PersistentVolumeSource{
+				CSI: &corev1.CSIPersistentVolumeSource{
+					Driver:       a.opts.DriverName,
+					VolumeHandle: volumeHandle,
+				},
+			},
+		},
+	}
+}
+
+func (a *Adopter) persistentVolumeClaim(pvName, pvcName string, capacity resource.Quantity) *corev1.PersistentVolumeClaim {
+	storageClass := a.opts.StorageClass
+	return &corev1.PersistentVolumeClaim{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "PersistentVolumeClaim"},
+		ObjectMeta: metav1.ObjectMeta{Name: pvcName, Namespace: a.opts.Namespace},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes:      []corev1.PersistentVolumeAccessMode{a.opts.AccessMode},
+			StorageClassName: &storageClass,
+			VolumeName:       pvName,
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: capacity},
+			},
+		},
+	}
+}
+
+// objectName converts name to a valid Kubernetes object name.
+func objectName(name string) (string, error) {
+	name = strings.Trim(invalidNameChars.ReplaceAllString(strings.ToLower(name), "-"), "-")
+	if errs := validation.IsDNS1123Subdomain(name); len(errs) > 0 {
+		return "", fmt.Errorf("unable to generate a valid object name %q: %s", name, strings.Join(errs, ", "))
+	}
+	return name, nil
+}
@@ -0,0 +1,248 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package inspect maps the PersistentVolumeClaims and VolumeSnapshots of IBM
+// Storage Scale CSI driver to their filesets, paths, quotas and snapshots in
+// IBM Storage Scale.
+package inspect
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/IBM/ibm-spectrum-scale-csi/driver/csiplugin/connectors"
+	"github.com/IBM/ibm-spectrum-scale-csi/driver/csiplugin/pkg/supportbundle"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// quotaBlockUnit is the unit of the block quota returned by the GUI
+	quotaBlockUnit = 1024
+
+	// volume types of supportbundle.VolumeHandle
+	lightweightVolume = "lightweight"
+	shallowCopyVolume = "shallowCopy"
+	advancedClass     = "advanced"
+)
+
+// Volume is a PersistentVolume of the driver with its state in IBM Storage
+// Scale.
+type Volume struct {
+	Namespace        string `json:"namespace,omitempty"`
+	Claim            string `json:"claim,omitempty"`
+	PersistentVolume string `json:"persistentVolume"`
+	StorageClass     string `json:"storageClass,omitempty"`
+	// Capacity is the capacity of the PersistentVolume in bytes
+	Capacity int64 `json:"capacity"`
+	// Handle is the decoded volume handle, its filesystem is the name of the
+	// filesystem on the primary cluster
+	Handle supportbundle.VolumeHandle `json:"handle"`
+	// Filesystem is the name of the filesystem on the owning cluster
+	Filesystem string   `json:"filesystem,omitempty"`
+	Quota      *Quota   `json:"quota,omitempty"`
+	Errors     []string `json:"errors,omitempty"`
+}
+
+// Quota is the fileset quota of a volume, lightweight volumes have none.
+type Quota struct {
+	// BlockLimit and BlockUsage are in bytes
+	BlockLimit int64 `json:"blockLimit"`
+	BlockUsage int64 `json:"blockUsage"`
+	FilesLimit int   `json:"filesLimit"`
+	FilesUsage int   `json:"filesUsage"`
+}
+
+// Inspector queries Kubernetes and the IBM Storage Scale clusters of the
+// driver.
+type Inspector struct {
+	client        kubernetes.Interface
+	dynamicClient dynamic.Interface
+	conns         map[string]connectors.SpectrumScaleConnector
+	driverName    string
+
+	// filesystems caches the filesystem names keyed by clusterID;UUID and
+	// the mount points keyed by clusterID;name
+	filesystems map[string]string
+	mountPoints map[string]string
+}
+
+// NewInspector returns an Inspector. conns are the connectors returned by
+// scaleconfig.NewConnectors, they are not used to list snapshots.
+func NewInspector(client kubernetes.Interface, dynamicClient dynamic.Interface, conns map[string]connectors.SpectrumScaleConnector, driverName string) *Inspector {
+	return &Inspector{
+		client:        client,
+		dynamicClient: dynamicClient,
+		conns:         conns,
+		driverName:    driverName,
+		filesystems:   map[string]string{},
+		mountPoints:   map[string]string{},
+	}
+}
+
+// DescribeClaim returns the volume of a bound PersistentVolumeClaim.
+func (i *Inspector) DescribeClaim(ctx context.Context, namespace, name string) (*Volume, error) {
+	pvc, err := i.client.CoreV1().PersistentVolumeClaims(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("unable to get PersistentVolumeClaim %s/%s: %w", namespace, name, err)
+	}
+	if pvc.Spec.VolumeName == "" {
+		return nil, fmt.Errorf("PersistentVolumeClaim %s/%s is not bound", namespace, name)
+	}
+	pv, err := i.client.CoreV1().PersistentVolumes().Get(ctx, pvc.Spec.VolumeName, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("unable to get PersistentVolume %s: %w", pvc.Spec.VolumeName, err)
+	}
+	if pv.Spec.CSI == nil || pv.Spec.CSI.Driver != i.driverName {
+		return nil, fmt.Errorf("PersistentVolume %s is not a volume of %s", pv.Name, i.driverName)
+	}
+	return i.volume(ctx, pv), nil
+}
+
+// volumes returns the PersistentVolumes of the driver bound to claims in
+// namespace, or in all namespaces if namespace is empty, sorted by claim.
+func (i *Inspector) volumes(ctx context.Context, namespace string) ([]corev1.PersistentVolume, error) {
+	list, err := i.client.CoreV1().PersistentVolumes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("unable to list PersistentVolumes: %w", err)
+	}
+	pvs := []corev1.PersistentVolume{}
+	for _, pv := range list.Items {
+		if pv.Spec.CSI == nil || pv.Spec.CSI.Driver != i.driverName || pv.Spec.ClaimRef == nil {
+			continue
+		}
+		if namespace != "" && pv.Spec.ClaimRef.Namespace != namespace {
+			continue
+		}
+		pvs = append(pvs, pv)
+	}
+	sort.Slice(pvs, func(a, b int) bool {
+		return claimKey(&pvs[a]) < claimKey(&pvs[b])
+	})
+	return pvs, nil
+}
+
+func claimKey(pv *corev1.PersistentVolume) string {
+	return pv.Spec.ClaimRef.Namespace + "/" + pv.Spec.ClaimRef.Name
+}
+
+// volume decodes the volume handle of pv and gets the filesystem and the
+// quota of the volume. Errors of the backend are recorded in the volume.
+func (i *Inspector) volume(ctx context.Context, pv *corev1.PersistentVolume) *Volume {
+	vol := &Volume{PersistentVolume: pv.Name, StorageClass: pv.Spec.StorageClassName, Handle: supportbundle.DecodeVolumeHandle(pv)}
+	if ref := pv.Spec.ClaimRef; ref != nil {
+		vol.Namespace, vol.Claim = ref.Namespace, ref.Name
+	}
+	if capacity, ok := pv.Spec.Capacity[corev1.ResourceStorage]; ok {
+		vol.Capacity = capacity.Value()
+	}
+	if vol.Handle.Error != "" {
+		vol.Errors = append(vol.Errors, "invalid volume handle: "+vol.Handle.Error)
+		return vol
+	}
+
+	conn, err := i.conn(vol.Handle.ClusterID)
+	if err != nil {
+		vol.Errors = append(vol.Errors, err.Error())
+		return vol
+	}
+	if vol.Filesystem, err = i.filesystemName(ctx, conn, vol.Handle.ClusterID, vol.Handle.FilesystemUUID); err != nil {
+		vol.Errors = append(vol.Errors, err.Error())
+		return vol
+	}
+	// volume handles of old releases identify the fileset by its ID
+	if vol.Handle.Fileset == "" && vol.Handle.FilesetID != "" {
+		if vol.Handle.Fileset, err = conn.GetFileSetNameFromId(ctx, vol.Filesystem, vol.Handle.FilesetID); err != nil {
+			vol.Errors = append(vol.Errors, err.Error())
+			return vol
+		}
+	}
+	// shallow copy volumes are paths in a snapshot without a fileset of their own
+	if vol.Handle.Fileset == "" || vol.Handle.VolumeType == shallowCopyVolume {
+		return vol
+	}
+	quota, err := conn.GetFilesetQuotaDetails(ctx, vol.Filesystem, vol.Handle.Fileset)
+	if err != nil {
+		vol.Errors = append(vol.Errors, fmt.Sprintf("unable to get quota of fileset %s: %v", vol.Handle.Fileset, err))
+		return vol
+	}
+	vol.Quota = &Quota{
+		BlockLimit: int64(quota.BlockLimit) * quotaBlockUnit,
+		BlockUsage: int64(quota.BlockUsage) * quotaBlockUnit,
+		FilesLimit: quota.FilesLimit,
+		FilesUsage: quota.FilesUsage,
+	}
+	return vol
+}
+
+func (i *Inspector) conn(clusterID string) (connectors.SpectrumScaleConnector, error) {
+	conn, ok := i.conns[clusterID]
+	if !ok {
+		return nil, fmt.Errorf("cluster %s is not configured in the driver", clusterID)
+	}
+	return conn, nil
+}
+
+// filesystemName returns the name of a filesystem on the cluster of conn.
+func (i *Inspector) filesystemName(ctx context.Context, conn connectors.SpectrumScaleConnector, clusterID, uuid string) (string, error) {
+	key := clusterID + ";" + uuid
+	if name, ok := i.filesystems[key]; ok {
+		return name, nil
+	}
+	name, err := conn.GetFilesystemName(ctx, uuid)
+	if err != nil {
+		return "", fmt.Errorf("unable to get filesystem %s of cluster %s: %w", uuid, clusterID, err)
+	}
+	i.filesystems[key] = name
+	return name, nil
+}
+
+// mountPoint returns the mount point of a filesystem on a cluster.
+func (i *Inspector) mountPoint(ctx context.Context, clusterID, filesystem string) (string, error) {
+	key := clusterID + ";" + filesystem
+	if mountPoint, ok := i.mountPoints[key]; ok {
+		return mountPoint, nil
+	}
+	conn, err := i.conn(clusterID)
+	if err != nil {
+		return "", err
+	}
+	mountPoint, err := conn.GetFilesystemMountpoint(ctx, filesystem)
+	if err != nil {
+		return "", fmt.Errorf("unable to get mount point of filesystem %s of cluster %s: %w", filesystem, clusterID, err)
+	}
+	i.mountPoints[key] = mountPoint
+	return mountPoint, nil
+}
+
+// relativePath returns path relative to the mount point of a filesystem of
+// the primary cluster. The volume handles contain the path below the mount
+// point of the primary cluster, which is the same below the mount point of
+// the owning cluster.
+func (i *Inspector) relativePath(ctx context.Context, clusterID, filesystem, path string) (string, error) {
+	mountPoint, err := i.mountPoint(ctx, clusterID, filesystem)
+	if err != nil {
+		return "", err
+	}
+	if path != mountPoint && !strings.HasPrefix(path, strings.TrimSuffix(mountPoint, "/")+"/") {
+		return "", fmt.Errorf("path %s is not below mount point %s of filesystem %s", path, mountPoint, filesystem)
+	}
+	return strings.Trim(strings.TrimPrefix(path, mountPoint), "/"), nil
+}
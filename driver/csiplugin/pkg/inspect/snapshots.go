@@ -0,0 +1,124 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package inspect
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/IBM/ibm-spectrum-scale-csi/driver/csiplugin/pkg/scalehandle"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var (
+	volumeSnapshotGVR        = schema.GroupVersionResource{Group: "snapshot.storage.k8s.io", Version: "v1", Resource: "volumesnapshots"}
+	volumeSnapshotContentGVR = schema.GroupVersionResource{Group: "snapshot.storage.k8s.io", Version: "v1", Resource: "volumesnapshotcontents"}
+)
+
+// Snapshot is a VolumeSnapshot of the driver with its decoded snapshot
+// handle.
+type Snapshot struct {
+	Namespace    string `json:"namespace"`
+	Name         string `json:"name"`
+	Claim        string `json:"claim,omitempty"`
+	Content      string `json:"content"`
+	Ready        bool   `json:"ready"`
+	RestoreSize  string `json:"restoreSize,omitempty"`
+	CreationTime string `json:"creationTime,omitempty"`
+
+	SnapshotHandle   string `json:"snapshotHandle,omitempty"`
+	ClusterID        string `json:"clusterId,omitempty"`
+	FilesystemUUID   string `json:"filesystemUUID,omitempty"`
+	ConsistencyGroup string `json:"consistencyGroup,omitempty"`
+	Fileset          string `json:"fileset,omitempty"`
+	// SnapshotName is the name of the fileset snapshot in IBM Storage Scale
+	SnapshotName string `json:"snapshotName,omitempty"`
+	Path         string `json:"path,omitempty"`
+	Error        string `json:"error,omitempty"`
+}
+
+// decodeSnapshotHandle decodes the fields of a snapshot handle into s.
+func decodeSnapshotHandle(s *Snapshot, handle string) {
+	s.SnapshotHandle = handle
+	sh, err := scalehandle.DecodeSnapshot(handle)
+	if err != nil {
+		s.Error = err.Error()
+		return
+	}
+	s.ClusterID, s.FilesystemUUID, s.ConsistencyGroup, s.Fileset, s.SnapshotName = sh.ClusterID, sh.FilesystemUUID, sh.ConsistencyGroup, sh.FilesetName, sh.SnapshotName
+	// the driver uses the root of the snapshot without a path
+	s.Path = sh.Path
+	if s.Path == "" {
+		s.Path = "/"
+	}
+}
+
+// ListSnapshots returns the VolumeSnapshots of the driver in namespace, or in
+// all namespaces if namespace is empty.
+func (i *Inspector) ListSnapshots(ctx context.Context, namespace string) ([]Snapshot, error) {
+	contentList, err := i.dynamicClient.Resource(volumeSnapshotContentGVR).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("unable to list VolumeSnapshotContents: %w", err)
+	}
+	contents := map[string]*unstructured.Unstructured{}
+	for j := range contentList.Items {
+		content := &contentList.Items[j]
+		if driver, _, _ := unstructured.NestedString(content.Object, "spec", "driver"); driver == i.driverName {
+			contents[content.GetName()] = content
+		}
+	}
+
+	snapshotList, err := i.dynamicClient.Resource(volumeSnapshotGVR).Namespace(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("unable to list VolumeSnapshots: %w", err)
+	}
+	snapshots := []Snapshot{}
+	for _, vs := range snapshotList.Items {
+		contentName, _, _ := unstructured.NestedString(vs.Object, "status", "boundVolumeSnapshotContentName")
+		content, ok := contents[contentName]
+		if !ok {
+			continue
+		}
+		s := Snapshot{Namespace: vs.GetNamespace(), Name: vs.GetName(), Content: contentName}
+		s.Claim, _, _ = unstructured.NestedString(vs.Object, "spec", "source", "persistentVolumeClaimName")
+		s.Ready, _, _ = unstructured.NestedBool(vs.Object, "status", "readyToUse")
+		s.RestoreSize, _, _ = unstructured.NestedString(vs.Object, "status", "restoreSize")
+		s.CreationTime, _, _ = unstructured.NestedString(vs.Object, "status", "creationTime")
+
+		handle, _, _ := unstructured.NestedString(content.Object, "status", "snapshotHandle")
+		if handle == "" {
+			// pre-provisioned snapshots have their handle in the spec
+			handle, _, _ = unstructured.NestedString(content.Object, "spec", "source", "snapshotHandle")
+		}
+		if handle == "" {
+			s.Error = "VolumeSnapshotContent has no snapshot handle"
+		} else {
+			decodeSnapshotHandle(&s, handle)
+		}
+		snapshots = append(snapshots, s)
+	}
+	sort.Slice(snapshots, func(a, b int) bool {
+		if snapshots[a].Namespace != snapshots[b].Namespace {
+			return snapshots[a].Namespace < snapshots[b].Namespace
+		}
+		return snapshots[a].Name < snapshots[b].Name
+	})
+	return snapshots, nil
+}
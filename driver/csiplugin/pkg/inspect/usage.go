@@ -0,0 +1,69 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package inspect
+
+import (
+	"context"
+	"sort"
+)
+
+// NamespaceUsage is the capacity and fileset usage of the volumes of the
+// claims of a namespace.
+type NamespaceUsage struct {
+	Namespace string `json:"namespace"`
+	Claims    int    `json:"claims"`
+	// Capacity and Used are in bytes, Used only includes the volumes with a
+	// fileset quota
+	Capacity int64 `json:"capacity"`
+	Used     int64 `json:"used"`
+	// Unmetered is the number of volumes without usage, i.e. lightweight
+	// volumes and volumes whose quota could not be read
+	Unmetered int `json:"unmetered"`
+}
+
+// Usage returns the usage of the volumes of the driver by namespace.
+func (i *Inspector) Usage(ctx context.Context) ([]NamespaceUsage, error) {
+	pvs, err := i.volumes(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	usage := map[string]*NamespaceUsage{}
+	for j := range pvs {
+		vol := i.volume(ctx, &pvs[j])
+		u, ok := usage[vol.Namespace]
+		if !ok {
+			u = &NamespaceUsage{Namespace: vol.Namespace}
+			usage[vol.Namespace] = u
+		}
+		u.Claims++
+		u.Capacity += vol.Capacity
+		if vol.Quota != nil {
+			u.Used += vol.Quota.BlockUsage
+		} else {
+			u.Unmetered++
+		}
+	}
+
+	result := make([]NamespaceUsage, 0, len(usage))
+	for _, u := range usage {
+		result = append(result, *u)
+	}
+	sort.Slice(result, func(a, b int) bool {
+		return result[a].Namespace < result[b].Namespace
+	})
+	return result, nil
+}
@@ -0,0 +1,129 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package inspect
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/IBM/ibm-spectrum-scale-csi/driver/csiplugin/pkg/scaleconfig"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Verification is the result of the comparison of a PersistentVolume with
+// its state in IBM Storage Scale.
+type Verification struct {
+	Namespace        string   `json:"namespace"`
+	Claim            string   `json:"claim"`
+	PersistentVolume string   `json:"persistentVolume"`
+	Problems         []string `json:"problems,omitempty"`
+}
+
+// Verify compares the PersistentVolumes of the driver bound to claims in
+// namespace, or in all namespaces if namespace is empty, with IBM Storage
+// Scale. If claims are given, only their volumes are verified.
+func (i *Inspector) Verify(ctx context.Context, namespace string, claims []string) ([]Verification, error) {
+	pvs, err := i.volumes(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+	selected := map[string]bool{}
+	for _, claim := range claims {
+		selected[claim] = true
+	}
+
+	result := []Verification{}
+	for j := range pvs {
+		pv := &pvs[j]
+		if len(selected) > 0 && !selected[pv.Spec.ClaimRef.Name] {
+			continue
+		}
+		vol := i.volume(ctx, pv)
+		v := Verification{Namespace: vol.Namespace, Claim: vol.Claim, PersistentVolume: vol.PersistentVolume, Problems: vol.Errors}
+		if len(vol.Errors) == 0 {
+			v.Problems = i.verify(ctx, pv, vol)
+		}
+		result = append(result, v)
+	}
+	return result, nil
+}
+
+// verify checks the filesets, link path and quota of a volume which was
+// resolved without errors.
+func (i *Inspector) verify(ctx context.Context, pv *corev1.PersistentVolume, vol *Volume) []string {
+	problems := []string{}
+	handle := vol.Handle
+	conn, err := i.conn(handle.ClusterID)
+	if err != nil {
+		return append(problems, err.Error())
+	}
+	if handle.VolumeType == shallowCopyVolume {
+		return problems
+	}
+
+	// the path of the volume handle is below the mount point of the primary
+	// cluster, legacy volume handles contain the path of a symlink instead
+	relPath := ""
+	if handle.VolumeType != "" && handle.Path != "" {
+		if relPath, err = i.relativePath(ctx, scaleconfig.Primary, handle.Filesystem, handle.Path); err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+
+	if handle.StorageClassType == advancedClass && handle.ConsistencyGroup != "" {
+		if _, err := conn.GetFileSetResponseFromName(ctx, vol.Filesystem, handle.ConsistencyGroup); err != nil {
+			problems = append(problems, fmt.Sprintf("consistency group fileset %s: %v", handle.ConsistencyGroup, err))
+		}
+	}
+
+	if handle.Fileset == "" {
+		if relPath == "" || handle.VolumeType != lightweightVolume {
+			return problems
+		}
+		found, err := conn.CheckIfFileDirPresent(ctx, vol.Filesystem, relPath)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("unable to check directory %s: %v", relPath, err))
+		} else if !found {
+			problems = append(problems, fmt.Sprintf("directory %s not found in filesystem %s", relPath, vol.Filesystem))
+		}
+		return problems
+	}
+
+	fileset, err := conn.GetFileSetResponseFromName(ctx, vol.Filesystem, handle.Fileset)
+	if err != nil {
+		return append(problems, fmt.Sprintf("fileset %s: %v", handle.Fileset, err))
+	}
+	linkPath := fileset.Config.Path
+	if linkPath == "" || linkPath == "--" {
+		problems = append(problems, fmt.Sprintf("fileset %s is not linked", handle.Fileset))
+	} else if relPath != "" {
+		relLinkPath, err := i.relativePath(ctx, handle.ClusterID, vol.Filesystem, linkPath)
+		if err != nil {
+			problems = append(problems, err.Error())
+		} else if relPath != relLinkPath && !strings.HasPrefix(relPath, relLinkPath+"/") {
+			// the volume path is the link path or a data directory below it
+			problems = append(problems, fmt.Sprintf("fileset %s is linked at %s, the volume path %s is not below it", handle.Fileset, linkPath, handle.Path))
+		}
+	}
+
+	if vol.Quota != nil && vol.Quota.BlockLimit > 0 && vol.Quota.BlockLimit < vol.Capacity {
+		capacity := pv.Spec.Capacity[corev1.ResourceStorage]
+		problems = append(problems, fmt.Sprintf("quota of fileset %s is %d bytes, below the capacity %s of the PersistentVolume", handle.Fileset, vol.Quota.BlockLimit, capacity.String()))
+	}
+	return problems
+}
@@ -0,0 +1,300 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package journal records the intent and the completed steps of multi-step
+// volume operations on disk, so that operations interrupted by a restart of
+// the driver can be rolled forward or rolled back.
+package journal
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Operation is the kind of a journaled volume operation.
+type Operation string
+
+const (
+	CreateVolume Operation = "CreateVolume"
+	DeleteVolume Operation = "DeleteVolume"
+)
+
+// CopyJobKind is the kind of data copied by an asynchronous copy job.
+type CopyJobKind string
+
+const (
+	SnapshotCopy CopyJobKind = "SnapshotCopy"
+	VolumeCopy   CopyJobKind = "VolumeCopy"
+)
+
+const recordSuffix = ".json"
+
+// CopyJob is an asynchronous IBM Storage Scale GUI job copying the content of
+// a snapshot or volume into the volume of an operation.
+type CopyJob struct {
+	Kind CopyJobKind `json:"kind"`
+	// ClusterID is the cluster whose GUI runs the job
+	ClusterID  string `json:"clusterId"`
+	JobID      uint64 `json:"jobId"`
+	StatusCode int    `json:"statusCode"`
+	// VolumeID is the volume ID returned once the copy completed
+	VolumeID  string    `json:"volumeId"`
+	StartTime time.Time `json:"startTime"`
+}
+
+// Record is the journal entry of one operation.
+type Record struct {
+	// ID identifies the operation, e.g. the name of the volume
+	ID        string    `json:"id"`
+	Operation Operation `json:"operation"`
+	ClusterID string    `json:"clusterId,omitempty"`
+	// Filesystem is the filesystem name on the cluster of ClusterID
+	Filesystem string `json:"filesystem,omitempty"`
+	Fileset    string `json:"fileset,omitempty"`
+	// Path is the path of the volume directory relative to the filesystem root
+	Path     string `json:"path,omitempty"`
+	VolumeID string `json:"volumeId,omitempty"`
+	// PVCName and PVCNamespace identify the claim of a CreateVolume, when the
+	// provisioner passes them
+	PVCName      string `json:"pvcName,omitempty"`
+	PVCNamespace string `json:"pvcNamespace,omitempty"`
+	// Steps are the completed steps of the operation in order
+	Steps []string `json:"steps,omitempty"`
+	// CopyJob is the running copy job of a CreateVolume with content source
+	CopyJob    *CopyJob  `json:"copyJob,omitempty"`
+	StartTime  time.Time `json:"startTime"`
+	UpdateTime time.Time `json:"updateTime"`
+}
+
+// HasStep reports whether step was recorded as completed.
+func (r Record) HasStep(step string) bool {
+	for _, s := range r.Steps {
+		if s == step {
+			return true
+		}
+	}
+	return false
+}
+
+// Journal stores one file per pending operation in a directory. A record is
+// written to a temporary file which replaces the record file, so that a crash
+// never leaves a partially written record.
+type Journal struct {
+	dir  string
+	lock sync.Mutex
+}
+
+// Open returns the journal stored in dir, the directory is created if it does
+// not exist.
+func Open(dir string) (*Journal, error) {
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return nil, fmt.Errorf("unable to create journal directory %s: %w", dir, err)
+	}
+	return &Journal{dir: dir}, nil
+}
+
+// Begin records the start of an operation. If a record with the same ID and
+// operation is pending, e.g. for a retried request, its steps and copy job are
+// kept and the remaining fields are updated from rec.
+func (j *Journal) Begin(rec Record) error {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	now := time.Now().UTC()
+	existing, err := j.read(rec.ID)
+	if err != nil {
+		return err
+	}
+	if existing != nil && existing.Operation == rec.Operation {
+		rec.Steps = existing.Steps
+		rec.CopyJob = existing.CopyJob
+		rec.StartTime = existing.StartTime
+	} else {
+		rec.Steps = nil
+		rec.StartTime = now
+	}
+	rec.UpdateTime = now
+	return j.write(rec)
+}
+
+// Update applies update to the pending record of id, e.g. to store the path
+// of a volume once it is known.
+func (j *Journal) Update(id string, update func(rec *Record)) error {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	rec, err := j.read(id)
+	if err != nil {
+		return err
+	}
+	if rec == nil {
+		return fmt.Errorf("no pending operation %s in journal", id)
+	}
+	update(rec)
+	rec.UpdateTime = time.Now().UTC()
+	return j.write(*rec)
+}
+
+// Get returns the pending record of id.
+func (j *Journal) Get(id string) (Record, bool, error) {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	rec, err := j.read(id)
+	if err != nil || rec == nil {
+		return Record{}, false, err
+	}
+	return *rec, true, nil
+}
+
+// Step records step as completed for the pending operation of id.
+func (j *Journal) Step(id, step string) error {
+	return j.Update(id, func(rec *Record) {
+		if !rec.HasStep(step) {
+			rec.Steps = append(rec.Steps, step)
+		}
+	})
+}
+
+// SetCopyJob records the copy job of the pending operation of id, nil clears
+// the copy job.
+func (j *Journal) SetCopyJob(id string, job *CopyJob) error {
+	return j.Update(id, func(rec *Record) {
+		rec.CopyJob = job
+	})
+}
+
+// Complete removes the record of id from the journal.
+func (j *Journal) Complete(id string) error {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	if err := os.Remove(j.recordPath(id)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("unable to remove journal record of %s: %w", id, err)
+	}
+	if err := j.syncDir(); err != nil {
+		return fmt.Errorf("unable to remove journal record of %s: %w", id, err)
+	}
+	return nil
+}
+
+// Pending returns the records of all operations which were not completed.
+func (j *Journal) Pending() ([]Record, error) {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	entries, err := os.ReadDir(j.dir)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read journal directory %s: %w", j.dir, err)
+	}
+	records := []Record{}
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), recordSuffix) {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(j.dir, entry.Name())) // #nosec G304 file is below the journal directory
+		if err != nil {
+			return nil, fmt.Errorf("unable to read journal record %s: %w", entry.Name(), err)
+		}
+		rec := Record{}
+		if err := json.Unmarshal(data, &rec); err != nil {
+			return nil, fmt.Errorf("unable to parse journal record %s: %w", entry.Name(), err)
+		}
+		records = append(records, rec)
+	}
+	return records, nil
+}
+
+func (j *Journal) read(id string) (*Record, error) {
+	data, err := os.ReadFile(j.recordPath(id))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("unable to read journal record of %s: %w", id, err)
+	}
+	rec := &Record{}
+	if err := json.Unmarshal(data, rec); err != nil {
+		return nil, fmt.Errorf("unable to parse journal record of %s: %w", id, err)
+	}
+	return rec, nil
+}
+
+// write replaces the record atomically, the record and the directory entry
+// are synced so that a completed step is not lost on a crash of the node.
+func (j *Journal) write(rec Record) error {
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	path := j.recordPath(rec.ID)
+	tmp := path + ".tmp"
+	if err := writeFileSync(tmp, data); err != nil {
+		return fmt.Errorf("unable to write journal record of %s: %w", rec.ID, err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("unable to write journal record of %s: %w", rec.ID, err)
+	}
+	if err := j.syncDir(); err != nil {
+		return fmt.Errorf("unable to write journal record of %s: %w", rec.ID, err)
+	}
+	return nil
+}
+
+func writeFileSync(name string, data []byte) error {
+	f, err := os.OpenFile(name, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600) // #nosec G304 file is below the journal directory
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// syncDir persists the renames and removals of records in the journal
+// directory.
+func (j *Journal) syncDir() error {
+	d, err := os.Open(j.dir)
+	if err != nil {
+		return err
+	}
+	if err := d.Sync(); err != nil {
+		_ = d.Close()
+		return err
+	}
+	return d.Close()
+}
+
+// recordPath returns the file of a record, the ID is hashed as it may contain
+// characters which are not valid in file names.
+func (j *Journal) recordPath(id string) string {
+	sum := sha256.Sum256([]byte(id))
+	return filepath.Join(j.dir, hex.EncodeToString(sum[:])+recordSuffix)
+}
@@ -0,0 +1,154 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package journal
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func openJournal(t *testing.T) (*Journal, string) {
+	t.Helper()
+	dir := filepath.Join(t.TempDir(), "journal")
+	j, err := Open(dir)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	return j, dir
+}
+
+func TestJournalRecord(t *testing.T) {
+	j, dir := openJournal(t)
+
+	rec := Record{ID: "pvc-1", Operation: CreateVolume, ClusterID: "123", Filesystem: "gpfs0", Fileset: "pvc-1", PVCName: "data", PVCNamespace: "ns"}
+	if err := j.Begin(rec); err != nil {
+		t.Fatalf("Begin() error = %v", err)
+	}
+	for _, step := range []string{"FilesetCreated", "FilesetLinked", "FilesetCreated"} {
+		if err := j.Step("pvc-1", step); err != nil {
+			t.Fatalf("Step(%s) error = %v", step, err)
+		}
+	}
+	job := &CopyJob{Kind: SnapshotCopy, ClusterID: "123", JobID: 42, StatusCode: 202, VolumeID: "vol-1"}
+	if err := j.SetCopyJob("pvc-1", job); err != nil {
+		t.Fatalf("SetCopyJob() error = %v", err)
+	}
+
+	// the record survives a restart of the driver
+	reopened, err := Open(dir)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	got, found, err := reopened.Get("pvc-1")
+	if err != nil || !found {
+		t.Fatalf("Get() = %v, %v, want the record", found, err)
+	}
+	if want := []string{"FilesetCreated", "FilesetLinked"}; !reflect.DeepEqual(got.Steps, want) {
+		t.Errorf("Steps = %v, want %v", got.Steps, want)
+	}
+	if got.CopyJob == nil || got.CopyJob.JobID != 42 || got.CopyJob.Kind != SnapshotCopy {
+		t.Errorf("CopyJob = %+v, want job 42", got.CopyJob)
+	}
+	if got.PVCName != "data" || got.PVCNamespace != "ns" {
+		t.Errorf("claim = %s/%s, want ns/data", got.PVCNamespace, got.PVCName)
+	}
+	if !got.HasStep("FilesetLinked") || got.HasStep("QuotaSet") {
+		t.Errorf("HasStep() does not match Steps %v", got.Steps)
+	}
+}
+
+func TestJournalBeginRetry(t *testing.T) {
+	j, _ := openJournal(t)
+
+	if err := j.Begin(Record{ID: "pvc-1", Operation: CreateVolume, Filesystem: "gpfs0"}); err != nil {
+		t.Fatalf("Begin() error = %v", err)
+	}
+	if err := j.Step("pvc-1", "FilesetCreated"); err != nil {
+		t.Fatalf("Step() error = %v", err)
+	}
+	first, _, _ := j.Get("pvc-1")
+
+	// a retried request keeps the completed steps
+	if err := j.Begin(Record{ID: "pvc-1", Operation: CreateVolume, Filesystem: "gpfs1"}); err != nil {
+		t.Fatalf("Begin() error = %v", err)
+	}
+	got, _, _ := j.Get("pvc-1")
+	if !got.HasStep("FilesetCreated") || got.Filesystem != "gpfs1" || !got.StartTime.Equal(first.StartTime) {
+		t.Errorf("retried record = %+v, want the steps and start time of %+v", got, first)
+	}
+
+	// another operation of the same ID starts over
+	if err := j.Begin(Record{ID: "pvc-1", Operation: DeleteVolume}); err != nil {
+		t.Fatalf("Begin() error = %v", err)
+	}
+	got, _, _ = j.Get("pvc-1")
+	if len(got.Steps) != 0 || got.Operation != DeleteVolume {
+		t.Errorf("record = %+v, want a DeleteVolume without steps", got)
+	}
+}
+
+func TestJournalPendingAndComplete(t *testing.T) {
+	j, dir := openJournal(t)
+
+	for _, rec := range []Record{
+		{ID: "pvc-1", Operation: CreateVolume},
+		{ID: "0;2;123;uuid;;pvc-2;/ibm/gpfs0/pvc-2/pvc-2-data", Operation: DeleteVolume},
+	} {
+		if err := j.Begin(rec); err != nil {
+			t.Fatalf("Begin() error = %v", err)
+		}
+	}
+	// leftovers of an interrupted write are ignored
+	if err := os.WriteFile(filepath.Join(dir, "record.json.tmp"), []byte("{"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	pending, err := j.Pending()
+	if err != nil {
+		t.Fatalf("Pending() error = %v", err)
+	}
+	if len(pending) != 2 {
+		t.Fatalf("Pending() = %d records, want 2", len(pending))
+	}
+
+	if err := j.Complete("pvc-1"); err != nil {
+		t.Fatalf("Complete() error = %v", err)
+	}
+	// completing twice is not an error
+	if err := j.Complete("pvc-1"); err != nil {
+		t.Fatalf("Complete() error = %v", err)
+	}
+	pending, err = j.Pending()
+	if err != nil {
+		t.Fatalf("Pending() error = %v", err)
+	}
+	if len(pending) != 1 || pending[0].Operation != DeleteVolume {
+		t.Errorf("Pending() = %+v, want the DeleteVolume", pending)
+	}
+	if _, found, _ := j.Get("pvc-1"); found {
+		t.Errorf("Get() found a completed record")
+	}
+}
+
+func TestJournalUpdateMissing(t *testing.T) {
+	j, _ := openJournal(t)
+	if err := j.Step("pvc-1", "FilesetCreated"); err == nil {
+		t.Errorf("Step() of a missing record succeeded")
+	}
+}
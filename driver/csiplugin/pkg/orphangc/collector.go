@@ -0,0 +1,424 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package orphangc finds filesets and directories created by IBM Storage Scale
+// CSI driver which are not referenced by any PersistentVolume anymore, left
+// behind by failed or interrupted CreateVolume calls and manually deleted
+// PersistentVolumes, and optionally deletes them.
+package orphangc
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/IBM/ibm-spectrum-scale-csi/driver/csiplugin/connectors"
+	"github.com/IBM/ibm-spectrum-scale-csi/driver/csiplugin/pkg/consistencygroup"
+	"github.com/IBM/ibm-spectrum-scale-csi/driver/csiplugin/pkg/scaleconfig"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Kind is the kind of an orphaned backend object.
+type Kind string
+
+const (
+	// KindFileset is a fileset created for a volume.
+	KindFileset Kind = "Fileset"
+	// KindDirectory is the directory of a lightweight volume.
+	KindDirectory Kind = "Directory"
+	// KindSnapshotMetadata is a snapshot metadata directory of a fileset
+	// whose snapshot does not exist anymore.
+	KindSnapshotMetadata Kind = "SnapshotMetadata"
+)
+
+const (
+	csiMetadataDir     = ".csimetadata"
+	filesetUnlinked    = "--"
+	attrVolBackendFs   = "volBackendFs"
+	attrVolDirBasePath = "volDirBasePath"
+
+	// createdFormat is the format of the creation time of filesets returned by
+	// the GUI, the milliseconds are replaced by the timezone offset of the cluster
+	createdFormat = "2006-01-02 15:04:05-07:00"
+)
+
+// Options configures a garbage collection run.
+type Options struct {
+	DriverName string
+	// GracePeriod is the minimum age of an orphan before it is deleted, it
+	// protects volumes whose CreateVolume call is still in progress.
+	GracePeriod time.Duration
+	// Delete deletes the orphans older than the grace period, otherwise they
+	// are only reported.
+	Delete bool
+	// LocalMounts enables the scan of lightweight volume directories and
+	// snapshot metadata directories, which can not be listed through the GUI,
+	// through the filesystems mounted on this node at the mount points of the
+	// primary cluster.
+	LocalMounts bool
+	// VolumeNamePrefix is the prefix of the names of the volumes created by the
+	// driver, directories with other names are never reported.
+	VolumeNamePrefix string
+}
+
+// Collector finds orphaned filesets and directories.
+type Collector struct {
+	client kubernetes.Interface
+	conns  map[string]connectors.SpectrumScaleConnector
+	opts   Options
+}
+
+// NewCollector returns a Collector. conns are the connectors returned by
+// scaleconfig.NewConnectors.
+func NewCollector(client kubernetes.Interface, conns map[string]connectors.SpectrumScaleConnector, opts Options) *Collector {
+	return &Collector{client: client, conns: conns, opts: opts}
+}
+
+// references are the backend objects used by the PersistentVolumes of the driver.
+type references struct {
+	// filesets are keyed by clusterID;filesystemUUID;filesetName
+	filesets map[string]bool
+	paths    map[string]bool
+}
+
+// filesystem is a filesystem of a cluster and its mount point on the primary cluster.
+type filesystem struct {
+	name            string
+	uuid            string
+	mountPoint      string
+	localMountPoint string
+}
+
+func filesetKey(clusterID, fsUUID, fileset string) string {
+	return strings.Join([]string{clusterID, fsUUID, fileset}, ";")
+}
+
+// Run scans all clusters for orphans and deletes them if requested.
+func (c *Collector) Run(ctx context.Context) (*Report, error) {
+	report := &Report{Delete: c.opts.Delete, GracePeriod: c.opts.GracePeriod.String(), StartTime: time.Now().UTC()}
+
+	refs, err := c.references(ctx)
+	if err != nil {
+		return nil, err
+	}
+	localMounts, err := c.localMountPoints(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	clusterIDs := []string{}
+	for id := range c.conns {
+		if id != scaleconfig.Primary {
+			clusterIDs = append(clusterIDs, id)
+		}
+	}
+	sort.Strings(clusterIDs)
+	for _, clusterID := range clusterIDs {
+		if err := c.scanCluster(ctx, clusterID, refs, localMounts, report); err != nil {
+			return nil, err
+		}
+	}
+	if c.opts.LocalMounts {
+		if err := c.scanVolumeDirectories(ctx, refs, report); err != nil {
+			return nil, err
+		}
+	}
+
+	report.CompletionTime = time.Now().UTC()
+	return report, nil
+}
+
+// references collects the filesets and paths of all PersistentVolumes of the driver.
+func (c *Collector) references(ctx context.Context) (*references, error) {
+	pvs, err := c.client.CoreV1().PersistentVolumes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("unable to list PersistentVolumes: %w", err)
+	}
+	refs := &references{filesets: map[string]bool{}, paths: map[string]bool{}}
+	for _, pv := range pvs.Items {
+		if pv.Spec.CSI == nil || pv.Spec.CSI.Driver != c.opts.DriverName {
+			continue
+		}
+		handle, err := consistencygroup.GetVolumeHandle(pv.Spec.CSI)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse volume handle of PersistentVolume %s: %w", pv.Name, err)
+		}
+		refs.paths[path.Clean(handle.FilesetLinkPath)] = true
+		if handle.FilesetName != "" {
+			refs.filesets[filesetKey(handle.ClusterID, handle.FilesystemUID, handle.FilesetName)] = true
+		}
+		if handle.StorageClassType == consistencygroup.ConsistencyGroupStorageClass && handle.ConsistencyGroup != "" {
+			refs.filesets[filesetKey(handle.ClusterID, handle.FilesystemUID, handle.ConsistencyGroup)] = true
+		}
+	}
+	return refs, nil
+}
+
+// localMountPoints returns the mount points of the filesystems on the primary
+// cluster keyed by filesystem UUID.
+func (c *Collector) localMountPoints(ctx context.Context) (map[string]string, error) {
+	mounts := map[string]string{}
+	if !c.opts.LocalMounts {
+		return mounts, nil
+	}
+	primaryConn := c.conns[scaleconfig.Primary]
+	filesystems, err := primaryConn.ListFilesystems(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list filesystems of primary cluster: %w", err)
+	}
+	for name, mountPoint := range filesystems {
+		uuid, err := primaryConn.GetFsUid(ctx, name)
+		if err != nil {
+			return nil, fmt.Errorf("unable to get UUID of filesystem %s of primary cluster: %w", name, err)
+		}
+		mounts[uuid] = mountPoint
+	}
+	return mounts, nil
+}
+
+// scanCluster reports the orphaned filesets and snapshot metadata directories
+// of all filesystems of a cluster.
+func (c *Collector) scanCluster(ctx context.Context, clusterID string, refs *references, localMounts map[string]string, report *Report) error {
+	conn := c.conns[clusterID]
+	filesystems, err := conn.ListFilesystems(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to list filesystems of cluster %s: %w", clusterID, err)
+	}
+	offset, err := conn.GetTimeZoneOffset(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to get timezone of cluster %s: %w", clusterID, err)
+	}
+	// for GMT, REST API returns Z instead of 00:00
+	if offset == "Z" {
+		offset = "+00:00"
+	}
+
+	names := []string{}
+	for name := range filesystems {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fs := filesystem{name: name, mountPoint: filesystems[name]}
+		if fs.uuid, err = conn.GetFsUid(ctx, name); err != nil {
+			return fmt.Errorf("unable to get UUID of filesystem %s of cluster %s: %w", name, clusterID, err)
+		}
+		fs.localMountPoint = localMounts[fs.uuid]
+
+		filesets, err := conn.ListFilesets(ctx, name)
+		if err != nil {
+			return fmt.Errorf("unable to list filesets of filesystem %s of cluster %s: %w", name, clusterID, err)
+		}
+		for _, fileset := range filesets {
+			if !strings.Contains(fileset.Config.Comment, connectors.FilesetComment) {
+				continue
+			}
+			if fs.localMountPoint != "" && fileset.Config.IsInodeSpaceOwner {
+				if err := c.scanSnapshotMetadata(ctx, conn, clusterID, fs, fileset, report); err != nil {
+					return err
+				}
+			}
+			if refs.filesets[filesetKey(clusterID, fs.uuid, fileset.FilesetName)] || !isVolumeFileset(fileset) {
+				continue
+			}
+			orphan := Orphan{
+				Kind:       KindFileset,
+				ClusterID:  clusterID,
+				Filesystem: name,
+				Name:       fileset.FilesetName,
+				Path:       fileset.Config.Path,
+				Comment:    fileset.Config.Comment,
+			}
+			created, err := time.Parse(createdFormat, strings.Replace(fileset.Config.Created, ",000", offset, 1))
+			if err == nil {
+				orphan.Created = created.UTC()
+			}
+			c.collect(ctx, conn, orphan, report)
+		}
+	}
+	return nil
+}
+
+// isVolumeFileset returns true for the filesets the driver created for one
+// volume. Filesets shared by several volumes, like the independent filesets
+// of consistency groups and the primary fileset, carry the comment without
+// the PersistentVolumeClaim and are not considered, neither are the unused
+// filesets of warm pools. AFM-DR secondary filesets are referenced through
+// their primary fileset. Adopted filesets hold data created outside of the
+// driver and are never collected.
+func isVolumeFileset(fileset connectors.Fileset_v2) bool {
+	if fileset.Config.Comment == connectors.FilesetComment ||
+		strings.HasPrefix(fileset.Config.Comment, connectors.WarmPoolFilesetCommentPrefix) ||
+		strings.HasPrefix(fileset.Config.Comment, connectors.AdoptedFilesetCommentPrefix) {
+		return false
+	}
+	return fileset.AFM.AFMMode != connectors.AfmModeSecondary
+}
+
+// scanSnapshotMetadata reports the snapshot metadata directories of an
+// independent fileset whose snapshot does not exist anymore.
+func (c *Collector) scanSnapshotMetadata(ctx context.Context, conn connectors.SpectrumScaleConnector, clusterID string, fs filesystem, fileset connectors.Fileset_v2, report *Report) error {
+	if fileset.Config.Path == "" || fileset.Config.Path == filesetUnlinked {
+		return nil
+	}
+	relativePath := strings.Trim(strings.Replace(fileset.Config.Path, fs.mountPoint, "", 1), "!/")
+	entries, err := os.ReadDir(filepath.Join(fs.localMountPoint, relativePath, csiMetadataDir))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("unable to read snapshot metadata directory of fileset %s: %w", fileset.FilesetName, err)
+	}
+	if len(entries) == 0 {
+		return nil
+	}
+
+	snapshots, err := conn.ListFilesetSnapshots(ctx, fs.name, fileset.FilesetName)
+	if err != nil {
+		return fmt.Errorf("unable to list snapshots of fileset %s in filesystem %s: %w", fileset.FilesetName, fs.name, err)
+	}
+	existing := map[string]bool{}
+	for _, snapshot := range snapshots {
+		existing[snapshot.SnapshotName] = true
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() || existing[entry.Name()] {
+			continue
+		}
+		orphan := Orphan{
+			Kind:       KindSnapshotMetadata,
+			ClusterID:  clusterID,
+			Filesystem: fs.name,
+			Name:       path.Join(relativePath, csiMetadataDir, entry.Name()),
+			Path:       path.Join(fileset.Config.Path, csiMetadataDir, entry.Name()),
+		}
+		if info, err := entry.Info(); err == nil {
+			orphan.Created = info.ModTime().UTC()
+		}
+		c.collect(ctx, conn, orphan, report)
+	}
+	return nil
+}
+
+// scanVolumeDirectories reports the directories of lightweight volumes below
+// the volDirBasePath of the StorageClasses of the driver which are not
+// referenced by any PersistentVolume.
+func (c *Collector) scanVolumeDirectories(ctx context.Context, refs *references, report *Report) error {
+	classes, err := c.client.StorageV1().StorageClasses().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("unable to list StorageClasses: %w", err)
+	}
+	primaryConn := c.conns[scaleconfig.Primary]
+	primaryID, err := primaryConn.GetClusterId(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to get cluster ID of primary cluster: %w", err)
+	}
+
+	scanned := map[string]bool{}
+	for _, class := range classes.Items {
+		fsName := class.Parameters[attrVolBackendFs]
+		baseDir := strings.Trim(class.Parameters[attrVolDirBasePath], "/")
+		if class.Provisioner != c.opts.DriverName || fsName == "" || baseDir == "" || scanned[fsName+"/"+baseDir] {
+			continue
+		}
+		scanned[fsName+"/"+baseDir] = true
+
+		mountPoint, err := primaryConn.GetFilesystemMountpoint(ctx, fsName)
+		if err != nil {
+			return fmt.Errorf("unable to get mount point of filesystem %s: %w", fsName, err)
+		}
+		entries, err := os.ReadDir(filepath.Join(mountPoint, baseDir))
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return fmt.Errorf("unable to read directory %s of filesystem %s: %w", baseDir, fsName, err)
+		}
+		for _, entry := range entries {
+			volumePath := path.Join(mountPoint, baseDir, entry.Name())
+			if !entry.IsDir() || !strings.HasPrefix(entry.Name(), c.opts.VolumeNamePrefix) || refs.paths[volumePath] {
+				continue
+			}
+			orphan := Orphan{
+				Kind:       KindDirectory,
+				ClusterID:  primaryID,
+				Filesystem: fsName,
+				Name:       path.Join(baseDir, entry.Name()),
+				Path:       volumePath,
+			}
+			if info, err := entry.Info(); err == nil {
+				orphan.Created = info.ModTime().UTC()
+			}
+			c.collect(ctx, primaryConn, orphan, report)
+		}
+	}
+	return nil
+}
+
+// collect adds an orphan to the report and deletes it if requested and its
+// grace period is over.
+func (c *Collector) collect(ctx context.Context, conn connectors.SpectrumScaleConnector, orphan Orphan, report *Report) {
+	switch {
+	case !c.opts.Delete:
+	case orphan.Created.IsZero():
+		orphan.Reason = "creation time is unknown"
+	case time.Since(orphan.Created) < c.opts.GracePeriod:
+		orphan.Reason = fmt.Sprintf("grace period ends at %s", orphan.Created.Add(c.opts.GracePeriod).Format(time.RFC3339))
+	default:
+		if err := c.delete(ctx, conn, orphan); err != nil {
+			orphan.Reason = err.Error()
+			orphan.Failed = true
+		} else {
+			orphan.Deleted = true
+		}
+	}
+	report.add(orphan)
+}
+
+func (c *Collector) delete(ctx context.Context, conn connectors.SpectrumScaleConnector, orphan Orphan) error {
+	if orphan.Kind != KindFileset {
+		return conn.DeleteDirectory(ctx, orphan.Filesystem, orphan.Name, false)
+	}
+
+	snapshots, err := conn.ListFilesetSnapshots(ctx, orphan.Filesystem, orphan.Name)
+	if err != nil {
+		return fmt.Errorf("unable to list snapshots: %w", err)
+	}
+	if len(snapshots) > 0 {
+		return fmt.Errorf("fileset contains %d snapshots", len(snapshots))
+	}
+	fileset, err := conn.ListFileset(ctx, orphan.Filesystem, orphan.Name)
+	if err != nil {
+		return fmt.Errorf("unable to list fileset: %w", err)
+	}
+	if fileset.Config.IsInodeSpaceOwner {
+		// dependent filesets share the inode space of their independent fileset
+		filesets, err := conn.GetFilesetsInodeSpace(ctx, orphan.Filesystem, fileset.Config.InodeSpace)
+		if err != nil {
+			return fmt.Errorf("unable to list filesets of inode space %d: %w", fileset.Config.InodeSpace, err)
+		}
+		if len(filesets) > 1 {
+			return fmt.Errorf("fileset contains %d dependent filesets", len(filesets)-1)
+		}
+	}
+	return conn.DeleteFileset(ctx, orphan.Filesystem, orphan.Name)
+}
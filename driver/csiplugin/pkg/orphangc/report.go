@@ -0,0 +1,133 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package orphangc
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Orphan is a fileset or directory not referenced by any PersistentVolume.
+type Orphan struct {
+	Kind       Kind   `json:"kind"`
+	ClusterID  string `json:"clusterId"`
+	Filesystem string `json:"filesystem"`
+	// Name is the fileset name, or the directory path relative to the filesystem root
+	Name    string    `json:"name"`
+	Path    string    `json:"path,omitempty"`
+	Comment string    `json:"comment,omitempty"`
+	Created time.Time `json:"created"`
+	Deleted bool      `json:"deleted"`
+	Failed  bool      `json:"failed,omitempty"`
+	// Reason explains why an orphan was not deleted
+	Reason string `json:"reason,omitempty"`
+}
+
+// Report is the machine-readable result of a garbage collection run.
+type Report struct {
+	Delete         bool      `json:"delete"`
+	GracePeriod    string    `json:"gracePeriod"`
+	StartTime      time.Time `json:"startTime"`
+	CompletionTime time.Time `json:"completionTime"`
+	Summary        Summary   `json:"summary"`
+	Orphans        []Orphan  `json:"orphans"`
+}
+
+// Summary counts the orphans of a run.
+type Summary struct {
+	Found   map[Kind]int `json:"found"`
+	Deleted int          `json:"deleted"`
+	Failed  int          `json:"failed"`
+}
+
+func (r *Report) add(orphan Orphan) {
+	if r.Summary.Found == nil {
+		r.Summary.Found = map[Kind]int{}
+	}
+	r.Orphans = append(r.Orphans, orphan)
+	r.Summary.Found[orphan.Kind]++
+	if orphan.Deleted {
+		r.Summary.Deleted++
+	}
+	if orphan.Failed {
+		r.Summary.Failed++
+	}
+}
+
+// Write stores the report as JSON at path.
+func (r *Report) Write(path string) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFile(path, data)
+}
+
+// Encode writes the report as JSON to w.
+func (r *Report) Encode(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r)
+}
+
+// WriteMetrics stores the number of orphans per kind in the Prometheus text
+// format at path, for the textfile collector of the node exporter.
+func (r *Report) WriteMetrics(path string) error {
+	var b strings.Builder
+	b.WriteString("# HELP ibm_spectrum_scale_csi_orphans Number of backend objects not referenced by any PersistentVolume.\n")
+	b.WriteString("# TYPE ibm_spectrum_scale_csi_orphans gauge\n")
+	for _, kind := range []Kind{KindFileset, KindDirectory, KindSnapshotMetadata} {
+		fmt.Fprintf(&b, "ibm_spectrum_scale_csi_orphans{kind=%q} %d\n", kind, r.Summary.Found[kind]-r.deleted(kind))
+	}
+	b.WriteString("# HELP ibm_spectrum_scale_csi_orphans_deleted Number of orphans deleted by the last run.\n")
+	b.WriteString("# TYPE ibm_spectrum_scale_csi_orphans_deleted gauge\n")
+	fmt.Fprintf(&b, "ibm_spectrum_scale_csi_orphans_deleted %d\n", r.Summary.Deleted)
+	b.WriteString("# HELP ibm_spectrum_scale_csi_orphans_last_run_timestamp_seconds Completion time of the last run.\n")
+	b.WriteString("# TYPE ibm_spectrum_scale_csi_orphans_last_run_timestamp_seconds gauge\n")
+	fmt.Fprintf(&b, "ibm_spectrum_scale_csi_orphans_last_run_timestamp_seconds %d\n", r.CompletionTime.Unix())
+	return writeFile(path, []byte(b.String()))
+}
+
+func (r *Report) deleted(kind Kind) int {
+	count := 0
+	for _, orphan := range r.Orphans {
+		if orphan.Kind == kind && orphan.Deleted {
+			count++
+		}
+	}
+	return count
+}
+
+// writeFile replaces the file at path, so that readers never see a partially written file.
+func writeFile(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("unable to write %s: %w", tmp, err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("unable to write %s: %w", path, err)
+	}
+	return nil
+}
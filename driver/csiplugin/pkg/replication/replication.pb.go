@@ -0,0 +1,833 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v5.29.3
+// source: replication.proto
+
+package replication
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Role int32
+
+const (
+	Role_ROLE_UNSPECIFIED Role = 0
+	Role_PRIMARY          Role = 1
+	Role_SECONDARY        Role = 2
+)
+
+// Enum value maps for Role.
+var (
+	Role_name = map[int32]string{
+		0: "ROLE_UNSPECIFIED",
+		1: "PRIMARY",
+		2: "SECONDARY",
+	}
+	Role_value = map[string]int32{
+		"ROLE_UNSPECIFIED": 0,
+		"PRIMARY":          1,
+		"SECONDARY":        2,
+	}
+)
+
+func (x Role) Enum() *Role {
+	p := new(Role)
+	*p = x
+	return p
+}
+
+func (x Role) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Role) Descriptor() protoreflect.EnumDescriptor {
+	return file_replication_proto_enumTypes[0].Descriptor()
+}
+
+func (Role) Type() protoreflect.EnumType {
+	return &file_replication_proto_enumTypes[0]
+}
+
+func (x Role) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Role.Descriptor instead.
+func (Role) EnumDescriptor() ([]byte, []int) {
+	return file_replication_proto_rawDescGZIP(), []int{0}
+}
+
+type EnableVolumeReplicationRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	VolumeId string                 `protobuf:"bytes,1,opt,name=volume_id,json=volumeId,proto3" json:"volume_id,omitempty"`
+	// Replication parameters, see replicationClusterId, replicationFilesystem,
+	// replicationTargetServer and replicationRPO.
+	Parameters    map[string]string `protobuf:"bytes,2,rep,name=parameters,proto3" json:"parameters,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EnableVolumeReplicationRequest) Reset() {
+	*x = EnableVolumeReplicationRequest{}
+	mi := &file_replication_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnableVolumeReplicationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnableVolumeReplicationRequest) ProtoMessage() {}
+
+func (x *EnableVolumeReplicationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_replication_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnableVolumeReplicationRequest.ProtoReflect.Descriptor instead.
+func (*EnableVolumeReplicationRequest) Descriptor() ([]byte, []int) {
+	return file_replication_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *EnableVolumeReplicationRequest) GetVolumeId() string {
+	if x != nil {
+		return x.VolumeId
+	}
+	return ""
+}
+
+func (x *EnableVolumeReplicationRequest) GetParameters() map[string]string {
+	if x != nil {
+		return x.Parameters
+	}
+	return nil
+}
+
+type EnableVolumeReplicationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EnableVolumeReplicationResponse) Reset() {
+	*x = EnableVolumeReplicationResponse{}
+	mi := &file_replication_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnableVolumeReplicationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnableVolumeReplicationResponse) ProtoMessage() {}
+
+func (x *EnableVolumeReplicationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_replication_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnableVolumeReplicationResponse.ProtoReflect.Descriptor instead.
+func (*EnableVolumeReplicationResponse) Descriptor() ([]byte, []int) {
+	return file_replication_proto_rawDescGZIP(), []int{1}
+}
+
+type DisableVolumeReplicationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VolumeId      string                 `protobuf:"bytes,1,opt,name=volume_id,json=volumeId,proto3" json:"volume_id,omitempty"`
+	Parameters    map[string]string      `protobuf:"bytes,2,rep,name=parameters,proto3" json:"parameters,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DisableVolumeReplicationRequest) Reset() {
+	*x = DisableVolumeReplicationRequest{}
+	mi := &file_replication_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DisableVolumeReplicationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisableVolumeReplicationRequest) ProtoMessage() {}
+
+func (x *DisableVolumeReplicationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_replication_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisableVolumeReplicationRequest.ProtoReflect.Descriptor instead.
+func (*DisableVolumeReplicationRequest) Descriptor() ([]byte, []int) {
+	return file_replication_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *DisableVolumeReplicationRequest) GetVolumeId() string {
+	if x != nil {
+		return x.VolumeId
+	}
+	return ""
+}
+
+func (x *DisableVolumeReplicationRequest) GetParameters() map[string]string {
+	if x != nil {
+		return x.Parameters
+	}
+	return nil
+}
+
+type DisableVolumeReplicationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DisableVolumeReplicationResponse) Reset() {
+	*x = DisableVolumeReplicationResponse{}
+	mi := &file_replication_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DisableVolumeReplicationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisableVolumeReplicationResponse) ProtoMessage() {}
+
+func (x *DisableVolumeReplicationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_replication_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisableVolumeReplicationResponse.ProtoReflect.Descriptor instead.
+func (*DisableVolumeReplicationResponse) Descriptor() ([]byte, []int) {
+	return file_replication_proto_rawDescGZIP(), []int{3}
+}
+
+type PromoteVolumeRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	VolumeId string                 `protobuf:"bytes,1,opt,name=volume_id,json=volumeId,proto3" json:"volume_id,omitempty"`
+	// Fail over without restoring the last consistent RPO snapshot.
+	Force         bool              `protobuf:"varint,2,opt,name=force,proto3" json:"force,omitempty"`
+	Parameters    map[string]string `protobuf:"bytes,3,rep,name=parameters,proto3" json:"parameters,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PromoteVolumeRequest) Reset() {
+	*x = PromoteVolumeRequest{}
+	mi := &file_replication_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PromoteVolumeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PromoteVolumeRequest) ProtoMessage() {}
+
+func (x *PromoteVolumeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_replication_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PromoteVolumeRequest.ProtoReflect.Descriptor instead.
+func (*PromoteVolumeRequest) Descriptor() ([]byte, []int) {
+	return file_replication_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *PromoteVolumeRequest) GetVolumeId() string {
+	if x != nil {
+		return x.VolumeId
+	}
+	return ""
+}
+
+func (x *PromoteVolumeRequest) GetForce() bool {
+	if x != nil {
+		return x.Force
+	}
+	return false
+}
+
+func (x *PromoteVolumeRequest) GetParameters() map[string]string {
+	if x != nil {
+		return x.Parameters
+	}
+	return nil
+}
+
+type PromoteVolumeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PromoteVolumeResponse) Reset() {
+	*x = PromoteVolumeResponse{}
+	mi := &file_replication_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PromoteVolumeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PromoteVolumeResponse) ProtoMessage() {}
+
+func (x *PromoteVolumeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_replication_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PromoteVolumeResponse.ProtoReflect.Descriptor instead.
+func (*PromoteVolumeResponse) Descriptor() ([]byte, []int) {
+	return file_replication_proto_rawDescGZIP(), []int{5}
+}
+
+type DemoteVolumeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VolumeId      string                 `protobuf:"bytes,1,opt,name=volume_id,json=volumeId,proto3" json:"volume_id,omitempty"`
+	Force         bool                   `protobuf:"varint,2,opt,name=force,proto3" json:"force,omitempty"`
+	Parameters    map[string]string      `protobuf:"bytes,3,rep,name=parameters,proto3" json:"parameters,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DemoteVolumeRequest) Reset() {
+	*x = DemoteVolumeRequest{}
+	mi := &file_replication_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DemoteVolumeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DemoteVolumeRequest) ProtoMessage() {}
+
+func (x *DemoteVolumeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_replication_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DemoteVolumeRequest.ProtoReflect.Descriptor instead.
+func (*DemoteVolumeRequest) Descriptor() ([]byte, []int) {
+	return file_replication_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *DemoteVolumeRequest) GetVolumeId() string {
+	if x != nil {
+		return x.VolumeId
+	}
+	return ""
+}
+
+func (x *DemoteVolumeRequest) GetForce() bool {
+	if x != nil {
+		return x.Force
+	}
+	return false
+}
+
+func (x *DemoteVolumeRequest) GetParameters() map[string]string {
+	if x != nil {
+		return x.Parameters
+	}
+	return nil
+}
+
+type DemoteVolumeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DemoteVolumeResponse) Reset() {
+	*x = DemoteVolumeResponse{}
+	mi := &file_replication_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DemoteVolumeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DemoteVolumeResponse) ProtoMessage() {}
+
+func (x *DemoteVolumeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_replication_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DemoteVolumeResponse.ProtoReflect.Descriptor instead.
+func (*DemoteVolumeResponse) Descriptor() ([]byte, []int) {
+	return file_replication_proto_rawDescGZIP(), []int{7}
+}
+
+type ResyncVolumeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VolumeId      string                 `protobuf:"bytes,1,opt,name=volume_id,json=volumeId,proto3" json:"volume_id,omitempty"`
+	Force         bool                   `protobuf:"varint,2,opt,name=force,proto3" json:"force,omitempty"`
+	Parameters    map[string]string      `protobuf:"bytes,3,rep,name=parameters,proto3" json:"parameters,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResyncVolumeRequest) Reset() {
+	*x = ResyncVolumeRequest{}
+	mi := &file_replication_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResyncVolumeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResyncVolumeRequest) ProtoMessage() {}
+
+func (x *ResyncVolumeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_replication_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResyncVolumeRequest.ProtoReflect.Descriptor instead.
+func (*ResyncVolumeRequest) Descriptor() ([]byte, []int) {
+	return file_replication_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ResyncVolumeRequest) GetVolumeId() string {
+	if x != nil {
+		return x.VolumeId
+	}
+	return ""
+}
+
+func (x *ResyncVolumeRequest) GetForce() bool {
+	if x != nil {
+		return x.Force
+	}
+	return false
+}
+
+func (x *ResyncVolumeRequest) GetParameters() map[string]string {
+	if x != nil {
+		return x.Parameters
+	}
+	return nil
+}
+
+type ResyncVolumeResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// True when the volume is in sync with its peer.
+	Ready         bool `protobuf:"varint,1,opt,name=ready,proto3" json:"ready,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResyncVolumeResponse) Reset() {
+	*x = ResyncVolumeResponse{}
+	mi := &file_replication_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResyncVolumeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResyncVolumeResponse) ProtoMessage() {}
+
+func (x *ResyncVolumeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_replication_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResyncVolumeResponse.ProtoReflect.Descriptor instead.
+func (*ResyncVolumeResponse) Descriptor() ([]byte, []int) {
+	return file_replication_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ResyncVolumeResponse) GetReady() bool {
+	if x != nil {
+		return x.Ready
+	}
+	return false
+}
+
+type GetVolumeReplicationInfoRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VolumeId      string                 `protobuf:"bytes,1,opt,name=volume_id,json=volumeId,proto3" json:"volume_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetVolumeReplicationInfoRequest) Reset() {
+	*x = GetVolumeReplicationInfoRequest{}
+	mi := &file_replication_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetVolumeReplicationInfoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVolumeReplicationInfoRequest) ProtoMessage() {}
+
+func (x *GetVolumeReplicationInfoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_replication_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVolumeReplicationInfoRequest.ProtoReflect.Descriptor instead.
+func (*GetVolumeReplicationInfoRequest) Descriptor() ([]byte, []int) {
+	return file_replication_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetVolumeReplicationInfoRequest) GetVolumeId() string {
+	if x != nil {
+		return x.VolumeId
+	}
+	return ""
+}
+
+type GetVolumeReplicationInfoResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Creation time of the last recovery point objective snapshot.
+	LastSyncTime *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=last_sync_time,json=lastSyncTime,proto3" json:"last_sync_time,omitempty"`
+	// Seconds elapsed since last_sync_time.
+	ReplicationLagSeconds int64 `protobuf:"varint,2,opt,name=replication_lag_seconds,json=replicationLagSeconds,proto3" json:"replication_lag_seconds,omitempty"`
+	Role                  Role  `protobuf:"varint,3,opt,name=role,proto3,enum=spectrumscale.csi.replication.v1.Role" json:"role,omitempty"`
+	// AFM state of the fileset, e.g. Active or Dirty.
+	State string `protobuf:"bytes,4,opt,name=state,proto3" json:"state,omitempty"`
+	// AFM target of a primary fileset.
+	Peer          string `protobuf:"bytes,5,opt,name=peer,proto3" json:"peer,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetVolumeReplicationInfoResponse) Reset() {
+	*x = GetVolumeReplicationInfoResponse{}
+	mi := &file_replication_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetVolumeReplicationInfoResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVolumeReplicationInfoResponse) ProtoMessage() {}
+
+func (x *GetVolumeReplicationInfoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_replication_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVolumeReplicationInfoResponse.ProtoReflect.Descriptor instead.
+func (*GetVolumeReplicationInfoResponse) Descriptor() ([]byte, []int) {
+	return file_replication_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetVolumeReplicationInfoResponse) GetLastSyncTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastSyncTime
+	}
+	return nil
+}
+
+func (x *GetVolumeReplicationInfoResponse) GetReplicationLagSeconds() int64 {
+	if x != nil {
+		return x.ReplicationLagSeconds
+	}
+	return 0
+}
+
+func (x *GetVolumeReplicationInfoResponse) GetRole() Role {
+	if x != nil {
+		return x.Role
+	}
+	return Role_ROLE_UNSPECIFIED
+}
+
+func (x *GetVolumeReplicationInfoResponse) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *GetVolumeReplicationInfoResponse) GetPeer() string {
+	if x != nil {
+		return x.Peer
+	}
+	return ""
+}
+
+var File_replication_proto protoreflect.FileDescriptor
+
+const file_replication_proto_rawDesc = "" +
+	"\n" +
+	"\x11replication.proto\x12 spectrumscale.csi.replication.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xee\x01\n" +
+	"\x1eEnableVolumeReplicationRequest\x12\x1b\n" +
+	"\tvolume_id\x18\x01 \x01(\tR\bvolumeId\x12p\n" +
+	"\n" +
+	"parameters\x18\x02 \x03(\v2P.spectrumscale.csi.replication.v1.EnableVolumeReplicationRequest.ParametersEntryR\n" +
+	"parameters\x1a=\n" +
+	"\x0fParametersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"!\n" +
+	"\x1fEnableVolumeReplicationResponse\"\xf0\x01\n" +
+	"\x1fDisableVolumeReplicationRequest\x12\x1b\n" +
+	"\tvolume_id\x18\x01 \x01(\tR\bvolumeId\x12q\n" +
+	"\n" +
+	"parameters\x18\x02 \x03(\v2Q.spectrumscale.csi.replication.v1.DisableVolumeReplicationRequest.ParametersEntryR\n" +
+	"parameters\x1a=\n" +
+	"\x0fParametersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\"\n" +
+	" DisableVolumeReplicationResponse\"\xf0\x01\n" +
+	"\x14PromoteVolumeRequest\x12\x1b\n" +
+	"\tvolume_id\x18\x01 \x01(\tR\bvolumeId\x12\x14\n" +
+	"\x05force\x18\x02 \x01(\bR\x05force\x12f\n" +
+	"\n" +
+	"parameters\x18\x03 \x03(\v2F.spectrumscale.csi.replication.v1.PromoteVolumeRequest.ParametersEntryR\n" +
+	"parameters\x1a=\n" +
+	"\x0fParametersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x17\n" +
+	"\x15PromoteVolumeResponse\"\xee\x01\n" +
+	"\x13DemoteVolumeRequest\x12\x1b\n" +
+	"\tvolume_id\x18\x01 \x01(\tR\bvolumeId\x12\x14\n" +
+	"\x05force\x18\x02 \x01(\bR\x05force\x12e\n" +
+	"\n" +
+	"parameters\x18\x03 \x03(\v2E.spectrumscale.csi.replication.v1.DemoteVolumeRequest.ParametersEntryR\n" +
+	"parameters\x1a=\n" +
+	"\x0fParametersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x16\n" +
+	"\x14DemoteVolumeResponse\"\xee\x01\n" +
+	"\x13ResyncVolumeRequest\x12\x1b\n" +
+	"\tvolume_id\x18\x01 \x01(\tR\bvolumeId\x12\x14\n" +
+	"\x05force\x18\x02 \x01(\bR\x05force\x12e\n" +
+	"\n" +
+	"parameters\x18\x03 \x03(\v2E.spectrumscale.csi.replication.v1.ResyncVolumeRequest.ParametersEntryR\n" +
+	"parameters\x1a=\n" +
+	"\x0fParametersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\",\n" +
+	"\x14ResyncVolumeResponse\x12\x14\n" +
+	"\x05ready\x18\x01 \x01(\bR\x05ready\">\n" +
+	"\x1fGetVolumeReplicationInfoRequest\x12\x1b\n" +
+	"\tvolume_id\x18\x01 \x01(\tR\bvolumeId\"\x82\x02\n" +
+	" GetVolumeReplicationInfoResponse\x12@\n" +
+	"\x0elast_sync_time\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\flastSyncTime\x126\n" +
+	"\x17replication_lag_seconds\x18\x02 \x01(\x03R\x15replicationLagSeconds\x12:\n" +
+	"\x04role\x18\x03 \x01(\x0e2&.spectrumscale.csi.replication.v1.RoleR\x04role\x12\x14\n" +
+	"\x05state\x18\x04 \x01(\tR\x05state\x12\x12\n" +
+	"\x04peer\x18\x05 \x01(\tR\x04peer*8\n" +
+	"\x04Role\x12\x14\n" +
+	"\x10ROLE_UNSPECIFIED\x10\x00\x12\v\n" +
+	"\aPRIMARY\x10\x01\x12\r\n" +
+	"\tSECONDARY\x10\x022\x83\a\n" +
+	"\vReplication\x12\xa0\x01\n" +
+	"\x17EnableVolumeReplication\x12@.spectrumscale.csi.replication.v1.EnableVolumeReplicationRequest\x1aA.spectrumscale.csi.replication.v1.EnableVolumeReplicationResponse\"\x00\x12\xa3\x01\n" +
+	"\x18DisableVolumeReplication\x12A.spectrumscale.csi.replication.v1.DisableVolumeReplicationRequest\x1aB.spectrumscale.csi.replication.v1.DisableVolumeReplicationResponse\"\x00\x12\x82\x01\n" +
+	"\rPromoteVolume\x126.spectrumscale.csi.replication.v1.PromoteVolumeRequest\x1a7.spectrumscale.csi.replication.v1.PromoteVolumeResponse\"\x00\x12\x7f\n" +
+	"\fDemoteVolume\x125.spectrumscale.csi.replication.v1.DemoteVolumeRequest\x1a6.spectrumscale.csi.replication.v1.DemoteVolumeResponse\"\x00\x12\x7f\n" +
+	"\fResyncVolume\x125.spectrumscale.csi.replication.v1.ResyncVolumeRequest\x1a6.spectrumscale.csi.replication.v1.ResyncVolumeResponse\"\x00\x12\xa3\x01\n" +
+	"\x18GetVolumeReplicationInfo\x12A.spectrumscale.csi.replication.v1.GetVolumeReplicationInfoRequest\x1aB.spectrumscale.csi.replication.v1.GetVolumeReplicationInfoResponse\"\x00BHZFgithub.com/IBM/ibm-spectrum-scale-csi/driver/csiplugin/pkg/replicationb\x06proto3"
+
+var (
+	file_replication_proto_rawDescOnce sync.Once
+	file_replication_proto_rawDescData []byte
+)
+
+func file_replication_proto_rawDescGZIP() []byte {
+	file_replication_proto_rawDescOnce.Do(func() {
+		file_replication_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_replication_proto_rawDesc), len(file_replication_proto_rawDesc)))
+	})
+	return file_replication_proto_rawDescData
+}
+
+var file_replication_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_replication_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
+var file_replication_proto_goTypes = []any{
+	(Role)(0),                                // 0: spectrumscale.csi.replication.v1.Role
+	(*EnableVolumeReplicationRequest)(nil),   // 1: spectrumscale.csi.replication.v1.EnableVolumeReplicationRequest
+	(*EnableVolumeReplicationResponse)(nil),  // 2: spectrumscale.csi.replication.v1.EnableVolumeReplicationResponse
+	(*DisableVolumeReplicationRequest)(nil),  // 3: spectrumscale.csi.replication.v1.DisableVolumeReplicationRequest
+	(*DisableVolumeReplicationResponse)(nil), // 4: spectrumscale.csi.replication.v1.DisableVolumeReplicationResponse
+	(*PromoteVolumeRequest)(nil),             // 5: spectrumscale.csi.replication.v1.PromoteVolumeRequest
+	(*PromoteVolumeResponse)(nil),            // 6: spectrumscale.csi.replication.v1.PromoteVolumeResponse
+	(*DemoteVolumeRequest)(nil),              // 7: spectrumscale.csi.replication.v1.DemoteVolumeRequest
+	(*DemoteVolumeResponse)(nil),             // 8: spectrumscale.csi.replication.v1.DemoteVolumeResponse
+	(*ResyncVolumeRequest)(nil),              // 9: spectrumscale.csi.replication.v1.ResyncVolumeRequest
+	(*ResyncVolumeResponse)(nil),             // 10: spectrumscale.csi.replication.v1.ResyncVolumeResponse
+	(*GetVolumeReplicationInfoRequest)(nil),  // 11: spectrumscale.csi.replication.v1.GetVolumeReplicationInfoRequest
+	(*GetVolumeReplicationInfoResponse)(nil), // 12: spectrumscale.csi.replication.v1.GetVolumeReplicationInfoResponse
+	nil,                                      // 13: spectrumscale.csi.replication.v1.EnableVolumeReplicationRequest.ParametersEntry
+	nil,                                      // 14: spectrumscale.csi.replication.v1.DisableVolumeReplicationRequest.ParametersEntry
+	nil,                                      // 15: spectrumscale.csi.replication.v1.PromoteVolumeRequest.ParametersEntry
+	nil,                                      // 16: spectrumscale.csi.replication.v1.DemoteVolumeRequest.ParametersEntry
+	nil,                                      // 17: spectrumscale.csi.replication.v1.ResyncVolumeRequest.ParametersEntry
+	(*timestamppb.Timestamp)(nil),            // 18: google.protobuf.Timestamp
+}
+var file_replication_proto_depIdxs = []int32{
+	13, // 0: spectrumscale.csi.replication.v1.EnableVolumeReplicationRequest.parameters:type_name -> spectrumscale.csi.replication.v1.EnableVolumeReplicationRequest.ParametersEntry
+	14, // 1: spectrumscale.csi.replication.v1.DisableVolumeReplicationRequest.parameters:type_name -> spectrumscale.csi.replication.v1.DisableVolumeReplicationRequest.ParametersEntry
+	15, // 2: spectrumscale.csi.replication.v1.PromoteVolumeRequest.parameters:type_name -> spectrumscale.csi.replication.v1.PromoteVolumeRequest.ParametersEntry
+	16, // 3: spectrumscale.csi.replication.v1.DemoteVolumeRequest.parameters:type_name -> spectrumscale.csi.replication.v1.DemoteVolumeRequest.ParametersEntry
+	17, // 4: spectrumscale.csi.replication.v1.ResyncVolumeRequest.parameters:type_name -> spectrumscale.csi.replication.v1.ResyncVolumeRequest.ParametersEntry
+	18, // 5: spectrumscale.csi.replication.v1.GetVolumeReplicationInfoResponse.last_sync_time:type_name -> google.protobuf.Timestamp
+	0,  // 6: spectrumscale.csi.replication.v1.GetVolumeReplicationInfoResponse.role:type_name -> spectrumscale.csi.replication.v1.Role
+	1,  // 7: spectrumscale.csi.replication.v1.Replication.EnableVolumeReplication:input_type -> spectrumscale.csi.replication.v1.EnableVolumeReplicationRequest
+	3,  // 8: spectrumscale.csi.replication.v1.Replication.DisableVolumeReplication:input_type -> spectrumscale.csi.replication.v1.DisableVolumeReplicationRequest
+	5,  // 9: spectrumscale.csi.replication.v1.Replication.PromoteVolume:input_type -> spectrumscale.csi.replication.v1.PromoteVolumeRequest
+	7,  // 10: spectrumscale.csi.replication.v1.Replication.DemoteVolume:input_type -> spectrumscale.csi.replication.v1.DemoteVolumeRequest
+	9,  // 11: spectrumscale.csi.replication.v1.Replication.ResyncVolume:input_type -> spectrumscale.csi.replication.v1.ResyncVolumeRequest
+	11, // 12: spectrumscale.csi.replication.v1.Replication.GetVolumeReplicationInfo:input_type -> spectrumscale.csi.replication.v1.GetVolumeReplicationInfoRequest
+	2,  // 13: spectrumscale.csi.replication.v1.Replication.EnableVolumeReplication:output_type -> spectrumscale.csi.replication.v1.EnableVolumeReplicationResponse
+	4,  // 14: spectrumscale.csi.replication.v1.Replication.DisableVolumeReplication:output_type -> spectrumscale.csi.replication.v1.DisableVolumeReplicationResponse
+	6,  // 15: spectrumscale.csi.replication.v1.Replication.PromoteVolume:output_type -> spectrumscale.csi.replication.v1.PromoteVolumeResponse
+	8,  // 16: spectrumscale.csi.replication.v1.Replication.DemoteVolume:output_type -> spectrumscale.csi.replication.v1.DemoteVolumeResponse
+	10, // 17: spectrumscale.csi.replication.v1.Replication.ResyncVolume:output_type -> spectrumscale.csi.replication.v1.ResyncVolumeResponse
+	12, // 18: spectrumscale.csi.replication.v1.Replication.GetVolumeReplicationInfo:output_type -> spectrumscale.csi.replication.v1.GetVolumeReplicationInfoResponse
+	13, // [13:19] is the sub-list for method output_type
+	7,  // [7:13] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_replication_proto_init() }
+func file_replication_proto_init() {
+	if File_replication_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_replication_proto_rawDesc), len(file_replication_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   17,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_replication_proto_goTypes,
+		DependencyIndexes: file_replication_proto_depIdxs,
+		EnumInfos:         file_replication_proto_enumTypes,
+		MessageInfos:      file_replication_proto_msgTypes,
+	}.Build()
+	File_replication_proto = out.File
+	file_replication_proto_goTypes = nil
+	file_replication_proto_depIdxs = nil
+}
@@ -0,0 +1,102 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package scaleconfig creates connectors to the IBM Storage Scale clusters
+// configured for the driver, for tools running outside of the driver pods.
+package scaleconfig
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/IBM/ibm-spectrum-scale-csi/driver/csiplugin/connectors"
+	"github.com/IBM/ibm-spectrum-scale-csi/driver/csiplugin/settings"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// ConfigMapName is the ConfigMap with the cluster configuration of the
+	// driver, created by the operator in the namespace of the driver
+	ConfigMapName = "spectrum-scale-config"
+	configMapKey  = ConfigMapName + ".json"
+
+	// Primary is the key of the connector of the primary cluster, in addition
+	// to its cluster ID
+	Primary = "primary"
+
+	secretUsername = "username"
+	secretPassword = "password" // #nosec G101 false positive
+)
+
+// NewConnectors creates a connector for every cluster configured for the
+// driver, with the GUI credentials and certificates stored in namespace. The
+// connectors are keyed by cluster ID, the connector of the primary cluster is
+// also stored with the key Primary.
+func NewConnectors(ctx context.Context, client kubernetes.Interface, namespace string) (map[string]connectors.SpectrumScaleConnector, error) {
+	cm, err := client.CoreV1().ConfigMaps(namespace).Get(ctx, ConfigMapName, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("unable to get ConfigMap %s/%s: %w", namespace, ConfigMapName, err)
+	}
+	scaleConfig := settings.ScaleSettingsConfigMap{}
+	if err := json.Unmarshal([]byte(cm.Data[configMapKey]), &scaleConfig); err != nil {
+		return nil, fmt.Errorf("unable to parse %s of ConfigMap %s/%s: %w", configMapKey, namespace, ConfigMapName, err)
+	}
+	if len(scaleConfig.Clusters) == 0 {
+		return nil, fmt.Errorf("no cluster is configured in ConfigMap %s/%s", namespace, ConfigMapName)
+	}
+
+	conns := map[string]connectors.SpectrumScaleConnector{}
+	for _, cluster := range scaleConfig.Clusters {
+		if cluster.Secrets != "" {
+			secret, err := client.CoreV1().Secrets(namespace).Get(ctx, cluster.Secrets, metav1.GetOptions{})
+			if err != nil {
+				return nil, fmt.Errorf("unable to get Secret %s/%s of cluster %s: %w", namespace, cluster.Secrets, cluster.ID, err)
+			}
+			cluster.MgmtUsername = strings.TrimSpace(string(secret.Data[secretUsername]))
+			cluster.MgmtPassword = strings.TrimSuffix(string(secret.Data[secretPassword]), "\n")
+		}
+		if cluster.SecureSslMode && cluster.Cacert != "" {
+			cacert, err := client.CoreV1().ConfigMaps(namespace).Get(ctx, cluster.Cacert, metav1.GetOptions{})
+			if err != nil {
+				return nil, fmt.Errorf("unable to get ConfigMap %s/%s with the GUI certificates of cluster %s: %w", namespace, cluster.Cacert, cluster.ID, err)
+			}
+			pool := x509.NewCertPool()
+			for _, pem := range cacert.Data {
+				if !pool.AppendCertsFromPEM([]byte(pem)) {
+					return nil, fmt.Errorf("unable to parse the GUI certificates of ConfigMap %s/%s", namespace, cluster.Cacert)
+				}
+			}
+			cluster.CacertValue = pool
+		}
+
+		conn, err := connectors.NewSpectrumRestV2(ctx, cluster)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create connector for cluster %s: %w", cluster.ID, err)
+		}
+		conns[cluster.ID] = conn
+		if (scaleConfig.LocalScaleCluster != "" && scaleConfig.LocalScaleCluster == cluster.ID) || cluster.Primary != (settings.Primary{}) {
+			conns[Primary] = conn
+		}
+	}
+	if _, ok := conns[Primary]; !ok {
+		return nil, fmt.Errorf("no primary cluster is configured in ConfigMap %s/%s", namespace, ConfigMapName)
+	}
+	return conns, nil
+}
@@ -0,0 +1,295 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package scalehandle encodes and decodes the volume and snapshot handles of
+// IBM Storage Scale CSI driver in all layouts written by any release.
+//
+// The members of a handle are separated by ';'. Encode escapes '%' as "%25"
+// and ';' as "%3B" in every member, so a handle whose members contain neither
+// is identical to the one written by releases without escaping. Decode
+// reverses the escaping and keeps any other '%' as is, which decodes the
+// handles of those releases unchanged. For every handle h accepted by Encode,
+// Decode(Encode(h)) returns h.
+package scalehandle
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Version identifies the layout of a handle.
+type Version int
+
+const (
+	// V1 is the layout of the handles created before CSI 2.5.0:
+	//
+	//	volume:   <cluster_id>;<filesystem_uuid>;path=<symlink_path>
+	//	          <cluster_id>;<filesystem_uuid>;fileset=<fileset_id>;path=<symlink_path>
+	//	          <cluster_id>;<filesystem_uuid>;filesetName=<fileset_name>;path=<symlink_path>
+	//	snapshot: <cluster_id>;<filesystem_uuid>;<fileset_name>;<snapshot_name>[;<path>]
+	V1 Version = 1
+	// V2 is the layout of the handles created from CSI 2.5.0 onwards:
+	//
+	//	volume:   <storageclass_type>;<volume_type>;<cluster_id>;<filesystem_uuid>;<consistency_group>;<fileset_name>;<path>
+	//	snapshot: <storageclass_type>;<volume_type>;<cluster_id>;<filesystem_uuid>;<consistency_group>;<fileset_name>;<snapshot_name>;<meta_snapshot_name>[;<path>]
+	V2 Version = 2
+	// Latest is the layout of new handles
+	Latest = V2
+)
+
+// Storage class types of V2 handles.
+const (
+	ClassicStorageClass  = "0"
+	AdvancedStorageClass = "1"
+	CacheStorageClass    = "2"
+)
+
+// Volume types of V2 handles.
+const (
+	LightweightVolume        = "0"
+	DependentFilesetVolume   = "1"
+	IndependentFilesetVolume = "2"
+	ShallowCopyVolume        = "3"
+	VMDiskOptimizedVolume    = "4"
+)
+
+const (
+	separator = ";"
+
+	keyFileset     = "fileset"
+	keyFilesetName = "filesetName"
+	keyPath        = "path"
+
+	volumeV1Members   = 3
+	volumeV2Members   = 7
+	snapshotV1Members = 4
+	snapshotV2Members = 8
+)
+
+var (
+	ErrInvalidVolumeHandle   = errors.New("invalid volume handle")
+	ErrInvalidSnapshotHandle = errors.New("invalid snapshot handle")
+)
+
+// Volume is a decoded volume handle.
+type Volume struct {
+	Version          Version
+	StorageClassType string
+	VolumeType       string
+	ClusterID        string
+	FilesystemUUID   string
+	// ConsistencyGroup is the independent fileset of an advanced storage
+	// class volume, or <fileset>:<snapshot> of the source of a VM disk
+	// optimized volume
+	ConsistencyGroup string
+	FilesetName      string
+	// FilesetID identifies the fileset in V1 handles without filesetName=
+	FilesetID string
+	// Path is the link path of the volume in V2 handles and the path of the
+	// symlink to it in V1 handles
+	Path string
+}
+
+// Snapshot is a decoded snapshot handle.
+type Snapshot struct {
+	Version          Version
+	StorageClassType string
+	VolumeType       string
+	ClusterID        string
+	FilesystemUUID   string
+	ConsistencyGroup string
+	FilesetName      string
+	SnapshotName     string
+	MetaSnapshotName string
+	// Path is the path of the volume data below the snapshot, it is empty
+	// if the handle has no path member
+	Path string
+}
+
+// IsFilesetBased returns whether the volume is a fileset, lightweight volumes
+// are a directory.
+func (v Volume) IsFilesetBased() bool {
+	if v.Version == V1 {
+		return v.FilesetName != "" || v.FilesetID != ""
+	}
+	return v.StorageClassType != ClassicStorageClass || v.VolumeType != LightweightVolume
+}
+
+// Encode returns the volume handle. It fails if a member is set which the
+// layout of the version does not have.
+func (v Volume) Encode() (string, error) {
+	switch v.Version {
+	case V1:
+		if v.StorageClassType != "" || v.VolumeType != "" || v.ConsistencyGroup != "" {
+			return "", fmt.Errorf("%w: version 1 has no storage class type, volume type and consistency group", ErrInvalidVolumeHandle)
+		}
+		members := []string{escape(v.ClusterID), escape(v.FilesystemUUID)}
+		switch {
+		case v.FilesetName != "" && v.FilesetID != "":
+			return "", fmt.Errorf("%w: version 1 has either a fileset name or a fileset ID", ErrInvalidVolumeHandle)
+		case v.FilesetName != "":
+			members = append(members, keyFilesetName+"="+escape(v.FilesetName))
+		case v.FilesetID != "":
+			members = append(members, keyFileset+"="+escape(v.FilesetID))
+		}
+		members = append(members, keyPath+"="+escape(v.Path))
+		return strings.Join(members, separator), nil
+	case V2:
+		if v.FilesetID != "" {
+			return "", fmt.Errorf("%w: version 2 has no fileset ID", ErrInvalidVolumeHandle)
+		}
+		return join(v.StorageClassType, v.VolumeType, v.ClusterID, v.FilesystemUUID, v.ConsistencyGroup, v.FilesetName, v.Path), nil
+	}
+	return "", fmt.Errorf("%w: unknown version %d", ErrInvalidVolumeHandle, v.Version)
+}
+
+// DecodeVolume decodes a volume handle of any version.
+func DecodeVolume(volumeHandle string) (Volume, error) {
+	members := strings.Split(volumeHandle, separator)
+	switch {
+	case len(members) == volumeV1Members || len(members) == volumeV1Members+1:
+		v := Volume{Version: V1, ClusterID: unescape(members[0]), FilesystemUUID: unescape(members[1])}
+		if len(members) == volumeV1Members+1 {
+			key, value, ok := strings.Cut(members[2], "=")
+			if !ok {
+				return Volume{}, fmt.Errorf("%w: fileset member without '=' in %q", ErrInvalidVolumeHandle, volumeHandle)
+			}
+			// releases before filesetName= used any other key for the ID
+			if key == keyFilesetName {
+				v.FilesetName = unescape(value)
+			} else {
+				v.FilesetID = unescape(value)
+			}
+		}
+		_, value, ok := strings.Cut(members[len(members)-1], "=")
+		if !ok {
+			return Volume{}, fmt.Errorf("%w: path member without '=' in %q", ErrInvalidVolumeHandle, volumeHandle)
+		}
+		v.Path = unescape(value)
+		return v, nil
+	case len(members) >= volumeV2Members:
+		// releases without escaping wrote a path containing ';' unchanged,
+		// the path is the last member
+		return Volume{
+			Version:          V2,
+			StorageClassType: unescape(members[0]),
+			VolumeType:       unescape(members[1]),
+			ClusterID:        unescape(members[2]),
+			FilesystemUUID:   unescape(members[3]),
+			ConsistencyGroup: unescape(members[4]),
+			FilesetName:      unescape(members[5]),
+			Path:             unescape(strings.Join(members[volumeV2Members-1:], separator)),
+		}, nil
+	}
+	return Volume{}, fmt.Errorf("%w: %q has %d members", ErrInvalidVolumeHandle, volumeHandle, len(members))
+}
+
+// Encode returns the snapshot handle. It fails if a member is set which the
+// layout of the version does not have.
+func (s Snapshot) Encode() (string, error) {
+	members := []string{}
+	switch s.Version {
+	case V1:
+		if s.StorageClassType != "" || s.VolumeType != "" || s.ConsistencyGroup != "" || s.MetaSnapshotName != "" {
+			return "", fmt.Errorf("%w: version 1 has no storage class type, volume type, consistency group and meta snapshot name", ErrInvalidSnapshotHandle)
+		}
+		members = append(members, s.ClusterID, s.FilesystemUUID, s.FilesetName, s.SnapshotName)
+	case V2:
+		members = append(members, s.StorageClassType, s.VolumeType, s.ClusterID, s.FilesystemUUID, s.ConsistencyGroup, s.FilesetName, s.SnapshotName, s.MetaSnapshotName)
+	default:
+		return "", fmt.Errorf("%w: unknown version %d", ErrInvalidSnapshotHandle, s.Version)
+	}
+	if s.Path != "" {
+		members = append(members, s.Path)
+	}
+	return join(members...), nil
+}
+
+// DecodeSnapshot decodes a snapshot handle of any version.
+func DecodeSnapshot(snapshotHandle string) (Snapshot, error) {
+	members := strings.Split(snapshotHandle, separator)
+	switch {
+	case len(members) == snapshotV1Members || len(members) == snapshotV1Members+1:
+		s := Snapshot{
+			Version:        V1,
+			ClusterID:      unescape(members[0]),
+			FilesystemUUID: unescape(members[1]),
+			FilesetName:    unescape(members[2]),
+			SnapshotName:   unescape(members[3]),
+		}
+		if len(members) > snapshotV1Members {
+			s.Path = unescape(members[4])
+		}
+		return s, nil
+	case len(members) >= snapshotV2Members:
+		s := Snapshot{
+			Version:          V2,
+			StorageClassType: unescape(members[0]),
+			VolumeType:       unescape(members[1]),
+			ClusterID:        unescape(members[2]),
+			FilesystemUUID:   unescape(members[3]),
+			ConsistencyGroup: unescape(members[4]),
+			FilesetName:      unescape(members[5]),
+			SnapshotName:     unescape(members[6]),
+			MetaSnapshotName: unescape(members[7]),
+		}
+		// the path is the last member, see DecodeVolume
+		if len(members) > snapshotV2Members {
+			s.Path = unescape(strings.Join(members[snapshotV2Members:], separator))
+		}
+		return s, nil
+	}
+	return Snapshot{}, fmt.Errorf("%w: %q has %d members", ErrInvalidSnapshotHandle, snapshotHandle, len(members))
+}
+
+func join(members ...string) string {
+	for i := range members {
+		members[i] = escape(members[i])
+	}
+	return strings.Join(members, separator)
+}
+
+var escaper = strings.NewReplacer("%", "%25", ";", "%3B")
+
+func escape(member string) string {
+	return escaper.Replace(member)
+}
+
+// unescape reverses escape, a '%' which does not start "%25" or "%3B" is
+// kept as written by releases without escaping.
+func unescape(member string) string {
+	if !strings.Contains(member, "%") {
+		return member
+	}
+	var b strings.Builder
+	for i := 0; i < len(member); i++ {
+		if member[i] == '%' && i+2 < len(member) {
+			switch strings.ToUpper(member[i+1 : i+3]) {
+			case "25":
+				b.WriteByte('%')
+				i += 2
+				continue
+			case "3B":
+				b.WriteByte(';')
+				i += 2
+				continue
+			}
+		}
+		b.WriteByte(member[i])
+	}
+	return b.String()
+}
@@ -0,0 +1,234 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package scalehandle
+
+import (
+	"errors"
+	"testing"
+)
+
+// historical volume handles as written by the releases, the decoded handle
+// encodes to the same string. The version 2 handles are also tested by the
+// volumehandle package of the operator.
+var volumeHandles = []struct {
+	handle string
+	want   Volume
+}{
+	{"7118073361626808055;09762E35:5D26932A;path=/ibm/gpfs0/pvc-1",
+		Volume{Version: V1, ClusterID: "7118073361626808055", FilesystemUUID: "09762E35:5D26932A", Path: "/ibm/gpfs0/pvc-1"}},
+	{"7118073361626808055;09762E35:5D26932A;fileset=12;path=/ibm/gpfs0/pvc-2",
+		Volume{Version: V1, ClusterID: "7118073361626808055", FilesystemUUID: "09762E35:5D26932A", FilesetID: "12", Path: "/ibm/gpfs0/pvc-2"}},
+	{"7118073361626808055;09762E35:5D26932A;filesetName=pvc-3;path=/ibm/gpfs0/pvc-3",
+		Volume{Version: V1, ClusterID: "7118073361626808055", FilesystemUUID: "09762E35:5D26932A", FilesetName: "pvc-3", Path: "/ibm/gpfs0/pvc-3"}},
+	{"0;0;7118073361626808055;09762E35:5D26932A;;;/ibm/gpfs0/lw/pvc-4",
+		Volume{Version: V2, StorageClassType: ClassicStorageClass, VolumeType: LightweightVolume, ClusterID: "7118073361626808055", FilesystemUUID: "09762E35:5D26932A", Path: "/ibm/gpfs0/lw/pvc-4"}},
+	{"0;2;7118073361626808055;09762E35:5D26932A;;pvc-5;/ibm/gpfs0/pvc-5/pvc-5-data",
+		Volume{Version: V2, StorageClassType: ClassicStorageClass, VolumeType: IndependentFilesetVolume, ClusterID: "7118073361626808055", FilesystemUUID: "09762E35:5D26932A", FilesetName: "pvc-5", Path: "/ibm/gpfs0/pvc-5/pvc-5-data"}},
+	{"1;1;7118073361626808055;09762E35:5D26932A;cluster-ns;pvc-6;/ibm/gpfs0/cluster-ns/pvc-6",
+		Volume{Version: V2, StorageClassType: AdvancedStorageClass, VolumeType: DependentFilesetVolume, ClusterID: "7118073361626808055", FilesystemUUID: "09762E35:5D26932A", ConsistencyGroup: "cluster-ns", FilesetName: "pvc-6", Path: "/ibm/gpfs0/cluster-ns/pvc-6"}},
+	{"0;4;7118073361626808055;09762E35:5D26932A;pvc-7:snap-1;pvc-8;/ibm/gpfs0/pvc-8/pvc-8-data",
+		Volume{Version: V2, StorageClassType: ClassicStorageClass, VolumeType: VMDiskOptimizedVolume, ClusterID: "7118073361626808055", FilesystemUUID: "09762E35:5D26932A", ConsistencyGroup: "pvc-7:snap-1", FilesetName: "pvc-8", Path: "/ibm/gpfs0/pvc-8/pvc-8-data"}},
+	{"0;2;7118073361626808055;09762E35:5D26932A;;static;/ibm/gpfs0/100%25 used",
+		Volume{Version: V2, StorageClassType: ClassicStorageClass, VolumeType: IndependentFilesetVolume, ClusterID: "7118073361626808055", FilesystemUUID: "09762E35:5D26932A", FilesetName: "static", Path: "/ibm/gpfs0/100% used"}},
+}
+
+var snapshotHandles = []struct {
+	handle string
+	want   Snapshot
+}{
+	{"7118073361626808055;09762E35:5D26932A;pvc-1;snap-1",
+		Snapshot{Version: V1, ClusterID: "7118073361626808055", FilesystemUUID: "09762E35:5D26932A", FilesetName: "pvc-1", SnapshotName: "snap-1"}},
+	{"7118073361626808055;09762E35:5D26932A;pvc-1;snap-1;pvc-1-data",
+		Snapshot{Version: V1, ClusterID: "7118073361626808055", FilesystemUUID: "09762E35:5D26932A", FilesetName: "pvc-1", SnapshotName: "snap-1", Path: "pvc-1-data"}},
+	{"1;1;7118073361626808055;09762E35:5D26932A;cluster-ns;pvc-2;snap-2;snapshot-2",
+		Snapshot{Version: V2, StorageClassType: AdvancedStorageClass, VolumeType: DependentFilesetVolume, ClusterID: "7118073361626808055", FilesystemUUID: "09762E35:5D26932A", ConsistencyGroup: "cluster-ns", FilesetName: "pvc-2", SnapshotName: "snap-2", MetaSnapshotName: "snapshot-2"}},
+	{"0;2;7118073361626808055;09762E35:5D26932A;;pvc-3;snap-3;;pvc-3-data",
+		Snapshot{Version: V2, StorageClassType: ClassicStorageClass, VolumeType: IndependentFilesetVolume, ClusterID: "7118073361626808055", FilesystemUUID: "09762E35:5D26932A", FilesetName: "pvc-3", SnapshotName: "snap-3", Path: "pvc-3-data"}},
+	{"0;2;7118073361626808055;09762E35:5D26932A;;static;snap-4;;/",
+		Snapshot{Version: V2, StorageClassType: ClassicStorageClass, VolumeType: IndependentFilesetVolume, ClusterID: "7118073361626808055", FilesystemUUID: "09762E35:5D26932A", FilesetName: "static", SnapshotName: "snap-4", Path: "/"}},
+}
+
+func TestVolumeHandles(t *testing.T) {
+	for _, tc := range volumeHandles {
+		got, err := DecodeVolume(tc.handle)
+		if err != nil {
+			t.Fatalf("DecodeVolume(%q): %v", tc.handle, err)
+		}
+		if got != tc.want {
+			t.Errorf("DecodeVolume(%q) = %+v, want %+v", tc.handle, got, tc.want)
+		}
+		encoded, err := got.Encode()
+		if err != nil {
+			t.Fatalf("Encode(%+v): %v", got, err)
+		}
+		if encoded != tc.handle {
+			t.Errorf("Encode(%+v) = %q, want %q", got, encoded, tc.handle)
+		}
+	}
+}
+
+func TestSnapshotHandles(t *testing.T) {
+	for _, tc := range snapshotHandles {
+		got, err := DecodeSnapshot(tc.handle)
+		if err != nil {
+			t.Fatalf("DecodeSnapshot(%q): %v", tc.handle, err)
+		}
+		if got != tc.want {
+			t.Errorf("DecodeSnapshot(%q) = %+v, want %+v", tc.handle, got, tc.want)
+		}
+		encoded, err := got.Encode()
+		if err != nil {
+			t.Fatalf("Encode(%+v): %v", got, err)
+		}
+		if encoded != tc.handle {
+			t.Errorf("Encode(%+v) = %q, want %q", got, encoded, tc.handle)
+		}
+	}
+}
+
+func TestUnescapedPath(t *testing.T) {
+	// releases without escaping wrote a path containing ';' unchanged
+	got, err := DecodeVolume("0;2;1;uuid;;pvc-1;/ibm/gpfs0/a;b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Path != "/ibm/gpfs0/a;b" {
+		t.Errorf("path = %q, want %q", got.Path, "/ibm/gpfs0/a;b")
+	}
+	encoded, _ := got.Encode()
+	if encoded != "0;2;1;uuid;;pvc-1;/ibm/gpfs0/a%3Bb" {
+		t.Errorf("Encode(%+v) = %q", got, encoded)
+	}
+}
+
+func TestInvalidHandles(t *testing.T) {
+	for _, handle := range []string{"", "1;2", "1;uuid;nopath", "1;uuid;fileset=1;nopath", "0;2;1;uuid;;pvc"} {
+		if _, err := DecodeVolume(handle); !errors.Is(err, ErrInvalidVolumeHandle) {
+			t.Errorf("DecodeVolume(%q) = %v, want %v", handle, err, ErrInvalidVolumeHandle)
+		}
+	}
+	for _, handle := range []string{"", "1;uuid;pvc", "1;uuid;pvc;snap;path;x", "0;2;1;uuid;;pvc;snap"} {
+		if _, err := DecodeSnapshot(handle); !errors.Is(err, ErrInvalidSnapshotHandle) {
+			t.Errorf("DecodeSnapshot(%q) = %v, want %v", handle, err, ErrInvalidSnapshotHandle)
+		}
+	}
+	if _, err := (Volume{Version: V1, FilesetName: "a", FilesetID: "1"}).Encode(); !errors.Is(err, ErrInvalidVolumeHandle) {
+		t.Errorf("Encode of fileset name and ID = %v, want %v", err, ErrInvalidVolumeHandle)
+	}
+	if _, err := (Snapshot{}).Encode(); !errors.Is(err, ErrInvalidSnapshotHandle) {
+		t.Errorf("Encode without version = %v, want %v", err, ErrInvalidSnapshotHandle)
+	}
+}
+
+// FuzzVolumeRoundTrip checks that every encodable volume decodes to itself.
+func FuzzVolumeRoundTrip(f *testing.F) {
+	for _, tc := range volumeHandles {
+		v := tc.want
+		f.Add(int(v.Version), v.StorageClassType, v.VolumeType, v.ClusterID, v.FilesystemUUID, v.ConsistencyGroup, v.FilesetName, v.FilesetID, v.Path)
+	}
+	f.Add(2, "0", "2", "1", "uuid", "", "a;b", "", "/x;y%3B%25%")
+	f.Fuzz(func(t *testing.T, version int, storageClassType, volumeType, clusterID, fsUUID, consistencyGroup, filesetName, filesetID, path string) {
+		v := Volume{
+			Version:          Version(version),
+			StorageClassType: storageClassType,
+			VolumeType:       volumeType,
+			ClusterID:        clusterID,
+			FilesystemUUID:   fsUUID,
+			ConsistencyGroup: consistencyGroup,
+			FilesetName:      filesetName,
+			FilesetID:        filesetID,
+			Path:             path,
+		}
+		encoded, err := v.Encode()
+		if err != nil {
+			return
+		}
+		got, err := DecodeVolume(encoded)
+		if err != nil {
+			t.Fatalf("DecodeVolume(%q) of %+v: %v", encoded, v, err)
+		}
+		if got != v {
+			t.Fatalf("DecodeVolume(%q) = %+v, want %+v", encoded, got, v)
+		}
+	})
+}
+
+// FuzzSnapshotRoundTrip checks that every encodable snapshot decodes to
+// itself.
+func FuzzSnapshotRoundTrip(f *testing.F) {
+	for _, tc := range snapshotHandles {
+		s := tc.want
+		f.Add(int(s.Version), s.StorageClassType, s.VolumeType, s.ClusterID, s.FilesystemUUID, s.ConsistencyGroup, s.FilesetName, s.SnapshotName, s.MetaSnapshotName, s.Path)
+	}
+	f.Fuzz(func(t *testing.T, version int, storageClassType, volumeType, clusterID, fsUUID, consistencyGroup, filesetName, snapshotName, metaSnapshotName, path string) {
+		s := Snapshot{
+			Version:          Version(version),
+			StorageClassType: storageClassType,
+			VolumeType:       volumeType,
+			ClusterID:        clusterID,
+			FilesystemUUID:   fsUUID,
+			ConsistencyGroup: consistencyGroup,
+			FilesetName:      filesetName,
+			SnapshotName:     snapshotName,
+			MetaSnapshotName: metaSnapshotName,
+			Path:             path,
+		}
+		encoded, err := s.Encode()
+		if err != nil {
+			return
+		}
+		got, err := DecodeSnapshot(encoded)
+		if err != nil {
+			t.Fatalf("DecodeSnapshot(%q) of %+v: %v", encoded, s, err)
+		}
+		if got != s {
+			t.Fatalf("DecodeSnapshot(%q) = %+v, want %+v", encoded, got, s)
+		}
+	})
+}
+
+// FuzzDecode checks that decoding any string and encoding the result again
+// is stable.
+func FuzzDecode(f *testing.F) {
+	for _, tc := range volumeHandles {
+		f.Add(tc.handle)
+	}
+	for _, tc := range snapshotHandles {
+		f.Add(tc.handle)
+	}
+	f.Add("0;2;1;uuid;;pvc-1;/ibm/gpfs0/a;b%3b%2")
+	f.Fuzz(func(t *testing.T, handle string) {
+		if v, err := DecodeVolume(handle); err == nil {
+			encoded, err := v.Encode()
+			if err != nil {
+				t.Fatalf("Encode(%+v) of %q: %v", v, handle, err)
+			}
+			if got, err := DecodeVolume(encoded); err != nil || got != v {
+				t.Fatalf("DecodeVolume(%q) = %+v, %v, want %+v", encoded, got, err, v)
+			}
+		}
+		if s, err := DecodeSnapshot(handle); err == nil {
+			encoded, err := s.Encode()
+			if err != nil {
+				t.Fatalf("Encode(%+v) of %q: %v", s, handle, err)
+			}
+			if got, err := DecodeSnapshot(encoded); err != nil || got != s {
+				t.Fatalf("DecodeSnapshot(%q) = %+v, %v, want %+v", encoded, got, err, s)
+			}
+		}
+	})
+}
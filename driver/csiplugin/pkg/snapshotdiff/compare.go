@@ -0,0 +1,191 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package snapshotdiff
+
+import (
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"syscall"
+)
+
+// Compare walks the trees of the base and the target snapshot directory in
+// lexical order and calls fn for every path created, modified or deleted in
+// the target, relative to the root of the trees. Like mmsnapdiff, a file is
+// modified when its inode, size, modification or change time differs, the
+// changes of a directory are reported by its entries.
+//
+// Snapshots are read-only, so the walk order is stable. The paths up to and
+// including after are skipped to resume a walk. The walk stops at the first
+// error returned by fn.
+func Compare(baseDir, targetDir, after string, fn func(*ChangedFile) error) error {
+	w := &walker{baseDir: baseDir, targetDir: targetDir, after: after, fn: fn}
+	return w.compareDir("", true, true)
+}
+
+// Resume sorts changes reported by IBM Storage Scale in the order of Compare
+// and calls fn for the changes after the path after, so the resume tokens of
+// both are the same. It stops at the first error returned by fn.
+func Resume(changes []*ChangedFile, after string, fn func(*ChangedFile) error) error {
+	sort.SliceStable(changes, func(i, j int) bool {
+		return walkOrder(changes[i].Path, changes[j].Path) < 0
+	})
+	for _, change := range changes {
+		if after != "" && walkOrder(change.Path, after) <= 0 {
+			continue
+		}
+		if err := fn(change); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type walker struct {
+	baseDir   string
+	targetDir string
+	after     string
+	fn        func(*ChangedFile) error
+}
+
+// compareDir compares the entries of the directory rel in the trees it
+// exists in.
+func (w *walker) compareDir(rel string, inBase, inTarget bool) error {
+	var baseEntries, targetEntries []fs.DirEntry
+	var err error
+	if inBase {
+		if baseEntries, err = os.ReadDir(filepath.Join(w.baseDir, rel)); err != nil {
+			return err
+		}
+	}
+	if inTarget {
+		if targetEntries, err = os.ReadDir(filepath.Join(w.targetDir, rel)); err != nil {
+			return err
+		}
+	}
+
+	// os.ReadDir returns the entries sorted by name
+	for len(baseEntries) > 0 || len(targetEntries) > 0 {
+		var base, target fs.DirEntry
+		switch {
+		case len(targetEntries) == 0 || (len(baseEntries) > 0 && baseEntries[0].Name() < targetEntries[0].Name()):
+			base, baseEntries = baseEntries[0], baseEntries[1:]
+		case len(baseEntries) == 0 || targetEntries[0].Name() < baseEntries[0].Name():
+			target, targetEntries = targetEntries[0], targetEntries[1:]
+		default:
+			base, baseEntries = baseEntries[0], baseEntries[1:]
+			target, targetEntries = targetEntries[0], targetEntries[1:]
+		}
+		if err := w.compareEntry(rel, base, target); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// compareEntry reports the change of an entry existing in the base, the
+// target or both, and compares its subtree if it is a directory.
+func (w *walker) compareEntry(rel string, base, target fs.DirEntry) error {
+	var name string
+	if target != nil {
+		name = target.Name()
+	} else {
+		name = base.Name()
+	}
+	entryPath := path.Join(rel, name)
+
+	// subtrees before the resume point were completed already, the entries
+	// of the resume point and of its parents are still to be compared
+	resumed := w.after == "" || walkOrder(entryPath, w.after) > 0
+	if !resumed && entryPath != w.after && !isAncestor(entryPath, w.after) {
+		return nil
+	}
+
+	var change *ChangedFile
+	switch {
+	case target == nil:
+		change = &ChangedFile{Path: entryPath, ChangeType: ChangeType_DELETED}
+	case base == nil:
+		info, err := target.Info()
+		if err != nil {
+			return err
+		}
+		change = &ChangedFile{Path: entryPath, ChangeType: ChangeType_CREATED, SizeBytes: fileSize(info)}
+	default:
+		baseInfo, err := base.Info()
+		if err != nil {
+			return err
+		}
+		targetInfo, err := target.Info()
+		if err != nil {
+			return err
+		}
+		if baseInfo.IsDir() != targetInfo.IsDir() || (!targetInfo.IsDir() && isModified(baseInfo, targetInfo)) {
+			change = &ChangedFile{Path: entryPath, ChangeType: ChangeType_MODIFIED, SizeBytes: fileSize(targetInfo)}
+		}
+	}
+	if change != nil && resumed {
+		if err := w.fn(change); err != nil {
+			return err
+		}
+	}
+
+	inBase := base != nil && base.IsDir()
+	inTarget := target != nil && target.IsDir()
+	if inBase || inTarget {
+		return w.compareDir(entryPath, inBase, inTarget)
+	}
+	return nil
+}
+
+func fileSize(info fs.FileInfo) int64 {
+	if info.IsDir() {
+		return 0
+	}
+	return info.Size()
+}
+
+func isModified(base, target fs.FileInfo) bool {
+	if base.Size() != target.Size() || !base.ModTime().Equal(target.ModTime()) || base.Mode() != target.Mode() {
+		return true
+	}
+	baseStat, ok1 := base.Sys().(*syscall.Stat_t)
+	targetStat, ok2 := target.Sys().(*syscall.Stat_t)
+	if !ok1 || !ok2 {
+		return false
+	}
+	return baseStat.Ino != targetStat.Ino || baseStat.Ctim != targetStat.Ctim
+}
+
+// walkOrder compares two paths in the order of the walk, a directory comes
+// before its entries and entries are sorted by name.
+func walkOrder(a, b string) int {
+	aParts, bParts := strings.Split(a, "/"), strings.Split(b, "/")
+	for i := 0; i < len(aParts) && i < len(bParts); i++ {
+		if c := strings.Compare(aParts[i], bParts[i]); c != 0 {
+			return c
+		}
+	}
+	return len(aParts) - len(bParts)
+}
+
+func isAncestor(dir, p string) bool {
+	return strings.HasPrefix(p, dir+"/")
+}
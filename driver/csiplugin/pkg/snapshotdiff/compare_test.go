@@ -0,0 +1,180 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package snapshotdiff
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWalkOrder(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"a", "a", 0},
+		{"a", "b", -1},
+		{"b", "a", 1},
+		{"b", "b/x", -1},
+		{"b/x", "b", 1},
+		// a directory comes before its entries, even if a sibling sorts
+		// before the separator
+		{"b/z", "b.txt", -1},
+		{"b.txt", "b/z", 1},
+		{"b/z/y", "c", -1},
+		{"c", "b/z/y", 1},
+	}
+	for _, tt := range tests {
+		got := walkOrder(tt.a, tt.b)
+		if (got < 0) != (tt.want < 0) || (got > 0) != (tt.want > 0) {
+			t.Errorf("walkOrder(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+// snapshotTrees creates a base and a target tree, the unchanged files are
+// hard links like the files of a snapshot which were not modified.
+func snapshotTrees(t *testing.T) (string, string) {
+	t.Helper()
+	root := t.TempDir()
+	baseDir, targetDir := filepath.Join(root, "base"), filepath.Join(root, "target")
+	writeFile := func(name, data string) {
+		t.Helper()
+		if err := os.MkdirAll(filepath.Dir(name), 0750); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(name, []byte(data), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	link := func(rel string) {
+		t.Helper()
+		writeFile(filepath.Join(baseDir, rel), "unchanged")
+		if err := os.MkdirAll(filepath.Dir(filepath.Join(targetDir, rel)), 0750); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Link(filepath.Join(baseDir, rel), filepath.Join(targetDir, rel)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	link("a")
+	link("b/x")
+	writeFile(filepath.Join(baseDir, "b/y"), "deleted")
+	writeFile(filepath.Join(targetDir, "b/z"), "created")
+	writeFile(filepath.Join(targetDir, "b.txt"), "created")
+	writeFile(filepath.Join(targetDir, "c/w"), "created")
+	writeFile(filepath.Join(baseDir, "d"), "deleted")
+	writeFile(filepath.Join(baseDir, "m"), "base")
+	writeFile(filepath.Join(targetDir, "m"), "modified")
+	return baseDir, targetDir
+}
+
+var wantChanges = []*ChangedFile{
+	{Path: "b/y", ChangeType: ChangeType_DELETED},
+	{Path: "b/z", ChangeType: ChangeType_CREATED, SizeBytes: 7},
+	{Path: "b.txt", ChangeType: ChangeType_CREATED, SizeBytes: 7},
+	{Path: "c", ChangeType: ChangeType_CREATED},
+	{Path: "c/w", ChangeType: ChangeType_CREATED, SizeBytes: 7},
+	{Path: "d", ChangeType: ChangeType_DELETED},
+	{Path: "m", ChangeType: ChangeType_MODIFIED, SizeBytes: 8},
+}
+
+func checkChanges(t *testing.T, got, want []*ChangedFile) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("got %d changes %v, want %d changes %v", len(got), got, len(want), want)
+	}
+	for i := range want {
+		if got[i].Path != want[i].Path || got[i].ChangeType != want[i].ChangeType || got[i].SizeBytes != want[i].SizeBytes {
+			t.Errorf("change %d = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	baseDir, targetDir := snapshotTrees(t)
+
+	tests := []struct {
+		name  string
+		after string
+		want  []*ChangedFile
+	}{
+		{"from the beginning", "", wantChanges},
+		{"after a deleted file", "b/y", wantChanges[1:]},
+		{"after the last entry of a directory", "b/z", wantChanges[2:]},
+		{"after a created directory", "c", wantChanges[4:]},
+		{"after an entry of a created directory", "c/w", wantChanges[5:]},
+		{"after an unchanged file", "b/x", wantChanges},
+		{"after an unchanged directory", "b", wantChanges},
+		{"after a path not in the trees", "bb", wantChanges[3:]},
+		{"after the last change", "m", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []*ChangedFile
+			err := Compare(baseDir, targetDir, tt.after, func(change *ChangedFile) error {
+				got = append(got, change)
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("Compare() error = %v", err)
+			}
+			checkChanges(t, got, tt.want)
+		})
+	}
+}
+
+func TestCompareStops(t *testing.T) {
+	baseDir, targetDir := snapshotTrees(t)
+	errStop := errors.New("stop")
+	calls := 0
+	err := Compare(baseDir, targetDir, "", func(*ChangedFile) error {
+		calls++
+		return errStop
+	})
+	if !errors.Is(err, errStop) || calls != 1 {
+		t.Errorf("Compare() = %v after %d calls, want %v after 1 call", err, calls, errStop)
+	}
+}
+
+// The changes reported by IBM Storage Scale resume at the same tokens as a
+// walk of the trees.
+func TestResume(t *testing.T) {
+	for i := 0; i <= len(wantChanges); i++ {
+		after := ""
+		if i > 0 {
+			after = wantChanges[i-1].Path
+		}
+		reported := make([]*ChangedFile, 0, len(wantChanges))
+		for j := len(wantChanges) - 1; j >= 0; j-- {
+			reported = append(reported, wantChanges[j])
+		}
+
+		var got []*ChangedFile
+		err := Resume(reported, after, func(change *ChangedFile) error {
+			got = append(got, change)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("Resume(%q) error = %v", after, err)
+		}
+		checkChanges(t, got, wantChanges[i:])
+	}
+}
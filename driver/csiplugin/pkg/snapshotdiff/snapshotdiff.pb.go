@@ -0,0 +1,349 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v5.29.3
+// source: snapshotdiff.proto
+
+package snapshotdiff
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ChangeType int32
+
+const (
+	ChangeType_CHANGE_TYPE_UNSPECIFIED ChangeType = 0
+	ChangeType_CREATED                 ChangeType = 1
+	ChangeType_MODIFIED                ChangeType = 2
+	ChangeType_DELETED                 ChangeType = 3
+)
+
+// Enum value maps for ChangeType.
+var (
+	ChangeType_name = map[int32]string{
+		0: "CHANGE_TYPE_UNSPECIFIED",
+		1: "CREATED",
+		2: "MODIFIED",
+		3: "DELETED",
+	}
+	ChangeType_value = map[string]int32{
+		"CHANGE_TYPE_UNSPECIFIED": 0,
+		"CREATED":                 1,
+		"MODIFIED":                2,
+		"DELETED":                 3,
+	}
+)
+
+func (x ChangeType) Enum() *ChangeType {
+	p := new(ChangeType)
+	*p = x
+	return p
+}
+
+func (x ChangeType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ChangeType) Descriptor() protoreflect.EnumDescriptor {
+	return file_snapshotdiff_proto_enumTypes[0].Descriptor()
+}
+
+func (ChangeType) Type() protoreflect.EnumType {
+	return &file_snapshotdiff_proto_enumTypes[0]
+}
+
+func (x ChangeType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ChangeType.Descriptor instead.
+func (ChangeType) EnumDescriptor() ([]byte, []int) {
+	return file_snapshotdiff_proto_rawDescGZIP(), []int{0}
+}
+
+type GetChangedFilesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// CSI snapshot ID of the older snapshot.
+	BaseSnapshotId string `protobuf:"bytes,1,opt,name=base_snapshot_id,json=baseSnapshotId,proto3" json:"base_snapshot_id,omitempty"`
+	// CSI snapshot ID of the newer snapshot of the same volume.
+	TargetSnapshotId string `protobuf:"bytes,2,opt,name=target_snapshot_id,json=targetSnapshotId,proto3" json:"target_snapshot_id,omitempty"`
+	// Token returned in a previous response to resume the stream after it,
+	// empty to start from the beginning.
+	StartingToken string `protobuf:"bytes,3,opt,name=starting_token,json=startingToken,proto3" json:"starting_token,omitempty"`
+	// Maximum number of changed files in one response, 0 for the server default.
+	MaxResults    int32 `protobuf:"varint,4,opt,name=max_results,json=maxResults,proto3" json:"max_results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetChangedFilesRequest) Reset() {
+	*x = GetChangedFilesRequest{}
+	mi := &file_snapshotdiff_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetChangedFilesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetChangedFilesRequest) ProtoMessage() {}
+
+func (x *GetChangedFilesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_snapshotdiff_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetChangedFilesRequest.ProtoReflect.Descriptor instead.
+func (*GetChangedFilesRequest) Descriptor() ([]byte, []int) {
+	return file_snapshotdiff_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetChangedFilesRequest) GetBaseSnapshotId() string {
+	if x != nil {
+		return x.BaseSnapshotId
+	}
+	return ""
+}
+
+func (x *GetChangedFilesRequest) GetTargetSnapshotId() string {
+	if x != nil {
+		return x.TargetSnapshotId
+	}
+	return ""
+}
+
+func (x *GetChangedFilesRequest) GetStartingToken() string {
+	if x != nil {
+		return x.StartingToken
+	}
+	return ""
+}
+
+func (x *GetChangedFilesRequest) GetMaxResults() int32 {
+	if x != nil {
+		return x.MaxResults
+	}
+	return 0
+}
+
+type ChangedFile struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Path relative to the root of the volume.
+	Path       string     `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	ChangeType ChangeType `protobuf:"varint,2,opt,name=change_type,json=changeType,proto3,enum=spectrumscale.csi.snapshotdiff.v1.ChangeType" json:"change_type,omitempty"`
+	// Size in the target snapshot, 0 for deleted files.
+	SizeBytes     int64 `protobuf:"varint,3,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChangedFile) Reset() {
+	*x = ChangedFile{}
+	mi := &file_snapshotdiff_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangedFile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangedFile) ProtoMessage() {}
+
+func (x *ChangedFile) ProtoReflect() protoreflect.Message {
+	mi := &file_snapshotdiff_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangedFile.ProtoReflect.Descriptor instead.
+func (*ChangedFile) Descriptor() ([]byte, []int) {
+	return file_snapshotdiff_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ChangedFile) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *ChangedFile) GetChangeType() ChangeType {
+	if x != nil {
+		return x.ChangeType
+	}
+	return ChangeType_CHANGE_TYPE_UNSPECIFIED
+}
+
+func (x *ChangedFile) GetSizeBytes() int64 {
+	if x != nil {
+		return x.SizeBytes
+	}
+	return 0
+}
+
+type GetChangedFilesResponse struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	ChangedFiles []*ChangedFile         `protobuf:"bytes,1,rep,name=changed_files,json=changedFiles,proto3" json:"changed_files,omitempty"`
+	// Token to resume the stream after this response.
+	NextToken     string `protobuf:"bytes,2,opt,name=next_token,json=nextToken,proto3" json:"next_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetChangedFilesResponse) Reset() {
+	*x = GetChangedFilesResponse{}
+	mi := &file_snapshotdiff_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetChangedFilesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetChangedFilesResponse) ProtoMessage() {}
+
+func (x *GetChangedFilesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_snapshotdiff_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetChangedFilesResponse.ProtoReflect.Descriptor instead.
+func (*GetChangedFilesResponse) Descriptor() ([]byte, []int) {
+	return file_snapshotdiff_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetChangedFilesResponse) GetChangedFiles() []*ChangedFile {
+	if x != nil {
+		return x.ChangedFiles
+	}
+	return nil
+}
+
+func (x *GetChangedFilesResponse) GetNextToken() string {
+	if x != nil {
+		return x.NextToken
+	}
+	return ""
+}
+
+var File_snapshotdiff_proto protoreflect.FileDescriptor
+
+const file_snapshotdiff_proto_rawDesc = "" +
+	"\n" +
+	"\x12snapshotdiff.proto\x12!spectrumscale.csi.snapshotdiff.v1\"\xb8\x01\n" +
+	"\x16GetChangedFilesRequest\x12(\n" +
+	"\x10base_snapshot_id\x18\x01 \x01(\tR\x0ebaseSnapshotId\x12,\n" +
+	"\x12target_snapshot_id\x18\x02 \x01(\tR\x10targetSnapshotId\x12%\n" +
+	"\x0estarting_token\x18\x03 \x01(\tR\rstartingToken\x12\x1f\n" +
+	"\vmax_results\x18\x04 \x01(\x05R\n" +
+	"maxResults\"\x90\x01\n" +
+	"\vChangedFile\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12N\n" +
+	"\vchange_type\x18\x02 \x01(\x0e2-.spectrumscale.csi.snapshotdiff.v1.ChangeTypeR\n" +
+	"changeType\x12\x1d\n" +
+	"\n" +
+	"size_bytes\x18\x03 \x01(\x03R\tsizeBytes\"\x8d\x01\n" +
+	"\x17GetChangedFilesResponse\x12S\n" +
+	"\rchanged_files\x18\x01 \x03(\v2..spectrumscale.csi.snapshotdiff.v1.ChangedFileR\fchangedFiles\x12\x1d\n" +
+	"\n" +
+	"next_token\x18\x02 \x01(\tR\tnextToken*Q\n" +
+	"\n" +
+	"ChangeType\x12\x1b\n" +
+	"\x17CHANGE_TYPE_UNSPECIFIED\x10\x00\x12\v\n" +
+	"\aCREATED\x10\x01\x12\f\n" +
+	"\bMODIFIED\x10\x02\x12\v\n" +
+	"\aDELETED\x10\x032\x9d\x01\n" +
+	"\fSnapshotDiff\x12\x8c\x01\n" +
+	"\x0fGetChangedFiles\x129.spectrumscale.csi.snapshotdiff.v1.GetChangedFilesRequest\x1a:.spectrumscale.csi.snapshotdiff.v1.GetChangedFilesResponse\"\x000\x01BIZGgithub.com/IBM/ibm-spectrum-scale-csi/driver/csiplugin/pkg/snapshotdiffb\x06proto3"
+
+var (
+	file_snapshotdiff_proto_rawDescOnce sync.Once
+	file_snapshotdiff_proto_rawDescData []byte
+)
+
+func file_snapshotdiff_proto_rawDescGZIP() []byte {
+	file_snapshotdiff_proto_rawDescOnce.Do(func() {
+		file_snapshotdiff_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_snapshotdiff_proto_rawDesc), len(file_snapshotdiff_proto_rawDesc)))
+	})
+	return file_snapshotdiff_proto_rawDescData
+}
+
+var file_snapshotdiff_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_snapshotdiff_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_snapshotdiff_proto_goTypes = []any{
+	(ChangeType)(0),                 // 0: spectrumscale.csi.snapshotdiff.v1.ChangeType
+	(*GetChangedFilesRequest)(nil),  // 1: spectrumscale.csi.snapshotdiff.v1.GetChangedFilesRequest
+	(*ChangedFile)(nil),             // 2: spectrumscale.csi.snapshotdiff.v1.ChangedFile
+	(*GetChangedFilesResponse)(nil), // 3: spectrumscale.csi.snapshotdiff.v1.GetChangedFilesResponse
+}
+var file_snapshotdiff_proto_depIdxs = []int32{
+	0, // 0: spectrumscale.csi.snapshotdiff.v1.ChangedFile.change_type:type_name -> spectrumscale.csi.snapshotdiff.v1.ChangeType
+	2, // 1: spectrumscale.csi.snapshotdiff.v1.GetChangedFilesResponse.changed_files:type_name -> spectrumscale.csi.snapshotdiff.v1.ChangedFile
+	1, // 2: spectrumscale.csi.snapshotdiff.v1.SnapshotDiff.GetChangedFiles:input_type -> spectrumscale.csi.snapshotdiff.v1.GetChangedFilesRequest
+	3, // 3: spectrumscale.csi.snapshotdiff.v1.SnapshotDiff.GetChangedFiles:output_type -> spectrumscale.csi.snapshotdiff.v1.GetChangedFilesResponse
+	3, // [3:4] is the sub-list for method output_type
+	2, // [2:3] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_snapshotdiff_proto_init() }
+func file_snapshotdiff_proto_init() {
+	if File_snapshotdiff_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_snapshotdiff_proto_rawDesc), len(file_snapshotdiff_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_snapshotdiff_proto_goTypes,
+		DependencyIndexes: file_snapshotdiff_proto_depIdxs,
+		EnumInfos:         file_snapshotdiff_proto_enumTypes,
+		MessageInfos:      file_snapshotdiff_proto_msgTypes,
+	}.Build()
+	File_snapshotdiff_proto = out.File
+	file_snapshotdiff_proto_goTypes = nil
+	file_snapshotdiff_proto_depIdxs = nil
+}
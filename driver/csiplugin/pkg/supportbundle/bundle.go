@@ -0,0 +1,245 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package supportbundle collects the Kubernetes resources, logs and IBM
+// Storage Scale state of IBM Storage Scale CSI driver into a single archive
+// for problem determination.
+package supportbundle
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"time"
+
+	"github.com/IBM/ibm-spectrum-scale-csi/driver/csiplugin/connectors"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/yaml"
+)
+
+const manifestFile = "manifest.json"
+
+// Options configures the content of a support bundle.
+type Options struct {
+	// Namespace is the namespace of the operator and the driver
+	Namespace  string
+	DriverName string
+	// DriverSelector is the label selector of the driver pods, their nodes
+	// are the nodes whose persistent logs are collected
+	DriverSelector string
+	// Since limits the pod logs to the given duration, all logs are
+	// collected if zero
+	Since time.Duration
+	// Previous collects the logs of the previous instance of the containers
+	Previous bool
+	// PersistentLogs collects the persistent logs of the driver from the
+	// nodes of the driver pods through a short-lived collector pod per node
+	PersistentLogs bool
+	// QueryClusters collects the filesystems, filesets and quotas of each
+	// IBM Storage Scale cluster through its GUI
+	QueryClusters bool
+}
+
+// Manifest describes the content of a support bundle, it is the last file
+// of the archive.
+type Manifest struct {
+	Namespace      string           `json:"namespace"`
+	DriverName     string           `json:"driverName"`
+	StartTime      time.Time        `json:"startTime"`
+	CompletionTime time.Time        `json:"completionTime"`
+	Options        Options          `json:"options"`
+	Images         []ContainerImage `json:"images"`
+	Files          []File           `json:"files"`
+	// Errors are the parts of the bundle which could not be collected
+	Errors []string `json:"errors,omitempty"`
+}
+
+// File is a file of a support bundle.
+type File struct {
+	Path string `json:"path"`
+	Size int64  `json:"size"`
+}
+
+// ContainerImage is the image of a container of the driver namespace, it
+// identifies the versions of the operator, the driver and the sidecars.
+type ContainerImage struct {
+	Pod       string `json:"pod"`
+	Container string `json:"container"`
+	Image     string `json:"image"`
+	ImageID   string `json:"imageID,omitempty"`
+}
+
+// Collector creates support bundles.
+type Collector struct {
+	client        kubernetes.Interface
+	dynamicClient dynamic.Interface
+	conns         map[string]connectors.SpectrumScaleConnector
+	opts          Options
+}
+
+// NewCollector returns a Collector. conns are the connectors returned by
+// scaleconfig.NewConnectors, they are only used with Options.QueryClusters.
+func NewCollector(client kubernetes.Interface, dynamicClient dynamic.Interface, conns map[string]connectors.SpectrumScaleConnector, opts Options) *Collector {
+	return &Collector{client: client, dynamicClient: dynamicClient, conns: conns, opts: opts}
+}
+
+// Name returns the name of the top directory of a bundle created at t.
+func Name(t time.Time) string {
+	return fmt.Sprintf("ibm-spectrum-scale-csi-snap_%s", t.UTC().Format("20060102-150405"))
+}
+
+// Run writes a support bundle as gzip compressed tar archive to w. A part
+// of the bundle which can not be collected is recorded in the errors of the
+// manifest, Run only fails if the archive can not be written.
+func (c *Collector) Run(ctx context.Context, w io.Writer) (*Manifest, error) {
+	start := time.Now()
+	gz := gzip.NewWriter(w)
+	a := &archive{
+		tw:       tar.NewWriter(gz),
+		root:     Name(start),
+		modTime:  start,
+		manifest: &Manifest{Namespace: c.opts.Namespace, DriverName: c.opts.DriverName, StartTime: start.UTC(), Options: c.opts},
+	}
+
+	steps := []func(context.Context, *archive) error{
+		c.collectResources,
+		c.collectVolumes,
+		c.collectPodLogs,
+	}
+	if c.opts.PersistentLogs {
+		steps = append(steps, c.collectPersistentLogs)
+	}
+	if c.opts.QueryClusters {
+		steps = append(steps, c.collectClusters)
+	}
+	for _, step := range steps {
+		if err := step(ctx, a); err != nil {
+			return a.manifest, err
+		}
+		if ctx.Err() != nil {
+			a.failed("collection interrupted: %v", ctx.Err())
+			break
+		}
+	}
+
+	a.manifest.CompletionTime = time.Now().UTC()
+	data, err := json.MarshalIndent(a.manifest, "", "  ")
+	if err != nil {
+		return a.manifest, err
+	}
+	if err := a.add(manifestFile, data); err != nil {
+		return a.manifest, err
+	}
+	if err := a.tw.Close(); err != nil {
+		return a.manifest, err
+	}
+	return a.manifest, gz.Close()
+}
+
+// archive writes the files of a bundle below its root directory.
+type archive struct {
+	tw       *tar.Writer
+	root     string
+	modTime  time.Time
+	manifest *Manifest
+}
+
+// failed records a part of the bundle which could not be collected.
+func (a *archive) failed(format string, args ...interface{}) {
+	a.manifest.Errors = append(a.manifest.Errors, fmt.Sprintf(format, args...))
+}
+
+func (a *archive) add(name string, data []byte) error {
+	header := &tar.Header{
+		Name:    path.Join(a.root, name),
+		Mode:    0640,
+		Size:    int64(len(data)),
+		ModTime: a.modTime,
+	}
+	if err := a.tw.WriteHeader(header); err != nil {
+		return fmt.Errorf("unable to write %s to the support bundle: %w", name, err)
+	}
+	if _, err := a.tw.Write(data); err != nil {
+		return fmt.Errorf("unable to write %s to the support bundle: %w", name, err)
+	}
+	if name != manifestFile {
+		a.manifest.Files = append(a.manifest.Files, File{Path: name, Size: header.Size})
+	}
+	return nil
+}
+
+// addYAML adds obj as YAML file.
+func (a *archive) addYAML(name string, obj interface{}) error {
+	data, err := yaml.Marshal(obj)
+	if err != nil {
+		a.failed("unable to encode %s: %v", name, err)
+		return nil
+	}
+	return a.add(name, data)
+}
+
+// addJSON adds obj as JSON file.
+func (a *archive) addJSON(name string, obj interface{}) error {
+	data, err := json.MarshalIndent(obj, "", "  ")
+	if err != nil {
+		a.failed("unable to encode %s: %v", name, err)
+		return nil
+	}
+	return a.add(name, data)
+}
+
+// addStream adds the content of r, which is buffered in a temporary file as
+// the size of a tar entry must be known before its content. A read error is
+// recorded in the manifest and the content read so far is kept.
+func (a *archive) addStream(name string, r io.Reader) error {
+	tmp, err := os.CreateTemp("", "scale-csi-snap-")
+	if err != nil {
+		return fmt.Errorf("unable to create temporary file for %s: %w", name, err)
+	}
+	defer func() {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+	}()
+
+	size, err := io.Copy(tmp, r)
+	if err != nil {
+		a.failed("unable to read %s: %v", name, err)
+	}
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("unable to read temporary file of %s: %w", name, err)
+	}
+
+	header := &tar.Header{
+		Name:    path.Join(a.root, name),
+		Mode:    0640,
+		Size:    size,
+		ModTime: a.modTime,
+	}
+	if err := a.tw.WriteHeader(header); err != nil {
+		return fmt.Errorf("unable to write %s to the support bundle: %w", name, err)
+	}
+	if _, err := io.CopyN(a.tw, tmp, size); err != nil {
+		return fmt.Errorf("unable to write %s to the support bundle: %w", name, err)
+	}
+	a.manifest.Files = append(a.manifest.Files, File{Path: name, Size: size})
+	return nil
+}
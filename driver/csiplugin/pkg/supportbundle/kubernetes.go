@@ -0,0 +1,335 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package supportbundle
+
+import (
+	"context"
+	"fmt"
+	"path"
+
+	"github.com/IBM/ibm-spectrum-scale-csi/driver/csiplugin/pkg/scalehandle"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var csiScaleOperatorGVR = schema.GroupVersionResource{Group: "csi.ibm.com", Version: "v1", Resource: "csiscaleoperators"}
+
+// VolumeHandle is the decoded volume handle of a PersistentVolume of the
+// driver.
+type VolumeHandle struct {
+	PersistentVolume string `json:"persistentVolume"`
+	// Claim is the bound PersistentVolumeClaim as namespace/name
+	Claim            string `json:"claim,omitempty"`
+	VolumeHandle     string `json:"volumeHandle"`
+	StorageClassType string `json:"storageClassType,omitempty"`
+	VolumeType       string `json:"volumeType,omitempty"`
+	ClusterID        string `json:"clusterId,omitempty"`
+	FilesystemUUID   string `json:"filesystemUUID,omitempty"`
+	Filesystem       string `json:"filesystem,omitempty"`
+	ConsistencyGroup string `json:"consistencyGroup,omitempty"`
+	Fileset          string `json:"fileset,omitempty"`
+	// FilesetID is set instead of Fileset by volume handles of old releases
+	FilesetID string `json:"filesetId,omitempty"`
+	Path      string `json:"path,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+var (
+	storageClassTypes = map[string]string{
+		scalehandle.ClassicStorageClass:  "classic",
+		scalehandle.AdvancedStorageClass: "advanced",
+		scalehandle.CacheStorageClass:    "cache",
+	}
+	volumeTypes = map[string]string{
+		scalehandle.LightweightVolume:        "lightweight",
+		scalehandle.DependentFilesetVolume:   "dependentFileset",
+		scalehandle.IndependentFilesetVolume: "independentFileset",
+		scalehandle.ShallowCopyVolume:        "shallowCopy",
+		scalehandle.VMDiskOptimizedVolume:    "vmDiskOptimized",
+	}
+)
+
+// DecodeVolumeHandle decodes the volume handle of pv.
+func DecodeVolumeHandle(pv *corev1.PersistentVolume) VolumeHandle {
+	handle := VolumeHandle{PersistentVolume: pv.Name}
+	if pv.Spec.CSI == nil {
+		handle.Error = "not a CSI volume"
+		return handle
+	}
+	handle.VolumeHandle = pv.Spec.CSI.VolumeHandle
+	handle.Filesystem = pv.Spec.CSI.VolumeAttributes["volBackendFs"]
+	if ref := pv.Spec.ClaimRef; ref != nil {
+		handle.Claim = ref.Namespace + "/" + ref.Name
+	}
+
+	vh, err := scalehandle.DecodeVolume(pv.Spec.CSI.VolumeHandle)
+	if err != nil {
+		handle.Error = err.Error()
+		return handle
+	}
+	handle.StorageClassType = typeName(storageClassTypes, vh.StorageClassType)
+	handle.VolumeType = typeName(volumeTypes, vh.VolumeType)
+	handle.ClusterID = vh.ClusterID
+	handle.FilesystemUUID = vh.FilesystemUUID
+	handle.ConsistencyGroup = vh.ConsistencyGroup
+	handle.Fileset = vh.FilesetName
+	handle.FilesetID = vh.FilesetID
+	handle.Path = vh.Path
+	return handle
+}
+
+func typeName(names map[string]string, value string) string {
+	if name, ok := names[value]; ok {
+		return name
+	}
+	return value
+}
+
+// collectResources adds the CSIScaleOperator resources and the workloads,
+// configuration and events of the driver namespace, and the cluster scoped
+// resources of the driver.
+func (c *Collector) collectResources(ctx context.Context, a *archive) error {
+	ns := c.opts.Namespace
+	dir := path.Join("namespaces", ns)
+
+	if c.dynamicClient != nil {
+		crs, err := c.dynamicClient.Resource(csiScaleOperatorGVR).Namespace(ns).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			a.failed("unable to list CSIScaleOperators: %v", err)
+		} else if err := a.addYAML(path.Join(dir, "csiscaleoperators.yaml"), crs); err != nil {
+			return err
+		}
+	}
+
+	if configMaps, err := c.client.CoreV1().ConfigMaps(ns).List(ctx, metav1.ListOptions{}); err != nil {
+		a.failed("unable to list ConfigMaps: %v", err)
+	} else {
+		redactConfigMaps(configMaps.Items)
+		if err := a.addYAML(path.Join(dir, "configmaps.yaml"), configMaps); err != nil {
+			return err
+		}
+	}
+	if secrets, err := c.client.CoreV1().Secrets(ns).List(ctx, metav1.ListOptions{}); err != nil {
+		a.failed("unable to list Secrets: %v", err)
+	} else {
+		redactSecrets(secrets.Items)
+		if err := a.addYAML(path.Join(dir, "secrets.yaml"), secrets); err != nil {
+			return err
+		}
+	}
+
+	pods, err := c.client.CoreV1().Pods(ns).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		a.failed("unable to list Pods: %v", err)
+	} else {
+		for _, pod := range pods.Items {
+			for _, status := range append(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses...) {
+				a.manifest.Images = append(a.manifest.Images, ContainerImage{Pod: pod.Name, Container: status.Name, Image: status.Image, ImageID: status.ImageID})
+			}
+		}
+		if err := a.addYAML(path.Join(dir, "pods.yaml"), pods); err != nil {
+			return err
+		}
+	}
+
+	namespaced := []struct {
+		name string
+		list func() (interface{}, error)
+	}{
+		{"daemonsets", func() (interface{}, error) { return c.client.AppsV1().DaemonSets(ns).List(ctx, metav1.ListOptions{}) }},
+		{"deployments", func() (interface{}, error) { return c.client.AppsV1().Deployments(ns).List(ctx, metav1.ListOptions{}) }},
+		{"serviceaccounts", func() (interface{}, error) {
+			return c.client.CoreV1().ServiceAccounts(ns).List(ctx, metav1.ListOptions{})
+		}},
+		{"events", func() (interface{}, error) { return c.client.CoreV1().Events(ns).List(ctx, metav1.ListOptions{}) }},
+	}
+	for _, kind := range namespaced {
+		if err := c.addList(a, path.Join(dir, kind.name+".yaml"), kind.name, kind.list); err != nil {
+			return err
+		}
+	}
+
+	clusterScoped := []struct {
+		name string
+		list func() (interface{}, error)
+	}{
+		{"nodes", func() (interface{}, error) { return c.client.CoreV1().Nodes().List(ctx, metav1.ListOptions{}) }},
+		{"csinodes", func() (interface{}, error) { return c.client.StorageV1().CSINodes().List(ctx, metav1.ListOptions{}) }},
+		{"storageclasses", func() (interface{}, error) {
+			list, err := c.client.StorageV1().StorageClasses().List(ctx, metav1.ListOptions{})
+			if err != nil {
+				return nil, err
+			}
+			items := []storagev1.StorageClass{}
+			for _, sc := range list.Items {
+				if sc.Provisioner == c.opts.DriverName {
+					items = append(items, sc)
+				}
+			}
+			list.Items = items
+			return list, nil
+		}},
+		{"volumeattachments", func() (interface{}, error) {
+			list, err := c.client.StorageV1().VolumeAttachments().List(ctx, metav1.ListOptions{})
+			if err != nil {
+				return nil, err
+			}
+			items := []storagev1.VolumeAttachment{}
+			for _, va := range list.Items {
+				if va.Spec.Attacher == c.opts.DriverName {
+					items = append(items, va)
+				}
+			}
+			list.Items = items
+			return list, nil
+		}},
+	}
+	for _, kind := range clusterScoped {
+		if err := c.addList(a, path.Join("cluster", kind.name+".yaml"), kind.name, kind.list); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *Collector) addList(a *archive, name, kind string, list func() (interface{}, error)) error {
+	obj, err := list()
+	if err != nil {
+		a.failed("unable to list %s: %v", kind, err)
+		return nil
+	}
+	return a.addYAML(name, obj)
+}
+
+// driverVolumes returns the PersistentVolumes of the driver.
+func (c *Collector) driverVolumes(ctx context.Context) ([]corev1.PersistentVolume, error) {
+	list, err := c.client.CoreV1().PersistentVolumes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("unable to list PersistentVolumes: %w", err)
+	}
+	pvs := []corev1.PersistentVolume{}
+	for _, pv := range list.Items {
+		if pv.Spec.CSI != nil && pv.Spec.CSI.Driver == c.opts.DriverName {
+			pvs = append(pvs, pv)
+		}
+	}
+	return pvs, nil
+}
+
+// collectVolumes adds the PersistentVolumes of the driver with their decoded
+// volume handles, and their claims and the events of the claims.
+func (c *Collector) collectVolumes(ctx context.Context, a *archive) error {
+	pvs, err := c.driverVolumes(ctx)
+	if err != nil {
+		a.failed("%v", err)
+		return nil
+	}
+	if err := a.addYAML(path.Join("volumes", "persistentvolumes.yaml"), pvs); err != nil {
+		return err
+	}
+
+	handles := make([]VolumeHandle, 0, len(pvs))
+	claims := map[string]bool{}
+	for i := range pvs {
+		handle := DecodeVolumeHandle(&pvs[i])
+		handles = append(handles, handle)
+		if handle.Claim != "" {
+			claims[handle.Claim] = true
+		}
+	}
+	if err := a.addJSON(path.Join("volumes", "volumehandles.json"), handles); err != nil {
+		return err
+	}
+
+	pvcList, err := c.client.CoreV1().PersistentVolumeClaims(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		a.failed("unable to list PersistentVolumeClaims: %v", err)
+		return nil
+	}
+	pvcs := []corev1.PersistentVolumeClaim{}
+	for _, pvc := range pvcList.Items {
+		// claims which are not bound yet are included by their storage class
+		if claims[pvc.Namespace+"/"+pvc.Name] || (pvc.Spec.VolumeName == "" && pvc.Annotations["volume.kubernetes.io/storage-provisioner"] == c.opts.DriverName) {
+			pvcs = append(pvcs, pvc)
+			claims[pvc.Namespace+"/"+pvc.Name] = true
+		}
+	}
+	if err := a.addYAML(path.Join("volumes", "persistentvolumeclaims.yaml"), pvcs); err != nil {
+		return err
+	}
+
+	eventList, err := c.client.CoreV1().Events(metav1.NamespaceAll).List(ctx, metav1.ListOptions{FieldSelector: "involvedObject.kind=PersistentVolumeClaim"})
+	if err != nil {
+		a.failed("unable to list events of PersistentVolumeClaims: %v", err)
+		return nil
+	}
+	events := []corev1.Event{}
+	for _, event := range eventList.Items {
+		if claims[event.InvolvedObject.Namespace+"/"+event.InvolvedObject.Name] {
+			events = append(events, event)
+		}
+	}
+	return a.addYAML(path.Join("volumes", "persistentvolumeclaim-events.yaml"), events)
+}
+
+// collectPodLogs adds the logs of all containers of the pods in the driver
+// namespace.
+func (c *Collector) collectPodLogs(ctx context.Context, a *archive) error {
+	pods, err := c.client.CoreV1().Pods(c.opts.Namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		a.failed("unable to list Pods: %v", err)
+		return nil
+	}
+	for _, pod := range pods.Items {
+		for _, container := range append(pod.Spec.InitContainers, pod.Spec.Containers...) {
+			if err := c.addPodLog(ctx, a, pod.Name, container.Name, false); err != nil {
+				return err
+			}
+			if c.opts.Previous {
+				if err := c.addPodLog(ctx, a, pod.Name, container.Name, true); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (c *Collector) addPodLog(ctx context.Context, a *archive, pod, container string, previous bool) error {
+	opts := &corev1.PodLogOptions{Container: container, Previous: previous}
+	if c.opts.Since > 0 {
+		since := int64(c.opts.Since.Seconds())
+		opts.SinceSeconds = &since
+	}
+	name := path.Join("namespaces", c.opts.Namespace, "logs", pod, container+".log")
+	if previous {
+		name = path.Join("namespaces", c.opts.Namespace, "logs", pod, container+".previous.log")
+	}
+
+	stream, err := c.client.CoreV1().Pods(c.opts.Namespace).GetLogs(pod, opts).Stream(ctx)
+	if err != nil {
+		// containers without a previous instance are expected
+		if !previous {
+			a.failed("unable to get logs of container %s of pod %s: %v", container, pod, err)
+		}
+		return nil
+	}
+	defer func() { _ = stream.Close() }()
+	return a.addStream(name, stream)
+}
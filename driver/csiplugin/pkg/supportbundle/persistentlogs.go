@@ -0,0 +1,239 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package supportbundle
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	// PersistentLogHostDir is the directory of the persistent logs of the
+	// driver on the nodes
+	PersistentLogHostDir = "/var/adm/ras/scalecsilogs"
+	// PersistentLogPort is the port of the persistent log server of the
+	// collector pods
+	PersistentLogPort = 8095
+
+	driverContainerName     = "ibm-spectrum-scale-csi"
+	collectorContainerName  = "snap"
+	collectorLogDir         = "/host" + PersistentLogHostDir
+	collectorStartupTimeout = 2 * time.Minute
+	collectorPollInterval   = 2 * time.Second
+)
+
+// ServePersistentLogs serves the files below dir as gzip compressed tar
+// archive on addr. It is run by the driver binary in the collector pods of a
+// support bundle, which mount the persistent log directory of their node.
+func ServePersistentLogs(addr, dir string) error {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/gzip")
+		if err := writeDirArchive(w, dir); err != nil {
+			// the status was sent with the first byte, the truncated archive
+			// fails to decompress
+			fmt.Fprintf(os.Stderr, "unable to archive %s: %v\n", dir, err)
+		}
+	})
+	server := &http.Server{Addr: addr, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	return server.ListenAndServe()
+}
+
+// writeDirArchive writes the regular files below dir as gzip compressed tar
+// archive to w.
+func writeDirArchive(w io.Writer, dir string) error {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	err := filepath.WalkDir(dir, func(file string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		name, err := filepath.Rel(dir, file)
+		if err != nil {
+			return err
+		}
+		f, err := os.Open(filepath.Clean(file))
+		if err != nil {
+			return err
+		}
+		defer func() { _ = f.Close() }()
+
+		// a log file which grows while it is archived is cut at its size
+		// when the header was written
+		header := &tar.Header{Name: filepath.ToSlash(name), Mode: 0640, Size: info.Size(), ModTime: info.ModTime()}
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		_, err = io.CopyN(tw, f, info.Size())
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+// collectPersistentLogs adds the persistent logs of the nodes of the driver
+// pods. A collector pod with the driver image is started on each node, it
+// serves the persistent log directory of the node, which is read through
+// the pod proxy of the API server.
+func (c *Collector) collectPersistentLogs(ctx context.Context, a *archive) error {
+	pods, err := c.client.CoreV1().Pods(c.opts.Namespace).List(ctx, metav1.ListOptions{LabelSelector: c.opts.DriverSelector})
+	if err != nil {
+		a.failed("unable to list driver pods: %v", err)
+		return nil
+	}
+	nodes := map[string]bool{}
+	for i := range pods.Items {
+		driverPod := &pods.Items[i]
+		if driverPod.Spec.NodeName == "" || nodes[driverPod.Spec.NodeName] {
+			continue
+		}
+		nodes[driverPod.Spec.NodeName] = true
+		if err := c.collectNodeLogs(ctx, a, driverPod); err != nil {
+			return err
+		}
+	}
+	if len(nodes) == 0 {
+		a.failed("no driver pod matches %q, persistent logs are not collected", c.opts.DriverSelector)
+	}
+	return nil
+}
+
+func (c *Collector) collectNodeLogs(ctx context.Context, a *archive, driverPod *corev1.Pod) error {
+	node := driverPod.Spec.NodeName
+	pod, err := c.client.CoreV1().Pods(c.opts.Namespace).Create(ctx, collectorPod(driverPod), metav1.CreateOptions{})
+	if err != nil {
+		a.failed("unable to create persistent log collector pod on node %s: %v", node, err)
+		return nil
+	}
+	defer func() {
+		// the pod is deleted even if the collection was interrupted
+		grace := int64(0)
+		_ = c.client.CoreV1().Pods(pod.Namespace).Delete(context.WithoutCancel(ctx), pod.Name, metav1.DeleteOptions{GracePeriodSeconds: &grace})
+	}()
+
+	if err := c.waitForCollector(ctx, pod.Name); err != nil {
+		a.failed("persistent log collector pod %s on node %s did not start: %v", pod.Name, node, err)
+		return nil
+	}
+	stream, err := c.client.CoreV1().Pods(pod.Namespace).ProxyGet("http", pod.Name, strconv.Itoa(PersistentLogPort), "/", nil).Stream(ctx)
+	if err != nil {
+		a.failed("unable to get persistent logs of node %s: %v", node, err)
+		return nil
+	}
+	defer func() { _ = stream.Close() }()
+	return a.addStream(path.Join("nodes", node, "scalecsilogs.tar.gz"), stream)
+}
+
+// waitForCollector waits until the log server of a collector pod is ready.
+func (c *Collector) waitForCollector(ctx context.Context, name string) error {
+	ctx, cancel := context.WithTimeout(ctx, collectorStartupTimeout)
+	defer cancel()
+	for {
+		pod, err := c.client.CoreV1().Pods(c.opts.Namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		switch pod.Status.Phase {
+		case corev1.PodFailed, corev1.PodSucceeded:
+			return fmt.Errorf("pod terminated in phase %s", pod.Status.Phase)
+		case corev1.PodRunning:
+			for _, condition := range pod.Status.Conditions {
+				if condition.Type == corev1.PodReady && condition.Status == corev1.ConditionTrue {
+					return nil
+				}
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(collectorPollInterval):
+		}
+	}
+}
+
+// collectorPod returns a pod running the image of driverPod on its node with
+// the persistent log directory of the node. It uses the service account and
+// security context of the driver, which are allowed to mount host paths.
+func collectorPod(driverPod *corev1.Pod) *corev1.Pod {
+	container := corev1.Container{}
+	for _, c := range driverPod.Spec.Containers {
+		if c.Name == driverContainerName {
+			container = c
+		}
+	}
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "ibm-spectrum-scale-csi-snap-",
+			Namespace:    driverPod.Namespace,
+			Labels:       map[string]string{"app.kubernetes.io/name": "ibm-spectrum-scale-csi-snap"},
+		},
+		Spec: corev1.PodSpec{
+			NodeName:           driverPod.Spec.NodeName,
+			RestartPolicy:      corev1.RestartPolicyNever,
+			ServiceAccountName: driverPod.Spec.ServiceAccountName,
+			ImagePullSecrets:   driverPod.Spec.ImagePullSecrets,
+			Tolerations:        []corev1.Toleration{{Operator: corev1.TolerationOpExists}},
+			Containers: []corev1.Container{{
+				Name:            collectorContainerName,
+				Image:           container.Image,
+				ImagePullPolicy: corev1.PullIfNotPresent,
+				Args:            []string{fmt.Sprintf("--servePersistentLogs=:%d", PersistentLogPort)},
+				Ports:           []corev1.ContainerPort{{ContainerPort: PersistentLogPort, Protocol: corev1.ProtocolTCP}},
+				SecurityContext: container.SecurityContext,
+				ReadinessProbe: &corev1.Probe{
+					ProbeHandler: corev1.ProbeHandler{
+						TCPSocket: &corev1.TCPSocketAction{Port: intstr.FromInt(PersistentLogPort)},
+					},
+					PeriodSeconds: 2,
+				},
+				VolumeMounts: []corev1.VolumeMount{{Name: "logs", MountPath: collectorLogDir, ReadOnly: true}},
+			}},
+			Volumes: []corev1.Volume{{
+				Name: "logs",
+				VolumeSource: corev1.VolumeSource{
+					HostPath: &corev1.HostPathVolumeSource{Path: PersistentLogHostDir},
+				},
+			}},
+		},
+	}
+}
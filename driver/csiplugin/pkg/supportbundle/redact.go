@@ -0,0 +1,71 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package supportbundle
+
+import (
+	"regexp"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	redacted = "<redacted>"
+
+	// lastAppliedAnnotation holds a copy of the object applied with kubectl,
+	// including the data of secrets
+	lastAppliedAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+)
+
+var (
+	// sensitiveJSONRegex and sensitiveYAMLRegex match the values of
+	// credentials in ConfigMaps, e.g. a password in a JSON configuration
+	sensitiveJSONRegex = regexp.MustCompile(`(?i)("[^"]*(?:password|passwd|token|accesskey|access_key|secretkey|secret_key)[^"]*"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+	sensitiveYAMLRegex = regexp.MustCompile(`(?im)^(\s*[^:\s"]*(?:password|passwd|token|accesskey|access_key|secretkey|secret_key)[^:\s"]*\s*:\s*)\S.*$`)
+)
+
+// redactSecrets replaces the values of secrets, the keys are kept as they
+// show which credentials are configured.
+func redactSecrets(secrets []corev1.Secret) {
+	for i := range secrets {
+		secret := &secrets[i]
+		for key := range secret.Data {
+			secret.Data[key] = []byte(redacted)
+		}
+		for key := range secret.StringData {
+			secret.StringData[key] = redacted
+		}
+		delete(secret.Annotations, lastAppliedAnnotation)
+	}
+}
+
+// redactConfigMaps replaces the values of credentials in ConfigMaps.
+func redactConfigMaps(configMaps []corev1.ConfigMap) {
+	for i := range configMaps {
+		cm := &configMaps[i]
+		for key, value := range cm.Data {
+			cm.Data[key] = redactText(value)
+		}
+		if value, ok := cm.Annotations[lastAppliedAnnotation]; ok {
+			cm.Annotations[lastAppliedAnnotation] = redactText(value)
+		}
+	}
+}
+
+func redactText(text string) string {
+	text = sensitiveJSONRegex.ReplaceAllString(text, `${1}"`+redacted+`"`)
+	return sensitiveYAMLRegex.ReplaceAllString(text, "${1}"+redacted)
+}
@@ -0,0 +1,132 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package supportbundle
+
+import (
+	"context"
+	"path"
+	"sort"
+
+	"github.com/IBM/ibm-spectrum-scale-csi/driver/csiplugin/connectors"
+	"github.com/IBM/ibm-spectrum-scale-csi/driver/csiplugin/pkg/scaleconfig"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// clusterState is the state of an IBM Storage Scale cluster as returned by
+// its GUI.
+type clusterState struct {
+	ClusterID   string                     `json:"clusterId"`
+	Version     string                     `json:"version,omitempty"`
+	Summary     connectors.ClusterSummary  `json:"summary"`
+	Filesystems []connectors.FileSystem_v2 `json:"filesystems"`
+}
+
+// filesetQuota is the quota of a fileset used by a PersistentVolume.
+type filesetQuota struct {
+	PersistentVolume string              `json:"persistentVolume"`
+	Filesystem       string              `json:"filesystem"`
+	Fileset          string              `json:"fileset"`
+	Quota            connectors.Quota_v2 `json:"quota"`
+	Error            string              `json:"error,omitempty"`
+}
+
+// collectClusters adds the filesystems and filesets of each cluster, and the
+// quotas of the filesets of the PersistentVolumes of the driver.
+func (c *Collector) collectClusters(ctx context.Context, a *archive) error {
+	clusterIDs := []string{}
+	for clusterID := range c.conns {
+		if clusterID != scaleconfig.Primary {
+			clusterIDs = append(clusterIDs, clusterID)
+		}
+	}
+	sort.Strings(clusterIDs)
+
+	pvs, err := c.driverVolumes(ctx)
+	if err != nil {
+		a.failed("%v", err)
+	}
+	for _, clusterID := range clusterIDs {
+		if err := c.collectCluster(ctx, a, clusterID, c.conns[clusterID], pvs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *Collector) collectCluster(ctx context.Context, a *archive, clusterID string, conn connectors.SpectrumScaleConnector, pvs []corev1.PersistentVolume) error {
+	dir := path.Join("scale", clusterID)
+	state := clusterState{ClusterID: clusterID}
+	summary, err := conn.GetClusterSummary(ctx)
+	if err != nil {
+		a.failed("unable to get summary of cluster %s: %v", clusterID, err)
+		return nil
+	}
+	state.Summary = summary
+	if state.Version, err = conn.GetScaleVersion(ctx); err != nil {
+		a.failed("unable to get version of cluster %s: %v", clusterID, err)
+	}
+
+	filesystems, err := conn.ListFilesystems(ctx)
+	if err != nil {
+		a.failed("unable to list filesystems of cluster %s: %v", clusterID, err)
+	}
+	names := make([]string, 0, len(filesystems))
+	for name := range filesystems {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	// the filesystems of the volume handles are identified by UUID
+	filesystemNames := map[string]string{}
+	for _, name := range names {
+		details, err := conn.GetFilesystemDetails(ctx, name)
+		if err != nil {
+			a.failed("unable to get filesystem %s of cluster %s: %v", name, clusterID, err)
+			continue
+		}
+		state.Filesystems = append(state.Filesystems, details)
+		filesystemNames[details.UUID] = name
+
+		filesets, err := conn.ListFilesets(ctx, name)
+		if err != nil {
+			a.failed("unable to list filesets of filesystem %s of cluster %s: %v", name, clusterID, err)
+			continue
+		}
+		if err := a.addJSON(path.Join(dir, "filesets-"+name+".json"), filesets); err != nil {
+			return err
+		}
+	}
+	if err := a.addJSON(path.Join(dir, "cluster.json"), state); err != nil {
+		return err
+	}
+
+	quotas := []filesetQuota{}
+	for i := range pvs {
+		handle := DecodeVolumeHandle(&pvs[i])
+		if handle.ClusterID != clusterID || handle.Fileset == "" {
+			continue
+		}
+		quota := filesetQuota{PersistentVolume: handle.PersistentVolume, Filesystem: filesystemNames[handle.FilesystemUUID], Fileset: handle.Fileset}
+		if quota.Filesystem == "" {
+			quota.Error = "filesystem " + handle.FilesystemUUID + " not found"
+		} else if quota.Quota, err = conn.GetFilesetQuotaDetails(ctx, quota.Filesystem, quota.Fileset); err != nil {
+			quota.Error = err.Error()
+		}
+		quotas = append(quotas, quota)
+	}
+	return a.addJSON(path.Join(dir, "quotas.json"), quotas)
+}
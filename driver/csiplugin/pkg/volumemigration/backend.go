@@ -0,0 +1,89 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package volumemigration
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/IBM/ibm-spectrum-scale-csi/driver/csiplugin/connectors"
+	"github.com/IBM/ibm-spectrum-scale-csi/driver/csiplugin/pkg/scaleconfig"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Validator checks a rewritten volume handle against IBM Storage Scale.
+type Validator interface {
+	Validate(ctx context.Context, rewrite Rewrite) error
+}
+
+// backendValidator validates volume handles through the GUI of the cluster
+// owning the filesystem of a volume.
+type backendValidator struct {
+	conns map[string]connectors.SpectrumScaleConnector
+}
+
+// NewBackendValidator creates a connector for every cluster configured for the
+// driver, with the GUI credentials and certificates stored in namespace.
+func NewBackendValidator(ctx context.Context, client kubernetes.Interface, namespace string) (Validator, error) {
+	conns, err := scaleconfig.NewConnectors(ctx, client, namespace)
+	if err != nil {
+		return nil, err
+	}
+	return &backendValidator{conns: conns}, nil
+}
+
+// Validate checks that the fileset of the volume exists and is linked and that
+// the path of the new volume handle exists in the filesystem.
+func (v *backendValidator) Validate(ctx context.Context, rewrite Rewrite) error {
+	handle := rewrite.Handle
+	conn, ok := v.conns[handle.ClusterID]
+	if !ok {
+		return fmt.Errorf("cluster %s of the volume is not configured for the driver", handle.ClusterID)
+	}
+	fsName, err := conn.GetFilesystemName(ctx, handle.FilesystemUID)
+	if err != nil {
+		return fmt.Errorf("unable to get name of filesystem %s: %w", handle.FilesystemUID, err)
+	}
+
+	if handle.FilesetName != "" {
+		exists, err := conn.CheckIfFilesetExist(ctx, fsName, handle.FilesetName)
+		if err != nil {
+			return fmt.Errorf("unable to check fileset %s of filesystem %s: %w", handle.FilesetName, fsName, err)
+		}
+		if !exists {
+			return fmt.Errorf("fileset %s does not exist in filesystem %s", handle.FilesetName, fsName)
+		}
+		linked, err := conn.IsFilesetLinked(ctx, fsName, handle.FilesetName)
+		if err != nil {
+			return fmt.Errorf("unable to check link of fileset %s of filesystem %s: %w", handle.FilesetName, fsName, err)
+		}
+		if !linked {
+			return fmt.Errorf("fileset %s of filesystem %s is not linked", handle.FilesetName, fsName)
+		}
+	}
+
+	if rewrite.RelativePath != "" {
+		present, err := conn.CheckIfFileDirPresent(ctx, fsName, rewrite.RelativePath)
+		if err != nil {
+			return fmt.Errorf("unable to check path %s in filesystem %s: %w", rewrite.RelativePath, fsName, err)
+		}
+		if !present {
+			return fmt.Errorf("path %s does not exist in filesystem %s", rewrite.RelativePath, fsName)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package volumemigration
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// State is the state of a PersistentVolume in the migration.
+type State string
+
+const (
+	StateMigrated State = "Migrated"
+	StatePlanned  State = "Planned"
+	StateSkipped  State = "Skipped"
+	StateFailed   State = "Failed"
+	// StateRecreating is recorded after the source PersistentVolume and
+	// PersistentVolumeClaim were deleted and before they are recreated, a
+	// resumed migration recreates them from the backup.
+	StateRecreating State = "Recreating"
+)
+
+// Entry is the migration record of one PersistentVolume.
+type Entry struct {
+	PersistentVolume      string    `json:"persistentVolume"`
+	Namespace             string    `json:"namespace,omitempty"`
+	PersistentVolumeClaim string    `json:"persistentVolumeClaim,omitempty"`
+	VolumeType            string    `json:"volumeType,omitempty"`
+	OldVolumeHandle       string    `json:"oldVolumeHandle,omitempty"`
+	NewVolumeHandle       string    `json:"newVolumeHandle,omitempty"`
+	State                 State     `json:"state"`
+	Reason                string    `json:"reason,omitempty"`
+	BackupDir             string    `json:"backupDir,omitempty"`
+	UpdatedAt             time.Time `json:"updatedAt"`
+}
+
+// Checkpoint records the progress of a migration in a file so that an
+// interrupted or batched migration resumes where it stopped.
+type Checkpoint struct {
+	Mode    Mode              `json:"mode"`
+	Volumes map[string]*Entry `json:"volumes"`
+
+	path string
+	lock sync.Mutex
+}
+
+// LoadCheckpoint reads the checkpoint file at path, a new checkpoint is
+// returned if the file does not exist.
+func LoadCheckpoint(path string, mode Mode) (*Checkpoint, error) {
+	checkpoint := &Checkpoint{Mode: mode, Volumes: map[string]*Entry{}, path: path}
+	data, err := os.ReadFile(path) // #nosec G304 path is provided by the user running the migration
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return checkpoint, nil
+		}
+		return nil, fmt.Errorf("unable to read checkpoint file %s: %w", path, err)
+	}
+	if err := json.Unmarshal(data, checkpoint); err != nil {
+		return nil, fmt.Errorf("unable to parse checkpoint file %s: %w", path, err)
+	}
+	if checkpoint.Mode != mode {
+		return nil, fmt.Errorf("checkpoint file %s belongs to a migration in mode %s, not %s", path, checkpoint.Mode, mode)
+	}
+	if checkpoint.Volumes == nil {
+		checkpoint.Volumes = map[string]*Entry{}
+	}
+	return checkpoint, nil
+}
+
+// Get returns a copy of the entry of a PersistentVolume.
+func (c *Checkpoint) Get(pvName string) (Entry, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	entry, ok := c.Volumes[pvName]
+	if !ok {
+		return Entry{}, false
+	}
+	return *entry, true
+}
+
+// Record stores the entry of a PersistentVolume and writes the checkpoint file.
+func (c *Checkpoint) Record(entry Entry) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	entry.UpdatedAt = time.Now().UTC()
+	c.Volumes[entry.PersistentVolume] = &entry
+	return c.save()
+}
+
+// save writes the checkpoint to a temporary file which replaces the checkpoint
+// file, so that an interruption never leaves a partially written checkpoint.
+func (c *Checkpoint) save() error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0750); err != nil {
+		return err
+	}
+	tmp := c.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("unable to write checkpoint file %s: %w", tmp, err)
+	}
+	if err := os.Rename(tmp, c.path); err != nil {
+		return fmt.Errorf("unable to write checkpoint file %s: %w", c.path, err)
+	}
+	return nil
+}
@@ -0,0 +1,224 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package volumemigration
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/IBM/ibm-spectrum-scale-csi/driver/csiplugin/pkg/consistencygroup"
+	"github.com/IBM/ibm-spectrum-scale-csi/driver/csiplugin/pkg/scalehandle"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Mode selects how the path of the volume handles is rewritten.
+type Mode string
+
+const (
+	// ModeCSIToCNSA rewrites the volume handles of IBM Storage Scale CSI volumes
+	// to the mount path prefix of IBM Storage Scale container native.
+	ModeCSIToCNSA Mode = "csi-to-cnsa"
+	// ModeCSIPrimaryRemoval rewrites the volume handles of IBM Storage Scale CSI
+	// volumes created through the primary fileset to the actual fileset path.
+	ModeCSIPrimaryRemoval Mode = "csi-primary-removal"
+	// ModeCNSAPrimaryRemoval rewrites the volume handles of IBM Storage Scale
+	// container native volumes created through the primary fileset to the actual fileset path.
+	ModeCNSAPrimaryRemoval Mode = "cnsa-primary-removal"
+)
+
+const (
+	attrVolBackendFs   = "volBackendFs"
+	attrVolDirBasePath = "volDirBasePath"
+	attrParentFileset  = "parentFileset"
+	attrExistingVolume = "existingVolume"
+
+	// primaryFsetVolumesDir is the directory of the primary fileset below which
+	// the driver linked the volumes while the primary fileset was configured
+	primaryFsetVolumesDir = "/.volumes/"
+
+	cacheStorageClass consistencygroup.StorageClassType = 2
+	shallowCopyVolume consistencygroup.VolumeType       = 3
+	cacheVolume       consistencygroup.VolumeType       = 2
+)
+
+// AllowedCNSAPathPrefixes are the mount path prefixes supported by IBM Storage
+// Scale container native.
+var AllowedCNSAPathPrefixes = []string{"/ibm", "/var/mnt", "/mnt"}
+
+// ErrSkip is returned by RewriteVolumeHandle for volumes which do not need to be migrated.
+var ErrSkip = errors.New("migration not required")
+
+// Rewrite is the new volume handle of a PersistentVolume.
+type Rewrite struct {
+	OldVolumeHandle string
+	NewVolumeHandle string
+	// VolumeType describes the kind of volume, e.g. "0;2 independent fileset"
+	VolumeType string
+	// PathPrefix is the mount path prefix of the filesystem in the new handle
+	PathPrefix string
+	// RelativePath is the path of the volume relative to the filesystem root
+	RelativePath string
+	Handle       consistencygroup.VolumeHandle
+}
+
+// RewriteVolumeHandle returns the volume handle of a PersistentVolume after the
+// migration. pathPrefixes maps the volBackendFs of a volume to its new mount
+// path prefix, the prefix stored for the key "" applies to all filesystems.
+// ErrSkip is returned, wrapped with the reason, if the volume does not need to be migrated.
+func RewriteVolumeHandle(mode Mode, pv *corev1.PersistentVolume, pathPrefixes map[string]string) (Rewrite, error) {
+	rewrite := Rewrite{}
+	if pv.Spec.CSI == nil {
+		return rewrite, consistencygroup.ErrNoCsiVolume
+	}
+	rewrite.OldVolumeHandle = pv.Spec.CSI.VolumeHandle
+
+	handle, err := consistencygroup.GetVolumeHandle(pv.Spec.CSI)
+	if err != nil {
+		return rewrite, fmt.Errorf("unable to parse volume handle %s: %w", pv.Spec.CSI.VolumeHandle, err)
+	}
+	rewrite.Handle = handle
+	rewrite.VolumeType = volumeTypeName(handle)
+
+	attrs := pv.Spec.CSI.VolumeAttributes
+	fs := attrs[attrVolBackendFs]
+	if fs == "" {
+		return rewrite, fmt.Errorf("volume attribute %s is missing", attrVolBackendFs)
+	}
+
+	prefix, ok := pathPrefixes[fs]
+	if !ok {
+		prefix, ok = pathPrefixes[""]
+	}
+	if !ok || prefix == "" {
+		return rewrite, fmt.Errorf("%w: no path prefix defined for filesystem %s", ErrSkip, fs)
+	}
+	prefix = strings.TrimSuffix(prefix, "/")
+	rewrite.PathPrefix = prefix
+
+	oldPath := handle.FilesetLinkPath
+	primaryRemoval := mode == ModeCSIPrimaryRemoval || mode == ModeCNSAPrimaryRemoval
+	pvName := pv.Name
+	pvcName := ""
+	if pv.Spec.ClaimRef != nil {
+		pvcName = pv.Spec.ClaimRef.Name
+	}
+
+	var newPath string
+	switch {
+	case handle.StorageClassType == consistencygroup.ClassicStorageClass && handle.VolumeType == consistencygroup.LightweightVolume,
+		handle.StorageClassType == consistencygroup.ClassicStorageClass && handle.VolumeType == consistencygroup.DependentFilesetBasedVolume,
+		handle.StorageClassType == consistencygroup.ClassicStorageClass && handle.VolumeType == consistencygroup.IndependentFilesetBasedVolume:
+		if primaryRemoval && !strings.Contains(oldPath, primaryFsetVolumesDir) {
+			return rewrite, fmt.Errorf("%w: volume is not linked below the primary fileset", ErrSkip)
+		}
+		newPath = classicVolumePath(handle.VolumeType, prefix, fs, pvName, pvcName, attrs)
+
+	case handle.StorageClassType == consistencygroup.ConsistencyGroupStorageClass && handle.VolumeType == consistencygroup.DependentFilesetBasedVolume,
+		handle.VolumeType == shallowCopyVolume && (handle.StorageClassType == consistencygroup.ClassicStorageClass || handle.StorageClassType == consistencygroup.ConsistencyGroupStorageClass):
+		if primaryRemoval {
+			return rewrite, fmt.Errorf("%w: %s volumes do not use the primary fileset", ErrSkip, rewrite.VolumeType)
+		}
+		// keep everything from the filesystem name on and replace the prefix
+		index := strings.Index(oldPath, "/"+fs+"/")
+		if index < 0 {
+			return rewrite, fmt.Errorf("path %s of the volume handle is not below filesystem %s", oldPath, fs)
+		}
+		newPath = prefix + oldPath[index:]
+
+	case handle.StorageClassType == cacheStorageClass && handle.VolumeType == cacheVolume && primaryRemoval:
+		return rewrite, fmt.Errorf("%w: %s volumes do not use the primary fileset", ErrSkip, rewrite.VolumeType)
+
+	default:
+		return rewrite, fmt.Errorf("unknown volume handle type %d;%d", handle.StorageClassType, handle.VolumeType)
+	}
+
+	fsRoot := path.Join(prefix, fs)
+	rewrite.RelativePath = strings.TrimPrefix(strings.TrimPrefix(newPath, fsRoot), "/")
+
+	newHandle, err := scalehandle.DecodeVolume(rewrite.OldVolumeHandle)
+	if err != nil {
+		return rewrite, fmt.Errorf("unable to parse volume handle %s: %w", rewrite.OldVolumeHandle, err)
+	}
+	newHandle.Path = newPath
+	if rewrite.NewVolumeHandle, err = newHandle.Encode(); err != nil {
+		return rewrite, err
+	}
+	if rewrite.NewVolumeHandle == rewrite.OldVolumeHandle {
+		return rewrite, fmt.Errorf("%w: volume handle is already migrated", ErrSkip)
+	}
+	return rewrite, nil
+}
+
+// classicVolumePath returns the path of a volume of a classic StorageClass
+// below the mount path prefix.
+func classicVolumePath(volumeType consistencygroup.VolumeType, prefix, fs, pvName, pvcName string, attrs map[string]string) string {
+	volDirBasePath := attrs[attrVolDirBasePath]
+	parentFileset := attrs[attrParentFileset]
+	hasParent := parentFileset != "" && parentFileset != "root"
+	static := attrs[attrExistingVolume] == "yes"
+	dataDir := path.Join(pvName, pvName+"-data")
+
+	switch volumeType {
+	case consistencygroup.LightweightVolume:
+		return path.Join(prefix, fs, volDirBasePath, pvName)
+
+	case consistencygroup.DependentFilesetBasedVolume:
+		if static {
+			if hasParent {
+				return path.Join(prefix, fs, parentFileset, pvcName)
+			}
+			return path.Join(prefix, fs, pvcName)
+		}
+		if hasParent && volDirBasePath != "" {
+			return path.Join(prefix, fs, volDirBasePath, parentFileset, dataDir)
+		}
+		if hasParent {
+			return path.Join(prefix, fs, parentFileset, dataDir)
+		}
+		return path.Join(prefix, fs, dataDir)
+
+	default:
+		if static {
+			return path.Join(prefix, fs, pvcName)
+		}
+		if volDirBasePath != "" {
+			return path.Join(prefix, fs, volDirBasePath, dataDir)
+		}
+		return path.Join(prefix, fs, dataDir)
+	}
+}
+
+func volumeTypeName(handle consistencygroup.VolumeHandle) string {
+	kind := "unknown"
+	switch {
+	case handle.VolumeType == shallowCopyVolume:
+		kind = "shallow copy"
+	case handle.StorageClassType == cacheStorageClass:
+		kind = "cache fileset"
+	case handle.StorageClassType == consistencygroup.ConsistencyGroupStorageClass:
+		kind = "consistency group fileset"
+	case handle.VolumeType == consistencygroup.LightweightVolume:
+		kind = "lightweight"
+	case handle.VolumeType == consistencygroup.DependentFilesetBasedVolume:
+		kind = "dependent fileset"
+	case handle.VolumeType == consistencygroup.IndependentFilesetBasedVolume:
+		kind = "independent fileset"
+	}
+	return fmt.Sprintf("%d;%d %s", handle.StorageClassType, handle.VolumeType, kind)
+}
@@ -0,0 +1,468 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package volumemigration rewrites the volume handles of the PersistentVolumes
+// of IBM Storage Scale CSI driver when the mount path of the filesystems
+// changes, by recreating the PersistentVolumes and PersistentVolumeClaims with
+// the same names and the new volume handle.
+package volumemigration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	pvBackupFile  = "pv.yaml"
+	pvcBackupFile = "pvc.yaml"
+
+	annBoundByController = "pv.kubernetes.io/bound-by-controller"
+	annBindCompleted     = "pv.kubernetes.io/bind-completed"
+
+	pollInterval = 2 * time.Second
+)
+
+// Options configures a migration run.
+type Options struct {
+	Mode Mode
+	// DriverName selects the PersistentVolumes to migrate.
+	DriverName string
+	// PathPrefixes maps a volBackendFs to its new mount path prefix, the key ""
+	// applies to all filesystems.
+	PathPrefixes map[string]string
+	// DryRun prints the volume handle changes without changing any object.
+	DryRun bool
+	// BatchSize is the maximum number of PersistentVolumes processed in this
+	// run, 0 processes all of them.
+	BatchSize int
+	// BackupDir receives a copy of every PersistentVolume and PersistentVolumeClaim before it is deleted.
+	BackupDir string
+	// Timeout is the time to wait for the deletion and binding of an object.
+	Timeout time.Duration
+}
+
+// Migrator migrates the PersistentVolumes of the driver.
+type Migrator struct {
+	client     kubernetes.Interface
+	validator  Validator
+	checkpoint *Checkpoint
+	opts       Options
+	out        io.Writer
+}
+
+// NewMigrator returns a Migrator. The rewritten volume handles are not checked
+// against IBM Storage Scale if validator is nil.
+func NewMigrator(client kubernetes.Interface, validator Validator, checkpoint *Checkpoint, opts Options, out io.Writer) *Migrator {
+	return &Migrator{
+		client:     client,
+		validator:  validator,
+		checkpoint: checkpoint,
+		opts:       opts,
+		out:        out,
+	}
+}
+
+// Run migrates the PersistentVolumes which are not completed in the checkpoint
+// yet, up to the batch size, and returns the report of all PersistentVolumes.
+func (m *Migrator) Run(ctx context.Context) (*Report, error) {
+	report := &Report{Mode: m.opts.Mode, DryRun: m.opts.DryRun, StartTime: time.Now().UTC()}
+
+	pvList, err := m.client.CoreV1().PersistentVolumes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("unable to list PersistentVolumes: %w", err)
+	}
+	pvs := []corev1.PersistentVolume{}
+	existing := map[string]bool{}
+	for _, pv := range pvList.Items {
+		if pv.Spec.CSI != nil && pv.Spec.CSI.Driver == m.opts.DriverName {
+			pvs = append(pvs, pv)
+			existing[pv.Name] = true
+		}
+	}
+	sort.Slice(pvs, func(i, j int) bool { return pvs[i].Name < pvs[j].Name })
+	fmt.Fprintf(m.out, "Found %d PersistentVolumes of driver %s\n", len(pvs), m.opts.DriverName)
+
+	processed := 0
+	// PersistentVolumes deleted by an interrupted run are recreated first
+	for _, name := range m.interruptedVolumes(existing) {
+		entry, _ := m.checkpoint.Get(name)
+		if m.opts.DryRun {
+			fmt.Fprintf(m.out, "PersistentVolume %s was deleted by an interrupted migration and would be recreated from %s\n", name, entry.BackupDir)
+			entry.State = StatePlanned
+		} else {
+			fmt.Fprintf(m.out, "Recreating PersistentVolume %s deleted by an interrupted migration\n", name)
+			entry = m.recreateFromBackup(ctx, entry)
+			if err := m.checkpoint.Record(entry); err != nil {
+				return nil, err
+			}
+		}
+		processed++
+		report.Summary.Total++
+		report.add(entry)
+	}
+
+	report.Summary.Total += len(pvs)
+	for i := range pvs {
+		pv := &pvs[i]
+		if entry, ok := m.checkpoint.Get(pv.Name); ok && (entry.State == StateMigrated || entry.State == StateSkipped) && entry.NewVolumeHandle == pv.Spec.CSI.VolumeHandle {
+			report.add(entry)
+			continue
+		}
+		if err := ctx.Err(); err != nil || (m.opts.BatchSize > 0 && processed >= m.opts.BatchSize) {
+			report.Summary.Remaining++
+			continue
+		}
+		processed++
+
+		fmt.Fprintf(m.out, "\n[%d/%d] Processing PersistentVolume %s\n", i+1, len(pvs), pv.Name)
+		entry := m.migrate(ctx, pv)
+		switch entry.State {
+		case StateFailed, StateRecreating:
+			fmt.Fprintf(m.out, "Failed to migrate PersistentVolume %s: %s\n", pv.Name, entry.Reason)
+		case StateSkipped:
+			fmt.Fprintf(m.out, "Skipped PersistentVolume %s: %s\n", pv.Name, entry.Reason)
+		case StateMigrated:
+			fmt.Fprintf(m.out, "Migrated PersistentVolume %s and PersistentVolumeClaim %s/%s\n", pv.Name, entry.Namespace, entry.PersistentVolumeClaim)
+		}
+		if !m.opts.DryRun {
+			if err := m.checkpoint.Record(entry); err != nil {
+				return nil, err
+			}
+		}
+		report.add(entry)
+	}
+
+	report.CompletionTime = time.Now().UTC()
+	return report, nil
+}
+
+// interruptedVolumes returns the PersistentVolumes which were deleted but not
+// recreated by an earlier run.
+func (m *Migrator) interruptedVolumes(existing map[string]bool) []string {
+	names := []string{}
+	m.checkpoint.lock.Lock()
+	defer m.checkpoint.lock.Unlock()
+	for name, entry := range m.checkpoint.Volumes {
+		if entry.State == StateRecreating && !existing[name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// migrate rewrites the volume handle of one PersistentVolume.
+func (m *Migrator) migrate(ctx context.Context, pv *corev1.PersistentVolume) Entry {
+	entry := Entry{PersistentVolume: pv.Name, OldVolumeHandle: pv.Spec.CSI.VolumeHandle}
+	fail := func(format string, args ...interface{}) Entry {
+		entry.State = StateFailed
+		entry.Reason = fmt.Sprintf(format, args...)
+		return entry
+	}
+
+	if pv.Spec.ClaimRef == nil {
+		return fail("PersistentVolume is not bound to a PersistentVolumeClaim")
+	}
+	entry.Namespace = pv.Spec.ClaimRef.Namespace
+	entry.PersistentVolumeClaim = pv.Spec.ClaimRef.Name
+
+	rewrite, err := RewriteVolumeHandle(m.opts.Mode, pv, m.opts.PathPrefixes)
+	entry.VolumeType = rewrite.VolumeType
+	if errors.Is(err, ErrSkip) {
+		entry.State = StateSkipped
+		entry.NewVolumeHandle = entry.OldVolumeHandle
+		entry.Reason = err.Error()
+		return entry
+	}
+	if err != nil {
+		return fail("%v", err)
+	}
+	entry.NewVolumeHandle = rewrite.NewVolumeHandle
+
+	pvc, err := m.client.CoreV1().PersistentVolumeClaims(entry.Namespace).Get(ctx, entry.PersistentVolumeClaim, metav1.GetOptions{})
+	if err != nil {
+		return fail("unable to get PersistentVolumeClaim %s/%s: %v", entry.Namespace, entry.PersistentVolumeClaim, err)
+	}
+
+	if m.validator != nil {
+		if err := m.validator.Validate(ctx, rewrite); err != nil {
+			return fail("validation of volume handle %s failed: %v", rewrite.NewVolumeHandle, err)
+		}
+	}
+
+	if m.opts.DryRun {
+		fmt.Fprintf(m.out, "--- PersistentVolume/%s\n+++ PersistentVolume/%s\n", pv.Name, pv.Name)
+		fmt.Fprintf(m.out, "-  volumeHandle: %s\n+  volumeHandle: %s\n", entry.OldVolumeHandle, entry.NewVolumeHandle)
+		entry.State = StatePlanned
+		return entry
+	}
+
+	pod, err := m.podUsingClaim(ctx, pvc)
+	if err != nil {
+		return fail("unable to list pods in namespace %s: %v", entry.Namespace, err)
+	}
+	if pod != "" {
+		return fail("PersistentVolumeClaim is used by pod %s, scale down the workload before the migration", pod)
+	}
+
+	entry.BackupDir = filepath.Join(m.opts.BackupDir, entry.Namespace, entry.PersistentVolumeClaim)
+	if err := backup(entry.BackupDir, pv, pvc); err != nil {
+		return fail("unable to back up PersistentVolume and PersistentVolumeClaim: %v", err)
+	}
+
+	if pv.Spec.PersistentVolumeReclaimPolicy != corev1.PersistentVolumeReclaimRetain {
+		fmt.Fprintf(m.out, "Setting reclaim policy to Retain for PersistentVolume %s\n", pv.Name)
+		retained := pv.DeepCopy()
+		retained.Spec.PersistentVolumeReclaimPolicy = corev1.PersistentVolumeReclaimRetain
+		if _, err := m.client.CoreV1().PersistentVolumes().Update(ctx, retained, metav1.UpdateOptions{}); err != nil {
+			return fail("unable to set reclaim policy to Retain: %v", err)
+		}
+	}
+
+	entry.State = StateRecreating
+	if err := m.checkpoint.Record(entry); err != nil {
+		return fail("%v", err)
+	}
+
+	fmt.Fprintf(m.out, "Deleting PersistentVolumeClaim %s/%s and PersistentVolume %s\n", entry.Namespace, entry.PersistentVolumeClaim, pv.Name)
+	if err := m.deleteClaimAndVolume(ctx, entry); err != nil {
+		entry.Reason = err.Error()
+		return entry
+	}
+	return m.recreate(ctx, entry, pv, pvc)
+}
+
+// deleteClaimAndVolume deletes the PersistentVolumeClaim and the PersistentVolume
+// of an entry and waits until both are gone.
+func (m *Migrator) deleteClaimAndVolume(ctx context.Context, entry Entry) error {
+	claims := m.client.CoreV1().PersistentVolumeClaims(entry.Namespace)
+	err := claims.Delete(ctx, entry.PersistentVolumeClaim, metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("unable to delete PersistentVolumeClaim: %v", err)
+	}
+	err = m.waitUntil(ctx, func(ctx context.Context) (bool, error) {
+		_, err := claims.Get(ctx, entry.PersistentVolumeClaim, metav1.GetOptions{})
+		return apierrors.IsNotFound(err), ignoreNotFound(err)
+	})
+	if err != nil {
+		return fmt.Errorf("PersistentVolumeClaim was not deleted: %v", err)
+	}
+
+	volumes := m.client.CoreV1().PersistentVolumes()
+	pv, err := volumes.Get(ctx, entry.PersistentVolume, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("unable to get PersistentVolume: %v", err)
+	}
+	attacherFinalizer := "external-attacher/" + strings.ReplaceAll(m.opts.DriverName, ".", "-")
+	finalizers := []string{}
+	for _, finalizer := range pv.Finalizers {
+		if finalizer != attacherFinalizer {
+			finalizers = append(finalizers, finalizer)
+		}
+	}
+	if len(finalizers) != len(pv.Finalizers) {
+		fmt.Fprintf(m.out, "Removing %s finalizer from PersistentVolume %s\n", attacherFinalizer, pv.Name)
+		pv.Finalizers = finalizers
+		if _, err := volumes.Update(ctx, pv, metav1.UpdateOptions{}); err != nil {
+			return fmt.Errorf("unable to remove finalizer %s: %v", attacherFinalizer, err)
+		}
+	}
+	if err := volumes.Delete(ctx, entry.PersistentVolume, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("unable to delete PersistentVolume: %v", err)
+	}
+	err = m.waitUntil(ctx, func(ctx context.Context) (bool, error) {
+		_, err := volumes.Get(ctx, entry.PersistentVolume, metav1.GetOptions{})
+		return apierrors.IsNotFound(err), ignoreNotFound(err)
+	})
+	if err != nil {
+		return fmt.Errorf("PersistentVolume was not deleted: %v", err)
+	}
+	return nil
+}
+
+// recreate creates the PersistentVolume with the new volume handle and the
+// PersistentVolumeClaim bound to it and waits for the binding.
+func (m *Migrator) recreate(ctx context.Context, entry Entry, pv *corev1.PersistentVolume, pvc *corev1.PersistentVolumeClaim) Entry {
+	fmt.Fprintf(m.out, "Recreating PersistentVolume %s and PersistentVolumeClaim %s/%s\n", entry.PersistentVolume, entry.Namespace, entry.PersistentVolumeClaim)
+
+	_, err := m.client.CoreV1().PersistentVolumes().Create(ctx, newPersistentVolume(pv, entry.NewVolumeHandle), metav1.CreateOptions{})
+	if err != nil && !apierrors.IsAlreadyExists(err) {
+		entry.State = StateRecreating
+		entry.Reason = fmt.Sprintf("unable to create PersistentVolume: %v", err)
+		return entry
+	}
+	claims := m.client.CoreV1().PersistentVolumeClaims(entry.Namespace)
+	_, err = claims.Create(ctx, newPersistentVolumeClaim(pvc, entry.PersistentVolume), metav1.CreateOptions{})
+	if err != nil && !apierrors.IsAlreadyExists(err) {
+		entry.State = StateRecreating
+		entry.Reason = fmt.Sprintf("unable to create PersistentVolumeClaim: %v", err)
+		return entry
+	}
+
+	err = m.waitUntil(ctx, func(ctx context.Context) (bool, error) {
+		claim, err := claims.Get(ctx, entry.PersistentVolumeClaim, metav1.GetOptions{})
+		if err != nil {
+			return false, ignoreNotFound(err)
+		}
+		return claim.Status.Phase == corev1.ClaimBound, nil
+	})
+	if err != nil {
+		entry.State = StateFailed
+		entry.Reason = fmt.Sprintf("PersistentVolumeClaim is not bound to the recreated PersistentVolume: %v", err)
+		return entry
+	}
+	entry.State = StateMigrated
+	entry.Reason = ""
+	return entry
+}
+
+// recreateFromBackup recreates the objects of an entry deleted by an interrupted run.
+func (m *Migrator) recreateFromBackup(ctx context.Context, entry Entry) Entry {
+	pv := &corev1.PersistentVolume{}
+	pvc := &corev1.PersistentVolumeClaim{}
+	for file, obj := range map[string]interface{}{pvBackupFile: pv, pvcBackupFile: pvc} {
+		data, err := os.ReadFile(filepath.Join(entry.BackupDir, file)) // #nosec G304 path is recorded in the checkpoint
+		if err == nil {
+			err = yaml.Unmarshal(data, obj)
+		}
+		if err != nil {
+			entry.Reason = fmt.Sprintf("unable to read backup %s: %v", filepath.Join(entry.BackupDir, file), err)
+			return entry
+		}
+	}
+	// the PersistentVolumeClaim may not have been deleted before the interruption
+	if err := m.deleteClaimAndVolume(ctx, entry); err != nil {
+		entry.Reason = err.Error()
+		return entry
+	}
+	return m.recreate(ctx, entry, pv, pvc)
+}
+
+// podUsingClaim returns the name of a pod which uses the PersistentVolumeClaim.
+func (m *Migrator) podUsingClaim(ctx context.Context, pvc *corev1.PersistentVolumeClaim) (string, error) {
+	pods, err := m.client.CoreV1().Pods(pvc.Namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return "", err
+	}
+	for _, pod := range pods.Items {
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		for _, volume := range pod.Spec.Volumes {
+			if volume.PersistentVolumeClaim != nil && volume.PersistentVolumeClaim.ClaimName == pvc.Name {
+				return pod.Name, nil
+			}
+		}
+	}
+	return "", nil
+}
+
+func (m *Migrator) waitUntil(ctx context.Context, condition wait.ConditionWithContextFunc) error {
+	return wait.PollUntilContextTimeout(ctx, pollInterval, m.opts.Timeout, true, condition)
+}
+
+// backup writes the PersistentVolume and PersistentVolumeClaim as YAML into dir.
+func backup(dir string, pv *corev1.PersistentVolume, pvc *corev1.PersistentVolumeClaim) error {
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return err
+	}
+	pv = pv.DeepCopy()
+	pv.APIVersion, pv.Kind = "v1", "PersistentVolume"
+	pvc = pvc.DeepCopy()
+	pvc.APIVersion, pvc.Kind = "v1", "PersistentVolumeClaim"
+	for file, obj := range map[string]interface{}{pvBackupFile: pv, pvcBackupFile: pvc} {
+		data, err := yaml.Marshal(obj)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(filepath.Join(dir, file), data, 0600); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// newPersistentVolume returns a copy of the source PersistentVolume with the new
+// volume handle, not bound to any claim yet.
+func newPersistentVolume(source *corev1.PersistentVolume, volumeHandle string) *corev1.PersistentVolume {
+	pv := &corev1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        source.Name,
+			Labels:      source.Labels,
+			Annotations: withoutAnnotations(source.Annotations, annBoundByController),
+		},
+		Spec: *source.Spec.DeepCopy(),
+	}
+	pv.Spec.ClaimRef = nil
+	pv.Spec.CSI.VolumeHandle = volumeHandle
+	return pv
+}
+
+// newPersistentVolumeClaim returns a copy of the source PersistentVolumeClaim
+// which binds to the PersistentVolume pvName.
+func newPersistentVolumeClaim(source *corev1.PersistentVolumeClaim, pvName string) *corev1.PersistentVolumeClaim {
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        source.Name,
+			Namespace:   source.Namespace,
+			Labels:      source.Labels,
+			Annotations: withoutAnnotations(source.Annotations, annBoundByController, annBindCompleted),
+		},
+		Spec: *source.Spec.DeepCopy(),
+	}
+	pvc.Spec.VolumeName = pvName
+	pvc.Spec.DataSource = nil
+	pvc.Spec.DataSourceRef = nil
+	return pvc
+}
+
+func withoutAnnotations(annotations map[string]string, keys ...string) map[string]string {
+	if annotations == nil {
+		return nil
+	}
+	result := map[string]string{}
+	for key, value := range annotations {
+		result[key] = value
+	}
+	for _, key := range keys {
+		delete(result, key)
+	}
+	return result
+}
+
+func ignoreNotFound(err error) error {
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,75 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package volumemigration
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Report is the machine-readable result of a migration run.
+type Report struct {
+	Mode           Mode      `json:"mode"`
+	DryRun         bool      `json:"dryRun"`
+	StartTime      time.Time `json:"startTime"`
+	CompletionTime time.Time `json:"completionTime"`
+	Summary        Summary   `json:"summary"`
+	Volumes        []Entry   `json:"volumes"`
+}
+
+// Summary counts the PersistentVolumes of a run per state.
+type Summary struct {
+	Total    int `json:"total"`
+	Migrated int `json:"migrated"`
+	Planned  int `json:"planned"`
+	Skipped  int `json:"skipped"`
+	Failed   int `json:"failed"`
+	// Remaining is the number of PersistentVolumes left for a later batch.
+	Remaining int `json:"remaining"`
+}
+
+func (r *Report) add(entry Entry) {
+	r.Volumes = append(r.Volumes, entry)
+	switch entry.State {
+	case StateMigrated:
+		r.Summary.Migrated++
+	case StatePlanned:
+		r.Summary.Planned++
+	case StateSkipped:
+		r.Summary.Skipped++
+	default:
+		r.Summary.Failed++
+	}
+}
+
+// Write stores the report as JSON at path.
+func (r *Report) Write(path string) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("unable to write report %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,584 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package scale
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/IBM/ibm-spectrum-scale-csi/driver/csiplugin/connectors"
+	"github.com/IBM/ibm-spectrum-scale-csi/driver/csiplugin/pkg/replication"
+	"github.com/IBM/ibm-spectrum-scale-csi/driver/csiplugin/utils"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// AFM-DR creates the recovery point objective snapshots with this prefix
+	afmRPOSnapshotPrefix = "psnap"
+
+	afmStateFailbackInProgress = "FailbackInProgress"
+)
+
+// volumeReplication holds the AFM-DR parameters of a volume.
+type volumeReplication struct {
+	ClusterId    string `json:"clusterId"`
+	Filesystem   string `json:"filesystem"`
+	TargetServer string `json:"targetServer"`
+	RPO          int    `json:"rpo"`
+}
+
+type ScaleReplicationServer struct {
+	Driver *ScaleDriver
+	replication.UnimplementedReplicationServer
+}
+
+func NewReplicationServer(d *ScaleDriver) *ScaleReplicationServer {
+	return &ScaleReplicationServer{
+		Driver: d,
+	}
+}
+
+// getVolumeReplicationOptions parses the AFM-DR parameters of a storageClass or
+// replication request. The secondary filesystem defaults to defaultFs.
+func getVolumeReplicationOptions(params map[string]string, defaultFs string) (*volumeReplication, bool, error) {
+	clusterId := params[connectors.UserSpecifiedReplicationClusterId]
+	filesystem := params[connectors.UserSpecifiedReplicationFs]
+	targetServer := params[connectors.UserSpecifiedReplicationServer]
+	rpo := params[connectors.UserSpecifiedReplicationRPO]
+
+	if clusterId == "" {
+		if filesystem != "" || targetServer != "" || rpo != "" {
+			return nil, false, status.Error(codes.InvalidArgument, "The parameters \"replicationFilesystem\", \"replicationTargetServer\" and \"replicationRPO\" must be specified together with \"replicationClusterId\"")
+		}
+		return nil, false, nil
+	}
+
+	volReplication := &volumeReplication{
+		ClusterId:    clusterId,
+		Filesystem:   defaultFs,
+		TargetServer: targetServer,
+	}
+	if filesystem != "" {
+		volReplication.Filesystem = filesystem
+	}
+	if rpo != "" {
+		rpoMinutes, err := strconv.Atoi(rpo)
+		if err != nil || rpoMinutes < 1 {
+			return nil, false, status.Errorf(codes.InvalidArgument, "invalid value specified for replicationRPO: %s, it must be a number of minutes greater than 0", rpo)
+		}
+		volReplication.RPO = rpoMinutes
+	}
+	return volReplication, true, nil
+}
+
+// enableFilesetReplication converts the fileset of a volume to an AFM-DR
+// primary and creates, and links, its secondary fileset at the same relative
+// path on the remote cluster. It can be called again for a fileset which is
+// already a primary to complete a partially enabled replication.
+func (cs *ScaleControllerServer) enableFilesetReplication(ctx context.Context, conn connectors.SpectrumScaleConnector, filesystemName string, filesetName string, volReplication *volumeReplication) error {
+	loggerId := utils.GetLoggerId(ctx)
+	klog.Infof("[%s] enableFilesetReplication - fileset [%s:%s] to filesystem [%s] of cluster [%s]", loggerId, filesystemName, filesetName, volReplication.Filesystem, volReplication.ClusterId)
+
+	remoteConn, err := cs.getConnFromClusterID(ctx, volReplication.ClusterId)
+	if err != nil {
+		return err
+	}
+
+	fsetInfo, err := conn.ListFileset(ctx, filesystemName, filesetName)
+	if err != nil {
+		return status.Error(codes.Internal, fmt.Sprintf("unable to list fileset [%v] in filesystem [%v]. Error: %v", filesetName, filesystemName, err))
+	}
+	if fsetInfo.Config.Path == "" || fsetInfo.Config.Path == filesetUnlinkedPath {
+		return status.Error(codes.FailedPrecondition, fmt.Sprintf("fileset [%v] in filesystem [%v] is not linked", filesetName, filesystemName))
+	}
+
+	mountPoint, err := conn.GetFilesystemMountpoint(ctx, filesystemName)
+	if err != nil {
+		return status.Error(codes.Internal, fmt.Sprintf("unable to get mount point of filesystem [%v]. Error: %v", filesystemName, err))
+	}
+	remoteMountPoint, err := remoteConn.GetFilesystemMountpoint(ctx, volReplication.Filesystem)
+	if err != nil {
+		return status.Error(codes.Internal, fmt.Sprintf("unable to get mount point of filesystem [%v] on cluster [%v]. Error: %v", volReplication.Filesystem, volReplication.ClusterId, err))
+	}
+	relPath := strings.Trim(strings.TrimPrefix(fsetInfo.Config.Path, mountPoint), "/")
+	secondaryPath := fmt.Sprintf("%s/%s", remoteMountPoint, relPath)
+
+	afmTarget := fmt.Sprintf("gpfs://%s", secondaryPath)
+	if volReplication.TargetServer != "" {
+		afmTarget = fmt.Sprintf("nfs://%s%s", volReplication.TargetServer, secondaryPath)
+	}
+
+	switch fsetInfo.AFM.AFMMode {
+	case "":
+		klog.Infof("[%s] enableFilesetReplication - converting fileset [%s:%s] to AFM-DR primary with target [%s]", loggerId, filesystemName, filesetName, afmTarget)
+		err = conn.RunAFMDRCommand(ctx, filesystemName, filesetName, connectors.AFMDRCommandRequest{
+			Action:    connectors.AfmDRConvertToPrimary,
+			AfmTarget: afmTarget,
+			AfmRPO:    volReplication.RPO,
+			Inband:    true,
+		})
+		if err != nil {
+			return status.Error(codes.Internal, fmt.Sprintf("unable to convert fileset [%v] in filesystem [%v] to AFM-DR primary. Error: %v", filesetName, filesystemName, err))
+		}
+		fsetInfo, err = conn.ListFileset(ctx, filesystemName, filesetName)
+		if err != nil {
+			return status.Error(codes.Internal, fmt.Sprintf("unable to list fileset [%v] in filesystem [%v]. Error: %v", filesetName, filesystemName, err))
+		}
+	case connectors.AfmModePrimary:
+		if fsetInfo.AFM.AFMTarget != afmTarget {
+			return status.Error(codes.AlreadyExists, fmt.Sprintf("fileset [%v] in filesystem [%v] is already replicated to [%v]", filesetName, filesystemName, fsetInfo.AFM.AFMTarget))
+		}
+		klog.Infof("[%s] enableFilesetReplication - fileset [%s:%s] is already AFM-DR primary", loggerId, filesystemName, filesetName)
+	default:
+		return status.Error(codes.FailedPrecondition, fmt.Sprintf("fileset [%v] in filesystem [%v] is an AFM fileset of mode [%v]", filesetName, filesystemName, fsetInfo.AFM.AFMMode))
+	}
+
+	if fsetInfo.AFM.AFMPrimaryID == "" {
+		return status.Error(codes.Internal, fmt.Sprintf("unable to get AFM primary ID of fileset [%v] in filesystem [%v]", filesetName, filesystemName))
+	}
+
+	secondaryExist, err := remoteConn.CheckIfFilesetExist(ctx, volReplication.Filesystem, filesetName)
+	if err != nil {
+		return status.Error(codes.Internal, fmt.Sprintf("unable to check fileset [%v] existence in filesystem [%v] on cluster [%v]. Error: %v", filesetName, volReplication.Filesystem, volReplication.ClusterId, err))
+	}
+	if !secondaryExist {
+		klog.Infof("[%s] enableFilesetReplication - creating AFM-DR secondary fileset [%s:%s] on cluster [%s]", loggerId, volReplication.Filesystem, filesetName, volReplication.ClusterId)
+		err = remoteConn.CreateAFMDRSecondaryFileset(ctx, volReplication.Filesystem, filesetName, fsetInfo.AFM.AFMPrimaryID, fsetInfo.Config.Comment)
+		if err != nil {
+			return status.Error(codes.Internal, fmt.Sprintf("unable to create secondary fileset [%v] in filesystem [%v] on cluster [%v]. Error: %v", filesetName, volReplication.Filesystem, volReplication.ClusterId, err))
+		}
+	}
+
+	linked, err := remoteConn.IsFilesetLinked(ctx, volReplication.Filesystem, filesetName)
+	if err != nil {
+		return status.Error(codes.Internal, fmt.Sprintf("unable to check if secondary fileset [%v] in filesystem [%v] is linked. Error: %v", filesetName, volReplication.Filesystem, err))
+	}
+	if !linked {
+		err = remoteConn.LinkFileset(ctx, volReplication.Filesystem, filesetName, secondaryPath)
+		if err != nil {
+			return status.Error(codes.Internal, fmt.Sprintf("linking secondary fileset [%v] in filesystem [%v] at path [%v] failed. Error: %v", filesetName, volReplication.Filesystem, secondaryPath, err))
+		}
+	}
+
+	klog.Infof("[%s] enableFilesetReplication - fileset [%s:%s] is replicated to [%s]", loggerId, filesystemName, filesetName, afmTarget)
+	return nil
+}
+
+// getReplicatedVolume returns the volume ID members of a volume which can be
+// replicated with AFM-DR.
+func getReplicatedVolume(volumeId string) (scaleVolId, error) {
+	if volumeId == "" {
+		return scaleVolId{}, status.Error(codes.InvalidArgument, "volume ID missing in request")
+	}
+
+	volumeIDMembers, err := getVolIDMembers(volumeId)
+	if err != nil {
+		return scaleVolId{}, status.Error(codes.NotFound, fmt.Sprintf("invalid volume ID [%v]. Error: %v", volumeId, err))
+	}
+	if !volumeIDMembers.IsFilesetBased || volumeIDMembers.StorageClassType != STORAGECLASS_CLASSIC ||
+		volumeIDMembers.VolType != FILE_INDEPENDENTFILESET_VOLUME {
+		return scaleVolId{}, status.Error(codes.InvalidArgument, fmt.Sprintf("replication is supported only for independent fileset based volumes, volume [%v]", volumeId))
+	}
+	return volumeIDMembers, nil
+}
+
+// getPrimaryFileset returns the connector, filesystem and fileset of the
+// volume on the cluster it was created on.
+func (rs *ScaleReplicationServer) getPrimaryFileset(ctx context.Context, volumeIDMembers scaleVolId) (connectors.SpectrumScaleConnector, string, string, error) {
+	conn, err := rs.Driver.cs.getConnFromClusterID(ctx, volumeIDMembers.ClusterId)
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	filesystemName, err := conn.GetFilesystemName(ctx, volumeIDMembers.FsUUID)
+	if err != nil {
+		return nil, "", "", status.Error(codes.Internal, fmt.Sprintf("unable to get filesystem Name for Filesystem Uid [%v] and clusterId [%v]. Error [%v]", volumeIDMembers.FsUUID, volumeIDMembers.ClusterId, err))
+	}
+	return conn, filesystemName, volumeIDMembers.FsetName, nil
+}
+
+// getReplicatedFileset returns the connector, filesystem and fileset of a
+// replicated volume on the primary cluster of this driver. That is the
+// primary fileset on the site the volume was created on, and its secondary
+// fileset on the disaster recovery site, so a failover is run by the driver
+// of the site taking over the volume. The filesystem of the secondary is
+// replicationFilesystem of params if given, otherwise it is searched.
+func (rs *ScaleReplicationServer) getReplicatedFileset(ctx context.Context, volumeId string, params map[string]string) (connectors.SpectrumScaleConnector, string, string, error) {
+	volumeIDMembers, err := getReplicatedVolume(volumeId)
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	localClusterId := rs.Driver.primary.PrimaryCid
+	if volumeIDMembers.ClusterId == localClusterId {
+		return rs.getPrimaryFileset(ctx, volumeIDMembers)
+	}
+
+	secondary, err := rs.Driver.cs.findSecondaryFileset(ctx, []string{localClusterId}, params[connectors.UserSpecifiedReplicationFs], volumeIDMembers.FsetName, "")
+	if err != nil {
+		return nil, "", "", err
+	}
+	if secondary == nil {
+		return nil, "", "", status.Error(codes.NotFound, fmt.Sprintf("volume [%v] of cluster [%v] has no AFM-DR secondary fileset on cluster [%v]", volumeId, volumeIDMembers.ClusterId, localClusterId))
+	}
+	return secondary.conn, secondary.filesystem, secondary.fileset, nil
+}
+
+// replicaFileset is the fileset of a replicated volume on a cluster.
+type replicaFileset struct {
+	conn       connectors.SpectrumScaleConnector
+	clusterId  string
+	filesystem string
+	fileset    string
+	info       connectors.Fileset_v2
+}
+
+// findSecondaryFileset returns the AFM-DR secondary of a fileset on one of
+// clusterIds, nil if there is none. A secondary has the name of its primary
+// and, when afmPrimaryID is given, its primary ID. It is also found after a
+// failover made it the acting primary. All filesystems of a cluster are
+// searched if filesystemName is empty.
+func (cs *ScaleControllerServer) findSecondaryFileset(ctx context.Context, clusterIds []string, filesystemName string, filesetName string, afmPrimaryID string) (*replicaFileset, error) {
+	loggerId := utils.GetLoggerId(ctx)
+	for _, clusterId := range clusterIds {
+		conn, err := cs.getConnFromClusterID(ctx, clusterId)
+		if err != nil {
+			return nil, err
+		}
+
+		filesystems := []string{filesystemName}
+		if filesystemName == "" {
+			fsMountpoints, err := conn.ListFilesystems(ctx)
+			if err != nil {
+				return nil, status.Error(codes.Internal, fmt.Sprintf("unable to list filesystems of cluster [%v]. Error: %v", clusterId, err))
+			}
+			filesystems = make([]string, 0, len(fsMountpoints))
+			for fsName := range fsMountpoints {
+				filesystems = append(filesystems, fsName)
+			}
+			sort.Strings(filesystems)
+		}
+
+		for _, fsName := range filesystems {
+			exist, err := conn.CheckIfFilesetExist(ctx, fsName, filesetName)
+			if err != nil {
+				return nil, status.Error(codes.Internal, fmt.Sprintf("unable to check fileset [%v] existence in filesystem [%v] on cluster [%v]. Error: %v", filesetName, fsName, clusterId, err))
+			}
+			if !exist {
+				continue
+			}
+			fsetInfo, err := conn.ListFileset(ctx, fsName, filesetName)
+			if err != nil {
+				return nil, status.Error(codes.Internal, fmt.Sprintf("unable to list fileset [%v] in filesystem [%v] on cluster [%v]. Error: %v", filesetName, fsName, clusterId, err))
+			}
+			isSecondary := fsetInfo.AFM.AFMMode == connectors.AfmModeSecondary ||
+				(fsetInfo.AFM.AFMMode == connectors.AfmModePrimary && fsetInfo.AFM.AFMTarget == "")
+			if !isSecondary || (afmPrimaryID != "" && fsetInfo.AFM.AFMPrimaryID != afmPrimaryID) {
+				continue
+			}
+			klog.V(4).Infof("[%s] findSecondaryFileset - secondary of fileset [%s] is [%s:%s] on cluster [%s]", loggerId, filesetName, fsName, filesetName, clusterId)
+			return &replicaFileset{conn: conn, clusterId: clusterId, filesystem: fsName, fileset: filesetName, info: fsetInfo}, nil
+		}
+	}
+	return nil, nil
+}
+
+// deleteFilesetReplication disables AFM-DR of the primary fileset of a volume
+// being deleted, deletes its RPO snapshots and its secondary fileset on the
+// remote cluster. A
+// secondary which is the acting primary after a failover holds the newer data
+// and is kept.
+func (cs *ScaleControllerServer) deleteFilesetReplication(ctx context.Context, conn connectors.SpectrumScaleConnector, clusterId string, filesystemName string, fsetInfo connectors.Fileset_v2) error {
+	loggerId := utils.GetLoggerId(ctx)
+	filesetName := fsetInfo.FilesetName
+
+	var remoteClusterIds []string
+	for id := range cs.Driver.connmap {
+		if id != "primary" && id != clusterId {
+			remoteClusterIds = append(remoteClusterIds, id)
+		}
+	}
+	sort.Strings(remoteClusterIds)
+	secondary, err := cs.findSecondaryFileset(ctx, remoteClusterIds, "", filesetName, fsetInfo.AFM.AFMPrimaryID)
+	if err != nil {
+		return err
+	}
+
+	klog.Infof("[%s] deleteFilesetReplication - disabling AFM-DR of fileset [%s:%s]", loggerId, filesystemName, filesetName)
+	err = conn.RunAFMDRCommand(ctx, filesystemName, filesetName, connectors.AFMDRCommandRequest{Action: connectors.AfmDRDisable})
+	if err != nil {
+		return status.Error(codes.Internal, fmt.Sprintf("unable to disable AFM-DR for fileset [%v] in filesystem [%v]. Error: %v", filesetName, filesystemName, err))
+	}
+
+	// the RPO snapshots are not needed without replication and would fail
+	// the snapshot check of the fileset delete
+	snapshots, err := conn.ListFilesetSnapshots(ctx, filesystemName, filesetName)
+	if err != nil {
+		return status.Error(codes.Internal, fmt.Sprintf("unable to list snapshots of fileset [%v] in filesystem [%v]. Error: %v", filesetName, filesystemName, err))
+	}
+	for _, snapshot := range snapshots {
+		if !strings.HasPrefix(snapshot.SnapshotName, afmRPOSnapshotPrefix) {
+			continue
+		}
+		err = conn.DeleteSnapshot(ctx, filesystemName, filesetName, snapshot.SnapshotName)
+		if err != nil {
+			return status.Error(codes.Internal, fmt.Sprintf("unable to delete RPO snapshot [%v] of fileset [%v] in filesystem [%v]. Error: %v", snapshot.SnapshotName, filesetName, filesystemName, err))
+		}
+	}
+
+	switch {
+	case secondary == nil:
+		klog.Infof("[%s] deleteFilesetReplication - fileset [%s:%s] has no secondary fileset", loggerId, filesystemName, filesetName)
+	case secondary.info.AFM.AFMMode != connectors.AfmModeSecondary:
+		klog.Infof("[%s] deleteFilesetReplication - secondary fileset [%s:%s] on cluster [%s] is the acting primary, it is kept", loggerId, secondary.filesystem, filesetName, secondary.clusterId)
+	case !strings.Contains(secondary.info.Config.Comment, connectors.FilesetComment):
+		klog.Infof("[%s] deleteFilesetReplication - secondary fileset [%s:%s] on cluster [%s] is not created by IBM Storage Scale CSI driver, it is kept", loggerId, secondary.filesystem, filesetName, secondary.clusterId)
+	default:
+		klog.Infof("[%s] deleteFilesetReplication - deleting secondary fileset [%s:%s] on cluster [%s]", loggerId, secondary.filesystem, filesetName, secondary.clusterId)
+		err = secondary.conn.DeleteFileset(ctx, secondary.filesystem, filesetName)
+		if err != nil && !strings.Contains(err.Error(), fsetNotFoundErrCode) && !strings.Contains(err.Error(), fsetNotFoundErrMsg) {
+			return status.Error(codes.Internal, fmt.Sprintf("unable to delete secondary fileset [%v] in filesystem [%v] on cluster [%v]. Error: %v", filesetName, secondary.filesystem, secondary.clusterId, err))
+		}
+	}
+	return nil
+}
+
+func (rs *ScaleReplicationServer) EnableVolumeReplication(ctx context.Context, req *replication.EnableVolumeReplicationRequest) (*replication.EnableVolumeReplicationResponse, error) {
+	loggerId := utils.GetLoggerId(ctx)
+	klog.Infof("[%s] EnableVolumeReplication - volume [%s], parameters [%v]", loggerId, req.GetVolumeId(), req.GetParameters())
+
+	volumeIDMembers, err := getReplicatedVolume(req.GetVolumeId())
+	if err != nil {
+		return nil, err
+	}
+	conn, filesystemName, filesetName, err := rs.getPrimaryFileset(ctx, volumeIDMembers)
+	if err != nil {
+		return nil, err
+	}
+
+	volReplication, isReplicationSpecified, err := getVolumeReplicationOptions(req.GetParameters(), filesystemName)
+	if err != nil {
+		return nil, err
+	}
+	if !isReplicationSpecified {
+		return nil, status.Error(codes.InvalidArgument, "The parameter \"replicationClusterId\" must be specified")
+	}
+
+	err = rs.Driver.cs.enableFilesetReplication(ctx, conn, filesystemName, filesetName, volReplication)
+	if err != nil {
+		klog.Errorf("[%s] EnableVolumeReplication - volume [%s]: %v", loggerId, req.GetVolumeId(), err)
+		return nil, err
+	}
+	return &replication.EnableVolumeReplicationResponse{}, nil
+}
+
+func (rs *ScaleReplicationServer) DisableVolumeReplication(ctx context.Context, req *replication.DisableVolumeReplicationRequest) (*replication.DisableVolumeReplicationResponse, error) {
+	loggerId := utils.GetLoggerId(ctx)
+	klog.Infof("[%s] DisableVolumeReplication - volume [%s]", loggerId, req.GetVolumeId())
+
+	conn, filesystemName, filesetName, err := rs.getReplicatedFileset(ctx, req.GetVolumeId(), req.GetParameters())
+	if err != nil {
+		return nil, err
+	}
+
+	fsetInfo, err := conn.ListFileset(ctx, filesystemName, filesetName)
+	if err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("unable to list fileset [%v] in filesystem [%v]. Error: %v", filesetName, filesystemName, err))
+	}
+
+	switch fsetInfo.AFM.AFMMode {
+	case "":
+		klog.Infof("[%s] DisableVolumeReplication - fileset [%s:%s] is not replicated", loggerId, filesystemName, filesetName)
+	case connectors.AfmModePrimary:
+		err = conn.RunAFMDRCommand(ctx, filesystemName, filesetName, connectors.AFMDRCommandRequest{Action: connectors.AfmDRDisable})
+		if err != nil {
+			return nil, status.Error(codes.Internal, fmt.Sprintf("unable to disable AFM-DR for fileset [%v] in filesystem [%v]. Error: %v", filesetName, filesystemName, err))
+		}
+		klog.Infof("[%s] DisableVolumeReplication - replication of fileset [%s:%s] to [%s] is disabled, the secondary fileset is kept", loggerId, filesystemName, filesetName, fsetInfo.AFM.AFMTarget)
+	default:
+		return nil, status.Error(codes.FailedPrecondition, fmt.Sprintf("replication can be disabled only on the primary, fileset [%v] in filesystem [%v] is of AFM mode [%v]", filesetName, filesystemName, fsetInfo.AFM.AFMMode))
+	}
+	return &replication.DisableVolumeReplicationResponse{}, nil
+}
+
+func (rs *ScaleReplicationServer) PromoteVolume(ctx context.Context, req *replication.PromoteVolumeRequest) (*replication.PromoteVolumeResponse, error) {
+	loggerId := utils.GetLoggerId(ctx)
+	klog.Infof("[%s] PromoteVolume - volume [%s], force [%v]", loggerId, req.GetVolumeId(), req.GetForce())
+
+	conn, filesystemName, filesetName, err := rs.getReplicatedFileset(ctx, req.GetVolumeId(), req.GetParameters())
+	if err != nil {
+		return nil, err
+	}
+
+	fsetInfo, err := conn.ListFileset(ctx, filesystemName, filesetName)
+	if err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("unable to list fileset [%v] in filesystem [%v]. Error: %v", filesetName, filesystemName, err))
+	}
+
+	afmdrReq := connectors.AFMDRCommandRequest{}
+	switch {
+	case fsetInfo.AFM.AFMMode == connectors.AfmModeSecondary:
+		// failover, the secondary becomes the acting primary
+		afmdrReq.Action = connectors.AfmDRFailoverToSecondary
+		afmdrReq.NoRestore = req.GetForce()
+	case fsetInfo.AFM.AFMMode == connectors.AfmModePrimary && fsetInfo.State.AFMState == afmStateFailbackInProgress:
+		// complete the failback to the old primary
+		afmdrReq.Action = connectors.AfmDRFailbackToPrimaryStop
+	case fsetInfo.AFM.AFMMode == connectors.AfmModePrimary:
+		klog.Infof("[%s] PromoteVolume - fileset [%s:%s] is already primary", loggerId, filesystemName, filesetName)
+		return &replication.PromoteVolumeResponse{}, nil
+	default:
+		return nil, status.Error(codes.FailedPrecondition, fmt.Sprintf("fileset [%v] in filesystem [%v] is not replicated", filesetName, filesystemName))
+	}
+
+	klog.Infof("[%s] PromoteVolume - running [%s] for fileset [%s:%s]", loggerId, afmdrReq.Action, filesystemName, filesetName)
+	err = conn.RunAFMDRCommand(ctx, filesystemName, filesetName, afmdrReq)
+	if err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("unable to promote fileset [%v] in filesystem [%v]. Error: %v", filesetName, filesystemName, err))
+	}
+	return &replication.PromoteVolumeResponse{}, nil
+}
+
+func (rs *ScaleReplicationServer) DemoteVolume(ctx context.Context, req *replication.DemoteVolumeRequest) (*replication.DemoteVolumeResponse, error) {
+	loggerId := utils.GetLoggerId(ctx)
+	klog.Infof("[%s] DemoteVolume - volume [%s], force [%v]", loggerId, req.GetVolumeId(), req.GetForce())
+
+	conn, filesystemName, filesetName, err := rs.getReplicatedFileset(ctx, req.GetVolumeId(), req.GetParameters())
+	if err != nil {
+		return nil, err
+	}
+
+	fsetInfo, err := conn.ListFileset(ctx, filesystemName, filesetName)
+	if err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("unable to list fileset [%v] in filesystem [%v]. Error: %v", filesetName, filesystemName, err))
+	}
+
+	switch {
+	case fsetInfo.AFM.AFMMode == connectors.AfmModeSecondary:
+		klog.Infof("[%s] DemoteVolume - fileset [%s:%s] is already secondary", loggerId, filesystemName, filesetName)
+	case fsetInfo.AFM.AFMMode == connectors.AfmModePrimary && fsetInfo.State.AFMState == afmStateFailbackInProgress:
+		klog.Infof("[%s] DemoteVolume - failback of fileset [%s:%s] is already in progress", loggerId, filesystemName, filesetName)
+	case fsetInfo.AFM.AFMMode == connectors.AfmModePrimary && fsetInfo.AFM.AFMTarget == "":
+		// an acting primary has no AFM target, it becomes the secondary of the old primary again
+		err = conn.RunAFMDRCommand(ctx, filesystemName, filesetName, connectors.AFMDRCommandRequest{
+			Action:       connectors.AfmDRConvertToSecondary,
+			AfmPrimaryID: fsetInfo.AFM.AFMPrimaryID,
+		})
+		if err != nil {
+			return nil, status.Error(codes.Internal, fmt.Sprintf("unable to convert fileset [%v] in filesystem [%v] to secondary. Error: %v", filesetName, filesystemName, err))
+		}
+	case fsetInfo.AFM.AFMMode == connectors.AfmModePrimary:
+		// the old primary pulls the changes made on the acting primary
+		for _, action := range []string{connectors.AfmDRFailbackToPrimaryStart, connectors.AfmDRApplyUpdates} {
+			klog.Infof("[%s] DemoteVolume - running [%s] for fileset [%s:%s]", loggerId, action, filesystemName, filesetName)
+			err = conn.RunAFMDRCommand(ctx, filesystemName, filesetName, connectors.AFMDRCommandRequest{Action: action})
+			if err != nil {
+				return nil, status.Error(codes.Internal, fmt.Sprintf("unable to run [%v] for fileset [%v] in filesystem [%v]. Error: %v", action, filesetName, filesystemName, err))
+			}
+		}
+	default:
+		return nil, status.Error(codes.FailedPrecondition, fmt.Sprintf("fileset [%v] in filesystem [%v] is not replicated", filesetName, filesystemName))
+	}
+	return &replication.DemoteVolumeResponse{}, nil
+}
+
+func (rs *ScaleReplicationServer) ResyncVolume(ctx context.Context, req *replication.ResyncVolumeRequest) (*replication.ResyncVolumeResponse, error) {
+	loggerId := utils.GetLoggerId(ctx)
+	klog.Infof("[%s] ResyncVolume - volume [%s], force [%v]", loggerId, req.GetVolumeId(), req.GetForce())
+
+	conn, filesystemName, filesetName, err := rs.getReplicatedFileset(ctx, req.GetVolumeId(), req.GetParameters())
+	if err != nil {
+		return nil, err
+	}
+
+	fsetInfo, err := conn.ListFileset(ctx, filesystemName, filesetName)
+	if err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("unable to list fileset [%v] in filesystem [%v]. Error: %v", filesetName, filesystemName, err))
+	}
+	if fsetInfo.AFM.AFMMode != connectors.AfmModePrimary {
+		return nil, status.Error(codes.FailedPrecondition, fmt.Sprintf("resync is supported only on the primary, fileset [%v] in filesystem [%v] is of AFM mode [%v]", filesetName, filesystemName, fsetInfo.AFM.AFMMode))
+	}
+
+	action := connectors.AfmDRResync
+	if fsetInfo.State.AFMState == afmStateFailbackInProgress {
+		action = connectors.AfmDRApplyUpdates
+	}
+	klog.Infof("[%s] ResyncVolume - running [%s] for fileset [%s:%s]", loggerId, action, filesystemName, filesetName)
+	err = conn.RunAFMDRCommand(ctx, filesystemName, filesetName, connectors.AFMDRCommandRequest{Action: action})
+	if err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("unable to run [%v] for fileset [%v] in filesystem [%v]. Error: %v", action, filesetName, filesystemName, err))
+	}
+	return &replication.ResyncVolumeResponse{Ready: true}, nil
+}
+
+func (rs *ScaleReplicationServer) GetVolumeReplicationInfo(ctx context.Context, req *replication.GetVolumeReplicationInfoRequest) (*replication.GetVolumeReplicationInfoResponse, error) {
+	loggerId := utils.GetLoggerId(ctx)
+	klog.V(4).Infof("[%s] GetVolumeReplicationInfo - volume [%s]", loggerId, req.GetVolumeId())
+
+	conn, filesystemName, filesetName, err := rs.getReplicatedFileset(ctx, req.GetVolumeId(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	fsetInfo, err := conn.ListFileset(ctx, filesystemName, filesetName)
+	if err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("unable to list fileset [%v] in filesystem [%v]. Error: %v", filesetName, filesystemName, err))
+	}
+
+	resp := &replication.GetVolumeReplicationInfoResponse{
+		State: fsetInfo.State.AFMState,
+		Peer:  fsetInfo.AFM.AFMTarget,
+	}
+	switch fsetInfo.AFM.AFMMode {
+	case connectors.AfmModePrimary:
+		resp.Role = replication.Role_PRIMARY
+	case connectors.AfmModeSecondary:
+		resp.Role = replication.Role_SECONDARY
+	default:
+		return nil, status.Error(codes.FailedPrecondition, fmt.Sprintf("fileset [%v] in filesystem [%v] is not replicated", filesetName, filesystemName))
+	}
+
+	// The last RPO snapshot is the last point at which primary and secondary were consistent
+	snapshots, err := conn.ListFilesetSnapshots(ctx, filesystemName, filesetName)
+	if err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("unable to list snapshots of fileset [%v] in filesystem [%v]. Error: %v", filesetName, filesystemName, err))
+	}
+	lastRPOSnapshot := connectors.Snapshot_v2{}
+	for _, snapshot := range snapshots {
+		if strings.HasPrefix(snapshot.SnapshotName, afmRPOSnapshotPrefix) && snapshot.SnapID > lastRPOSnapshot.SnapID {
+			lastRPOSnapshot = snapshot
+		}
+	}
+	if lastRPOSnapshot.SnapshotName != "" {
+		lastSync, err := rs.Driver.cs.getSnapshotCreateTimestamp(ctx, conn, filesystemName, filesetName, lastRPOSnapshot.SnapshotName)
+		if err != nil {
+			return nil, status.Error(codes.Internal, fmt.Sprintf("unable to get creation time of snapshot [%v] of fileset [%v]. Error: %v", lastRPOSnapshot.SnapshotName, filesetName, err))
+		}
+		resp.LastSyncTime = timestamppb.New(time.Unix(lastSync.GetSeconds(), 0))
+		resp.ReplicationLagSeconds = int64(time.Since(resp.LastSyncTime.AsTime()).Seconds())
+	}
+
+	klog.V(4).Infof("[%s] GetVolumeReplicationInfo - fileset [%s:%s] role [%v], state [%s], lag [%d]s", loggerId, filesystemName, filesetName, resp.Role, resp.State, resp.ReplicationLagSeconds)
+	return resp, nil
+}
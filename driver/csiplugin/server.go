@@ -25,14 +25,17 @@ import (
 	"k8s.io/klog/v2"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 
+	"github.com/IBM/ibm-spectrum-scale-csi/driver/csiplugin/pkg/replication"
+	"github.com/IBM/ibm-spectrum-scale-csi/driver/csiplugin/pkg/snapshotdiff"
 	csi "github.com/container-storage-interface/spec/lib/go/csi"
 )
 
 // Defines Non blocking GRPC server interfaces
 type NonBlockingGRPCServer interface {
 	// Start services at the endpoint
-	Start(endpoint string, ids csi.IdentityServer, cs csi.ControllerServer, ns csi.NodeServer)
+	Start(endpoint string, ids csi.IdentityServer, cs csi.ControllerServer, ns csi.NodeServer, sds snapshotdiff.SnapshotDiffServer, rs replication.ReplicationServer)
 	// Waits for the service to stop
 	Wait()
 	// Stops the service gracefully
@@ -41,20 +44,27 @@ type NonBlockingGRPCServer interface {
 	ForceStop()
 }
 
-func NewNonBlockingGRPCServer() NonBlockingGRPCServer {
-	return &nonBlockingGRPCServer{}
+// NewNonBlockingGRPCServer returns a server, tcp endpoints are served with TLS
+// if tlsFiles are set.
+func NewNonBlockingGRPCServer(tlsFiles TLSFiles) NonBlockingGRPCServer {
+	return &nonBlockingGRPCServer{tlsFiles: tlsFiles}
 }
 
 // NonBlocking server
 type nonBlockingGRPCServer struct {
+	tlsFiles TLSFiles
+
 	wg     sync.WaitGroup
+	lock   sync.Mutex
 	server *grpc.Server
+	// stopped is set when the server is stopped before it started serving
+	stopped bool
 }
 
-func (s *nonBlockingGRPCServer) Start(endpoint string, ids csi.IdentityServer, cs csi.ControllerServer, ns csi.NodeServer) {
+func (s *nonBlockingGRPCServer) Start(endpoint string, ids csi.IdentityServer, cs csi.ControllerServer, ns csi.NodeServer, sds snapshotdiff.SnapshotDiffServer, rs replication.ReplicationServer) {
 	s.wg.Add(1)
 
-	go s.serve(endpoint, ids, cs, ns)
+	go s.serve(endpoint, ids, cs, ns, sds, rs)
 }
 
 func (s *nonBlockingGRPCServer) Wait() {
@@ -62,14 +72,26 @@ func (s *nonBlockingGRPCServer) Wait() {
 }
 
 func (s *nonBlockingGRPCServer) Stop() {
-	s.server.GracefulStop()
+	if server := s.stop(); server != nil {
+		server.GracefulStop()
+	}
 }
 
 func (s *nonBlockingGRPCServer) ForceStop() {
-	s.server.Stop()
+	if server := s.stop(); server != nil {
+		server.Stop()
+	}
 }
 
-func (s *nonBlockingGRPCServer) serve(endpoint string, ids csi.IdentityServer, cs csi.ControllerServer, ns csi.NodeServer) {
+func (s *nonBlockingGRPCServer) stop() *grpc.Server {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.stopped = true
+	return s.server
+}
+
+func (s *nonBlockingGRPCServer) serve(endpoint string, ids csi.IdentityServer, cs csi.ControllerServer, ns csi.NodeServer, sds snapshotdiff.SnapshotDiffServer, rs replication.ReplicationServer) {
+	defer s.wg.Done()
 
 	opts := []grpc.ServerOption{
 		grpc.UnaryInterceptor(logGRPC),
@@ -89,8 +111,25 @@ func (s *nonBlockingGRPCServer) serve(endpoint string, ids csi.IdentityServer, c
 		if err := os.Remove(addr); err != nil && !os.IsNotExist(err) {
 			klog.Fatalf("Failed to remove %s, error: %s", addr, err.Error())
 		}
+		if s.tlsFiles.Enabled() {
+			klog.Warningf("TLS is not used for unix endpoint %s", endpoint)
+		}
 	case "tcp":
 		addr = u.Host
+		if s.tlsFiles.Enabled() {
+			reloader, err := newCertReloader(s.tlsFiles)
+			if err != nil {
+				klog.Fatalf("Failed to load TLS certificate for %s: %v", endpoint, err)
+			}
+			opts = append(opts, grpc.Creds(credentials.NewTLS(reloader.tlsConfig())))
+			if s.tlsFiles.ClientCAFile != "" {
+				klog.Infof("Serving %s with mutual TLS", endpoint)
+			} else {
+				klog.Infof("Serving %s with TLS", endpoint)
+			}
+		} else {
+			klog.Warningf("Serving tcp endpoint %s without TLS", endpoint)
+		}
 	default:
 		klog.Fatalf("%v endpoint scheme not supported", u.Scheme)
 	}
@@ -100,13 +139,23 @@ func (s *nonBlockingGRPCServer) serve(endpoint string, ids csi.IdentityServer, c
 	if err != nil {
 		klog.Fatalf("Failed to listen: %v", err)
 	}
-	// Updated csi.sock file permission to read and write only
-	// #nosec G703
-	if err := os.Chmod(addr, 0600); err != nil {
-		klog.Fatalf("Failed to modify csi.sock permission : %v", err)
+	if u.Scheme == "unix" {
+		// Updated csi.sock file permission to read and write only
+		// #nosec G703
+		if err := os.Chmod(addr, 0600); err != nil {
+			klog.Fatalf("Failed to modify csi.sock permission : %v", err)
+		}
 	}
 	server := grpc.NewServer(opts...)
+	s.lock.Lock()
+	if s.stopped {
+		s.lock.Unlock()
+		klog.Infof("Server stopped before serving on %#v", listener.Addr())
+		_ = listener.Close()
+		return
+	}
 	s.server = server
+	s.lock.Unlock()
 
 	if ids != nil {
 		csi.RegisterIdentityServer(server, ids)
@@ -117,6 +166,12 @@ func (s *nonBlockingGRPCServer) serve(endpoint string, ids csi.IdentityServer, c
 	if ns != nil {
 		csi.RegisterNodeServer(server, ns)
 	}
+	if sds != nil {
+		snapshotdiff.RegisterSnapshotDiffServer(server, sds)
+	}
+	if rs != nil {
+		replication.RegisterReplicationServer(server, rs)
+	}
 
 	klog.Infof("Started listening on %#v", listener.Addr())
 
@@ -123,7 +123,7 @@ func HandleSecretsAndCerts(ctx context.Context, cmap *ScaleSettingsConfigMap) er
 	klog.V(4).Infof("[%s] scale_config HandleSecrets", utils.GetLoggerId(ctx))
 	for i := 0; i < len(cmap.Clusters); i++ {
 		if cmap.Clusters[i].Secrets != "" {
-			unamePath := path.Join(SecretBasePath, cmap.Clusters[i].ID+secretFileSuffix, "username")
+			unamePath := secretFile(SecretBasePath, cmap.Clusters[i].ID, "username")
 			file, e := os.ReadFile(unamePath) // #nosec G304 Valid Path is generated internally
 			if e != nil {
 				return fmt.Errorf("the IBM Storage Scale secret not found: %v", e)
@@ -133,7 +133,7 @@ func HandleSecretsAndCerts(ctx context.Context, cmap *ScaleSettingsConfigMap) er
 			file_s = strings.TrimSuffix(file_s, "\n")
 			cmap.Clusters[i].MgmtUsername = file_s
 
-			pwdPath := path.Join(SecretBasePath, cmap.Clusters[i].ID+secretFileSuffix, "password")
+			pwdPath := secretFile(SecretBasePath, cmap.Clusters[i].ID, "password")
 			file, e = os.ReadFile(pwdPath) // #nosec G304 Valid Path is generated internally
 			if e != nil {
 				return fmt.Errorf("the IBM Storage Scale secret not found: %v", e)
@@ -145,34 +145,9 @@ func HandleSecretsAndCerts(ctx context.Context, cmap *ScaleSettingsConfigMap) er
 		}
 
 		if cmap.Clusters[i].SecureSslMode && cmap.Clusters[i].Cacert != "" {
-			certPath := path.Join(CertificatePath, cmap.Clusters[i].ID+cacertFileSuffix)
-			caCertPool := x509.NewCertPool()
-
-			// loop through directory and load all files as PEM certs
-			err := filepath.WalkDir(certPath, func(path string, d fs.DirEntry, walkErr error) error {
-				if walkErr != nil {
-					return fmt.Errorf("failed to iterate through the IBM Storage Scale CA certificate directory - error: %v", walkErr)
-				}
-				// Skip directories and symlinks
-				if d.IsDir() || d.Type()&os.ModeSymlink != 0 {
-					return nil
-				}
-
-				// Read file contents
-				data, err := os.ReadFile(path) // #nosec G304 Valid Path is generated internally
-				if err != nil {
-					return fmt.Errorf("failed to read the IBM Storage Scale CA certificate directory:%s - error: %v", path, err)
-				}
-
-				// append as PEM from the crt files
-				if ok := caCertPool.AppendCertsFromPEM(data); !ok {
-					// Not a valid cert, skip instead of failing
-					return nil
-				}
-				return nil
-			})
+			caCertPool, _, err := loadCACertificates(certificateDir(CertificatePath, cmap.Clusters[i].ID))
 			if err != nil {
-				return fmt.Errorf("failed to iterate through the IBM Storage Scale CA certificate directory and get certificates - error: %v", err)
+				return err
 			}
 
 			cmap.Clusters[i].CacertValue = caCertPool
@@ -180,3 +155,47 @@ func HandleSecretsAndCerts(ctx context.Context, cmap *ScaleSettingsConfigMap) er
 	}
 	return nil
 }
+
+func secretFile(secretBasePath, clusterID, key string) string {
+	return path.Join(secretBasePath, clusterID+secretFileSuffix, key)
+}
+
+func certificateDir(certificatePath, clusterID string) string {
+	return path.Join(certificatePath, clusterID+cacertFileSuffix)
+}
+
+// loadCACertificates returns the pool of the PEM certificates in the files of
+// certPath and their number, files without certificates are skipped.
+func loadCACertificates(certPath string) (*x509.CertPool, int, error) {
+	caCertPool := x509.NewCertPool()
+	count := 0
+
+	// loop through directory and load all files as PEM certs
+	err := filepath.WalkDir(certPath, func(path string, d fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return fmt.Errorf("failed to iterate through the IBM Storage Scale CA certificate directory - error: %v", walkErr)
+		}
+		// Skip directories and symlinks
+		if d.IsDir() || d.Type()&os.ModeSymlink != 0 {
+			return nil
+		}
+
+		// Read file contents
+		data, err := os.ReadFile(path) // #nosec G304 Valid Path is generated internally
+		if err != nil {
+			return fmt.Errorf("failed to read the IBM Storage Scale CA certificate directory:%s - error: %v", path, err)
+		}
+
+		// append as PEM from the crt files
+		if ok := caCertPool.AppendCertsFromPEM(data); !ok {
+			// Not a valid cert, skip instead of failing
+			return nil
+		}
+		count++
+		return nil
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to iterate through the IBM Storage Scale CA certificate directory and get certificates - error: %v", err)
+	}
+	return caCertPool, count, nil
+}
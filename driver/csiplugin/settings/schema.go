@@ -0,0 +1,138 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package settings
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// scaleConfigSchema is the JSON schema of spectrum-scale-config.json
+//
+//go:embed scale_config.schema.json
+var scaleConfigSchema []byte
+
+// schema is the subset of JSON schema used by scale_config.schema.json.
+type schema struct {
+	Type                 string             `json:"type"`
+	Required             []string           `json:"required"`
+	Properties           map[string]*schema `json:"properties"`
+	AdditionalProperties *bool              `json:"additionalProperties"`
+	Items                *schema            `json:"items"`
+	MinItems             *int               `json:"minItems"`
+	MinLength            *int               `json:"minLength"`
+	Minimum              *float64           `json:"minimum"`
+	Maximum              *float64           `json:"maximum"`
+	Deprecated           bool               `json:"deprecated"`
+}
+
+func loadSchema() (*schema, error) {
+	s := &schema{}
+	if err := json.Unmarshal(scaleConfigSchema, s); err != nil {
+		return nil, fmt.Errorf("invalid configuration schema: %v", err)
+	}
+	return s, nil
+}
+
+// validate appends the problems of value, decoded with UseNumber, at path to
+// problems.
+func (s *schema) validate(path string, value interface{}, problems []ConfigProblem) []ConfigProblem {
+	if s.Deprecated {
+		problems = append(problems, ConfigProblem{Path: path, Message: "deprecated field", Warning: true})
+	}
+	if actual := jsonType(value); s.Type != "" && actual != s.Type && (s.Type != "number" || actual != "integer") {
+		return append(problems, ConfigProblem{Path: path, Message: fmt.Sprintf("expected %s, found %s", s.Type, actual)})
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for _, name := range s.Required {
+			if _, ok := v[name]; !ok {
+				problems = append(problems, ConfigProblem{Path: path + "." + name, Message: "required field is missing"})
+			}
+		}
+		names := make([]string, 0, len(v))
+		for name := range v {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if property, ok := s.Properties[name]; ok {
+				problems = property.validate(path+"."+name, v[name], problems)
+			} else if s.AdditionalProperties != nil && !*s.AdditionalProperties {
+				problems = append(problems, s.unknownProperty(path+"."+name, name))
+			}
+		}
+	case []interface{}:
+		if s.MinItems != nil && len(v) < *s.MinItems {
+			problems = append(problems, ConfigProblem{Path: path, Message: fmt.Sprintf("must have at least %d entries, found %d", *s.MinItems, len(v))})
+		}
+		if s.Items != nil {
+			for i, item := range v {
+				problems = s.Items.validate(fmt.Sprintf("%s[%d]", path, i), item, problems)
+			}
+		}
+	case string:
+		if s.MinLength != nil && len(v) < *s.MinLength {
+			problems = append(problems, ConfigProblem{Path: path, Message: "must not be empty"})
+		}
+	case json.Number:
+		n, _ := v.Float64()
+		if s.Minimum != nil && n < *s.Minimum {
+			problems = append(problems, ConfigProblem{Path: path, Message: fmt.Sprintf("%s is below the minimum %v", v, *s.Minimum)})
+		}
+		if s.Maximum != nil && n > *s.Maximum {
+			problems = append(problems, ConfigProblem{Path: path, Message: fmt.Sprintf("%s is above the maximum %v", v, *s.Maximum)})
+		}
+	}
+	return problems
+}
+
+// unknownProperty reports a field which is not in the schema. The driver
+// decodes field names case-insensitively, such a field is used anyway.
+func (s *schema) unknownProperty(path, name string) ConfigProblem {
+	for property := range s.Properties {
+		if strings.EqualFold(property, name) {
+			return ConfigProblem{Path: path, Message: fmt.Sprintf("field should be named %q", property), Warning: true}
+		}
+	}
+	return ConfigProblem{Path: path, Message: "unknown field, ignored by the driver", Warning: true}
+}
+
+func jsonType(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	case json.Number:
+		if _, err := v.Int64(); err == nil {
+			return "integer"
+		}
+		return "number"
+	}
+	return fmt.Sprintf("%T", value)
+}
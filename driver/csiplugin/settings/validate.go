@@ -0,0 +1,211 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package settings
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/IBM/ibm-spectrum-scale-csi/driver/csiplugin/utils"
+	"k8s.io/klog/v2"
+)
+
+const rootPath = "$"
+
+// ConfigProblem is a problem of the IBM Storage Scale configuration at a
+// JSON path like $.clusters[0].restApi[0].guiHost.
+type ConfigProblem struct {
+	Path    string `json:"path"`
+	Message string `json:"message"`
+	// Warning is set for problems which do not stop the driver, like
+	// deprecated fields
+	Warning bool `json:"warning,omitempty"`
+}
+
+func (p ConfigProblem) String() string {
+	return p.Path + ": " + p.Message
+}
+
+// ConfigError is returned for a configuration with problems which are not
+// warnings, it lists all of them.
+type ConfigError struct {
+	File     string
+	Problems []ConfigProblem
+}
+
+func (e *ConfigError) Error() string {
+	messages := make([]string, 0, len(e.Problems))
+	for _, problem := range e.Problems {
+		messages = append(messages, problem.String())
+	}
+	return fmt.Sprintf("invalid IBM Storage Scale configuration %s: %s", e.File, strings.Join(messages, "; "))
+}
+
+// ValidateScaleConfig checks the content of spectrum-scale-config.json
+// against its JSON schema and the rules of the driver, and returns every
+// problem found. The secrets and CA certificates of the clusters are read
+// below secretBasePath and certificatePath, they are not checked if the
+// path is empty.
+func ValidateScaleConfig(data []byte, secretBasePath, certificatePath string) []ConfigProblem {
+	var value interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&value); err != nil {
+		return []ConfigProblem{{Path: rootPath, Message: jsonErrorMessage(data, err)}}
+	}
+	if decoder.More() {
+		return []ConfigProblem{{Path: rootPath, Message: "unexpected data after the configuration"}}
+	}
+
+	s, err := loadSchema()
+	if err != nil {
+		return []ConfigProblem{{Path: rootPath, Message: err.Error()}}
+	}
+	problems := s.validate(rootPath, value, nil)
+	if hasErrors(problems) {
+		// the rules below need a configuration which matches the schema
+		return problems
+	}
+
+	cmap := ScaleSettingsConfigMap{}
+	if err := json.Unmarshal(data, &cmap); err != nil {
+		return append(problems, ConfigProblem{Path: rootPath, Message: err.Error()})
+	}
+	problems = append(problems, validateClusters(cmap)...)
+	for i, cluster := range cmap.Clusters {
+		clusterPath := fmt.Sprintf("%s.clusters[%d]", rootPath, i)
+		if secretBasePath != "" && cluster.Secrets != "" {
+			problems = append(problems, validateSecret(clusterPath, secretBasePath, cluster.ID)...)
+		}
+		if certificatePath != "" && cluster.SecureSslMode && cluster.Cacert != "" {
+			dir := certificateDir(certificatePath, cluster.ID)
+			if _, count, err := loadCACertificates(dir); err != nil {
+				problems = append(problems, ConfigProblem{Path: clusterPath + ".cacert", Message: err.Error()})
+			} else if count == 0 {
+				problems = append(problems, ConfigProblem{Path: clusterPath + ".cacert", Message: fmt.Sprintf("no PEM encoded CA certificate found in %s", dir)})
+			}
+		}
+	}
+	return problems
+}
+
+// validateClusters checks the cluster IDs, the local cluster and the SSL
+// settings.
+func validateClusters(cmap ScaleSettingsConfigMap) []ConfigProblem {
+	problems := []ConfigProblem{}
+	ids := map[string]int{}
+	locals := []string{}
+	for i, cluster := range cmap.Clusters {
+		clusterPath := fmt.Sprintf("%s.clusters[%d]", rootPath, i)
+		if j, ok := ids[cluster.ID]; ok {
+			problems = append(problems, ConfigProblem{Path: clusterPath + ".id", Message: fmt.Sprintf("cluster ID %s is already used by %s.clusters[%d]", cluster.ID, rootPath, j)})
+		} else {
+			ids[cluster.ID] = i
+		}
+
+		// the driver uses the same rule to find the local cluster
+		if (cmap.LocalScaleCluster != "" && cmap.LocalScaleCluster == cluster.ID) || cluster.Primary != (Primary{}) {
+			locals = append(locals, clusterPath)
+		}
+		if cmap.LocalScaleCluster == "" && cluster.Primary != (Primary{}) {
+			problems = append(problems, ConfigProblem{Path: clusterPath + ".primary", Message: fmt.Sprintf("the primary stanza is deprecated, set localScaleCluster to %s instead", cluster.ID), Warning: true})
+		}
+
+		if cluster.SecureSslMode && cluster.Cacert == "" {
+			problems = append(problems, ConfigProblem{Path: clusterPath + ".cacert", Message: "a CA certificate is required if secureSslMode is true"})
+		} else if !cluster.SecureSslMode && cluster.Cacert != "" {
+			problems = append(problems, ConfigProblem{Path: clusterPath + ".cacert", Message: "the CA certificate is not used as secureSslMode is false", Warning: true})
+		}
+	}
+
+	if _, ok := ids[cmap.LocalScaleCluster]; cmap.LocalScaleCluster != "" && !ok {
+		problems = append(problems, ConfigProblem{Path: rootPath + ".localScaleCluster", Message: fmt.Sprintf("no cluster has the ID %s", cmap.LocalScaleCluster)})
+	}
+	switch {
+	case len(locals) == 0 && cmap.LocalScaleCluster == "":
+		problems = append(problems, ConfigProblem{Path: rootPath + ".localScaleCluster", Message: "no local cluster, set localScaleCluster to the ID of the cluster which mounts the filesystems on the Kubernetes nodes"})
+	case len(locals) > 1:
+		problems = append(problems, ConfigProblem{Path: rootPath + ".clusters", Message: fmt.Sprintf("exactly one cluster must be the local cluster set by localScaleCluster or a primary stanza, found %s", strings.Join(locals, ", "))})
+	}
+	return problems
+}
+
+// validateSecret checks that the GUI credentials of a cluster are readable
+// and not empty.
+func validateSecret(clusterPath, secretBasePath, clusterID string) []ConfigProblem {
+	problems := []ConfigProblem{}
+	for _, key := range []string{"username", "password"} {
+		file := secretFile(secretBasePath, clusterID, key)
+		data, err := os.ReadFile(file) // #nosec G304 Valid Path is generated internally
+		if err != nil {
+			problems = append(problems, ConfigProblem{Path: clusterPath + ".secrets", Message: fmt.Sprintf("unable to read the %s of the secret: %v", key, err)})
+		} else if strings.TrimSpace(string(data)) == "" {
+			problems = append(problems, ConfigProblem{Path: clusterPath + ".secrets", Message: fmt.Sprintf("the %s of the secret is empty", key)})
+		}
+	}
+	return problems
+}
+
+// ValidateScaleConfigFile validates the configuration file of the driver,
+// logs the warnings and returns a ConfigError if there are other problems.
+func ValidateScaleConfigFile(ctx context.Context, file string) error {
+	loggerId := utils.GetLoggerId(ctx)
+	data, err := os.ReadFile(file) // #nosec G304 Valid Path is generated internally
+	if err != nil {
+		return fmt.Errorf("IBM Storage Scale configuration not found: %v", err)
+	}
+	problems := ValidateScaleConfig(data, SecretBasePath, CertificatePath)
+	configErr := &ConfigError{File: file}
+	for _, problem := range problems {
+		if problem.Warning {
+			klog.Warningf("[%s] IBM Storage Scale configuration %s: %s", loggerId, file, problem)
+		} else {
+			configErr.Problems = append(configErr.Problems, problem)
+		}
+	}
+	if len(configErr.Problems) > 0 {
+		return configErr
+	}
+	return nil
+}
+
+func hasErrors(problems []ConfigProblem) bool {
+	for _, problem := range problems {
+		if !problem.Warning {
+			return true
+		}
+	}
+	return false
+}
+
+// jsonErrorMessage returns the message of a decoding error with the line and
+// column of a syntax error.
+func jsonErrorMessage(data []byte, err error) string {
+	var syntaxErr *json.SyntaxError
+	if !errors.As(err, &syntaxErr) {
+		return fmt.Sprintf("invalid JSON: %v", err)
+	}
+	before := data[:syntaxErr.Offset]
+	line := bytes.Count(before, []byte("\n")) + 1
+	column := len(before) - bytes.LastIndexByte(before, '\n')
+	return fmt.Sprintf("invalid JSON at line %d, column %d: %v", line, column, err)
+}
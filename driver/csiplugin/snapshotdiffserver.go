@@ -0,0 +1,261 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package scale
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/IBM/ibm-spectrum-scale-csi/driver/csiplugin/connectors"
+	"github.com/IBM/ibm-spectrum-scale-csi/driver/csiplugin/pkg/snapshotdiff"
+	"github.com/IBM/ibm-spectrum-scale-csi/driver/csiplugin/utils"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/klog/v2"
+)
+
+const (
+	defaultChangedFilesPerResponse = 1000
+	maxChangedFilesPerResponse     = 10000
+)
+
+type ScaleSnapshotDiffServer struct {
+	Driver *ScaleDriver
+	snapshotdiff.UnimplementedSnapshotDiffServer
+}
+
+func NewSnapshotDiffServer(d *ScaleDriver) *ScaleSnapshotDiffServer {
+	return &ScaleSnapshotDiffServer{
+		Driver: d,
+	}
+}
+
+// GetChangedFiles streams the files changed between two snapshots of the same
+// volume, one page of changes per response.
+func (ds *ScaleSnapshotDiffServer) GetChangedFiles(req *snapshotdiff.GetChangedFilesRequest, stream snapshotdiff.SnapshotDiff_GetChangedFilesServer) error {
+	ctx := utils.SetLoggerId(stream.Context())
+	loggerId := utils.GetLoggerId(ctx)
+	klog.Infof("[%s] GetChangedFiles - base snapshot [%s], target snapshot [%s], starting token [%s]", loggerId, req.GetBaseSnapshotId(), req.GetTargetSnapshotId(), req.GetStartingToken())
+
+	if req.GetBaseSnapshotId() == "" || req.GetTargetSnapshotId() == "" {
+		return status.Error(codes.InvalidArgument, "GetChangedFiles - base and target snapshot IDs must be provided")
+	}
+
+	limit := int(req.GetMaxResults())
+	if limit < 0 {
+		return status.Error(codes.InvalidArgument, fmt.Sprintf("GetChangedFiles - invalid max results [%d]", limit))
+	}
+	if limit == 0 {
+		limit = defaultChangedFilesPerResponse
+	}
+	if limit > maxChangedFilesPerResponse {
+		limit = maxChangedFilesPerResponse
+	}
+
+	base, err := ds.Driver.cs.GetSnapIdMembers(req.GetBaseSnapshotId())
+	if err != nil {
+		return status.Error(codes.NotFound, fmt.Sprintf("GetChangedFiles - invalid base snapshot ID [%s]. Error [%v]", req.GetBaseSnapshotId(), err))
+	}
+	target, err := ds.Driver.cs.GetSnapIdMembers(req.GetTargetSnapshotId())
+	if err != nil {
+		return status.Error(codes.NotFound, fmt.Sprintf("GetChangedFiles - invalid target snapshot ID [%s]. Error [%v]", req.GetTargetSnapshotId(), err))
+	}
+
+	if base.ClusterId != target.ClusterId || base.FsUUID != target.FsUUID ||
+		base.ConsistencyGroup != target.ConsistencyGroup || base.FsetName != target.FsetName || base.Path != target.Path {
+		return status.Error(codes.InvalidArgument, fmt.Sprintf("GetChangedFiles - snapshots [%s] and [%s] do not belong to the same volume", req.GetBaseSnapshotId(), req.GetTargetSnapshotId()))
+	}
+	if base.SnapName == target.SnapName {
+		return status.Error(codes.InvalidArgument, fmt.Sprintf("GetChangedFiles - base and target snapshot are the same snapshot [%s]", base.SnapName))
+	}
+
+	conn, err := ds.Driver.cs.getConnFromClusterID(ctx, target.ClusterId)
+	if err != nil {
+		return err
+	}
+
+	filesystemName, err := conn.GetFilesystemName(ctx, target.FsUUID)
+	if err != nil {
+		return status.Error(codes.Internal, fmt.Sprintf("GetChangedFiles - unable to get filesystem name for UUID [%s]. Error [%v]", target.FsUUID, err))
+	}
+
+	// For advanced storageClass the snapshot is taken on the consistency group
+	// fileset and the volume fileset is linked below it.
+	filesetName := target.FsetName
+	path := target.Path
+	if target.StorageClassType == STORAGECLASS_ADVANCED {
+		filesetName = target.ConsistencyGroup
+		path = target.FsetName
+	}
+
+	for _, snapName := range []string{base.SnapName, target.SnapName} {
+		snapExist, err := conn.CheckIfSnapshotExist(ctx, filesystemName, filesetName, snapName)
+		if err != nil {
+			return status.Error(codes.Internal, fmt.Sprintf("GetChangedFiles - unable to get snapshot [%s] of fileset [%s]. Error [%v]", snapName, filesetName, err))
+		}
+		if !snapExist {
+			return status.Error(codes.NotFound, fmt.Sprintf("GetChangedFiles - snapshot [%s] does not exist in fileset [%s]", snapName, filesetName))
+		}
+	}
+
+	baseDir, targetDir, err := ds.snapshotDirs(ctx, conn, target, filesystemName, filesetName, base.SnapName, path)
+	if err != nil {
+		return err
+	}
+
+	walk, err := ds.walkSnapshotTrees(ctx, conn)
+	if err != nil {
+		return err
+	}
+
+	// a page is sent when the next change is found, so only the last page has
+	// no next token
+	resp := &snapshotdiff.GetChangedFilesResponse{}
+	send := func(changedFile *snapshotdiff.ChangedFile) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if len(resp.ChangedFiles) == limit {
+			resp.NextToken = resp.ChangedFiles[limit-1].Path
+			if err := stream.Send(resp); err != nil {
+				return err
+			}
+			resp = &snapshotdiff.GetChangedFilesResponse{}
+		}
+		resp.ChangedFiles = append(resp.ChangedFiles, changedFile)
+		return nil
+	}
+	if walk {
+		klog.Infof("[%s] GetChangedFiles - comparing the trees of snapshots [%s] and [%s] of fileset [%s] on this node", loggerId, base.SnapName, target.SnapName, filesetName)
+		err = snapshotdiff.Compare(baseDir, targetDir, req.GetStartingToken(), send)
+	} else {
+		var changes []connectors.SnapshotChange
+		changes, err = conn.SnapshotDiff(ctx, filesystemName, filesetName, base.SnapName, target.SnapName, path)
+		if err == nil {
+			err = snapshotdiff.Resume(toChangedFiles(changes, path, targetDir), req.GetStartingToken(), send)
+		}
+	}
+	if err == nil {
+		err = stream.Send(resp)
+	}
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			klog.Infof("[%s] GetChangedFiles - stream closed by the client: %v", loggerId, ctxErr)
+			return status.FromContextError(ctxErr).Err()
+		}
+		klog.Errorf("[%s] GetChangedFiles - unable to list changes between snapshots [%s] and [%s] of fileset [%s]. Error [%v]", loggerId, base.SnapName, target.SnapName, filesetName, err)
+		return status.Error(codes.Internal, fmt.Sprintf("unable to list changes between snapshots [%s] and [%s] of fileset [%s]. Error [%v]", base.SnapName, target.SnapName, filesetName, err))
+	}
+	klog.Infof("[%s] GetChangedFiles - completed for snapshots [%s] and [%s] of fileset [%s]", loggerId, base.SnapName, target.SnapName, filesetName)
+	return nil
+}
+
+// walkSnapshotTrees returns whether the trees of the snapshots are compared on
+// this node. The changes are listed by mmsnapdiff through the GUI of the
+// owning cluster, the walk is only a fallback for a GUI not supporting it and
+// has to be enabled by SNAPSHOT_DIFF_TREE_WALK, as it reads every directory of
+// both snapshots.
+func (ds *ScaleSnapshotDiffServer) walkSnapshotTrees(ctx context.Context, conn connectors.SpectrumScaleConnector) (bool, error) {
+	supported, err := conn.IsSnapshotDiffSupported(ctx)
+	if err != nil {
+		return false, status.Error(codes.Internal, fmt.Sprintf("GetChangedFiles - unable to check if snapshot diff is supported. Error [%v]", err))
+	}
+	if supported {
+		return false, nil
+	}
+	if strings.ToUpper(os.Getenv(snapshotDiffTreeWalk)) != "ENABLED" {
+		return false, status.Error(codes.FailedPrecondition, fmt.Sprintf("GetChangedFiles - snapshot diff is not supported by the GUI of the cluster and the fallback [%s] is not enabled", snapshotDiffTreeWalk))
+	}
+	return true, nil
+}
+
+// toChangedFiles converts the changes reported below path of a fileset into
+// paths relative to the root of the volume, with the size of the files in
+// the target snapshot directory.
+func toChangedFiles(changes []connectors.SnapshotChange, path string, targetDir string) []*snapshotdiff.ChangedFile {
+	volPath := strings.Trim(path, "/")
+	changedFiles := make([]*snapshotdiff.ChangedFile, 0, len(changes))
+	for _, change := range changes {
+		relPath := strings.Trim(change.Path, "/")
+		if volPath != "" {
+			var found bool
+			if relPath, found = strings.CutPrefix(relPath, volPath+"/"); !found {
+				continue
+			}
+		}
+		if relPath == "" {
+			continue
+		}
+
+		changedFile := &snapshotdiff.ChangedFile{Path: relPath}
+		switch change.ChangeType {
+		case connectors.SnapshotChangeCreated:
+			changedFile.ChangeType = snapshotdiff.ChangeType_CREATED
+		case connectors.SnapshotChangeModified:
+			changedFile.ChangeType = snapshotdiff.ChangeType_MODIFIED
+		case connectors.SnapshotChangeDeleted:
+			changedFile.ChangeType = snapshotdiff.ChangeType_DELETED
+		}
+		if changedFile.ChangeType != snapshotdiff.ChangeType_DELETED {
+			if info, err := os.Lstat(filepath.Join(targetDir, relPath)); err == nil && !info.IsDir() {
+				changedFile.SizeBytes = info.Size()
+			}
+		}
+		changedFiles = append(changedFiles, changedFile)
+	}
+	return changedFiles
+}
+
+// snapshotDirs returns the directories of path in the base and the target
+// snapshot of a fileset, as seen through the host root of the driver pod. The
+// fileset is linked in the filesystem of the owning cluster, its path on the
+// primary filesystem mount of this node is used.
+func (ds *ScaleSnapshotDiffServer) snapshotDirs(ctx context.Context, conn connectors.SpectrumScaleConnector, target scaleSnapId, filesystemName, filesetName, baseSnapName, path string) (string, string, error) {
+	primaryConn, ok := ds.Driver.connmap["primary"]
+	if !ok {
+		return "", "", status.Error(codes.Internal, "unable to find primary cluster details in custom resource")
+	}
+	primaryFsName, err := primaryConn.GetFilesystemName(ctx, target.FsUUID)
+	if err != nil {
+		return "", "", status.Error(codes.Internal, fmt.Sprintf("GetChangedFiles - unable to get filesystem name for UUID [%s] in primary cluster. Error [%v]", target.FsUUID, err))
+	}
+	primaryMount, err := primaryConn.GetFilesystemMountDetails(ctx, primaryFsName)
+	if err != nil {
+		return "", "", status.Error(codes.Internal, fmt.Sprintf("GetChangedFiles - unable to get mount details of filesystem [%s] in primary cluster. Error [%v]", primaryFsName, err))
+	}
+	ownerMount, err := conn.GetFilesystemMountDetails(ctx, filesystemName)
+	if err != nil {
+		return "", "", status.Error(codes.Internal, fmt.Sprintf("GetChangedFiles - unable to get mount details of filesystem [%s]. Error [%v]", filesystemName, err))
+	}
+	filesetInfo, err := conn.ListFileset(ctx, filesystemName, filesetName)
+	if err != nil {
+		return "", "", status.Error(codes.Internal, fmt.Sprintf("GetChangedFiles - unable to get details of fileset [%s]. Error [%v]", filesetName, err))
+	}
+	if filesetInfo.Config.Path == "" || filesetInfo.Config.Path == filesetUnlinkedPath {
+		return "", "", status.Error(codes.FailedPrecondition, fmt.Sprintf("GetChangedFiles - fileset [%s] is not linked", filesetName))
+	}
+
+	relPath, found := strings.CutPrefix(filesetInfo.Config.Path, ownerMount.MountPoint)
+	if !found {
+		return "", "", status.Error(codes.Internal, fmt.Sprintf("GetChangedFiles - fileset path [%s] is not below the mount point [%s]", filesetInfo.Config.Path, ownerMount.MountPoint))
+	}
+	snapshotsDir := filepath.Join(hostDir, primaryMount.MountPoint, relPath, ".snapshots")
+	return filepath.Join(snapshotsDir, baseSnapName, path), filepath.Join(snapshotsDir, target.SnapName, path), nil
+}
@@ -0,0 +1,155 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package scale
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+// TLSFiles are the certificate files of a tcp endpoint. The client CA file
+// is optional, if it is set clients must present a certificate signed by it.
+type TLSFiles struct {
+	CertFile     string
+	KeyFile      string
+	ClientCAFile string
+}
+
+// Enabled reports whether TLS is configured.
+func (files TLSFiles) Enabled() bool {
+	return files.CertFile != "" || files.KeyFile != "" || files.ClientCAFile != ""
+}
+
+// Validate checks that the certificate and key are set together and that a
+// client CA is only set with them.
+func (files TLSFiles) Validate() error {
+	if files.CertFile == "" || files.KeyFile == "" {
+		return fmt.Errorf("both the TLS certificate and key files are required, certificate: [%s], key: [%s]", files.CertFile, files.KeyFile)
+	}
+	return nil
+}
+
+// certReloader loads the certificate files of a tcp endpoint again when they
+// were modified, e.g. rotated by cert-manager, so that new connections use
+// the new certificate without a restart of the driver.
+type certReloader struct {
+	files TLSFiles
+
+	lock      sync.Mutex
+	cert      *tls.Certificate
+	clientCAs *x509.CertPool
+	modTimes  map[string]time.Time
+}
+
+func newCertReloader(files TLSFiles) (*certReloader, error) {
+	if err := files.Validate(); err != nil {
+		return nil, err
+	}
+	r := &certReloader{files: files, modTimes: make(map[string]time.Time)}
+	if _, _, err := r.load(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// load returns the certificate and client CAs, the files are read again if
+// one of them was modified. If the modified files can't be loaded, e.g.
+// while the certificate was replaced but the key not yet, the previous
+// certificate is used until the next attempt succeeds.
+func (r *certReloader) load() (*tls.Certificate, *x509.CertPool, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	modTimes := make(map[string]time.Time)
+	changed := false
+	for _, file := range []string{r.files.CertFile, r.files.KeyFile, r.files.ClientCAFile} {
+		if file == "" {
+			continue
+		}
+		info, err := os.Stat(file)
+		if err != nil {
+			return r.previous(fmt.Errorf("unable to stat %s: %w", file, err))
+		}
+		modTimes[file] = info.ModTime()
+		if !info.ModTime().Equal(r.modTimes[file]) {
+			changed = true
+		}
+	}
+	if !changed {
+		return r.cert, r.clientCAs, nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(r.files.CertFile, r.files.KeyFile)
+	if err != nil {
+		return r.previous(fmt.Errorf("unable to load TLS certificate %s and key %s: %w", r.files.CertFile, r.files.KeyFile, err))
+	}
+	var clientCAs *x509.CertPool
+	if r.files.ClientCAFile != "" {
+		pem, err := os.ReadFile(filepath.Clean(r.files.ClientCAFile))
+		if err != nil {
+			return r.previous(fmt.Errorf("unable to read client CA file %s: %w", r.files.ClientCAFile, err))
+		}
+		clientCAs = x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(pem) {
+			return r.previous(fmt.Errorf("no certificate found in client CA file %s", r.files.ClientCAFile))
+		}
+	}
+
+	if r.cert != nil {
+		klog.Infof("reloaded TLS certificate %s", r.files.CertFile)
+	}
+	r.cert, r.clientCAs, r.modTimes = &cert, clientCAs, modTimes
+	return r.cert, r.clientCAs, nil
+}
+
+func (r *certReloader) previous(err error) (*tls.Certificate, *x509.CertPool, error) {
+	if r.cert == nil {
+		return nil, nil, err
+	}
+	klog.Errorf("using the previous TLS certificate. Error: %v", err)
+	return r.cert, r.clientCAs, nil
+}
+
+// tlsConfig returns the server TLS configuration, the certificate files are
+// checked for modifications on every new connection.
+func (r *certReloader) tlsConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			cert, clientCAs, err := r.load()
+			if err != nil {
+				return nil, err
+			}
+			config := &tls.Config{
+				MinVersion:   tls.VersionTLS12,
+				Certificates: []tls.Certificate{*cert},
+			}
+			if clientCAs != nil {
+				config.ClientCAs = clientCAs
+				config.ClientAuth = tls.RequireAndVerifyClientCert
+			}
+			return config, nil
+		},
+	}
+}
@@ -0,0 +1,239 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package scale
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/IBM/ibm-spectrum-scale-csi/driver/csiplugin/connectors"
+	"github.com/IBM/ibm-spectrum-scale-csi/driver/csiplugin/utils"
+	"k8s.io/klog/v2"
+)
+
+const warmPoolFilesetPrefix = "csi-warmpool"
+
+// warmPoolSpec are the parameters shared by the filesets of a warm pool.
+// Filesets of a pool are created unlinked and without quota, so volumes of
+// StorageClasses with the same spec share a pool. The tier is applied by the
+// placement rule matching the name a fileset gets when it is claimed.
+type warmPoolSpec struct {
+	clusterID     string
+	filesystem    string
+	filesetType   string
+	inodeLimit    string
+	parentFileset string
+	tier          string
+}
+
+// id returns a short identifier of the spec, which is part of the names and
+// the comment of the filesets of the pool.
+func (spec warmPoolSpec) id() string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s;%s;%s;%s;%s;%s", spec.clusterID, spec.filesystem, spec.filesetType, spec.inodeLimit, spec.parentFileset, spec.tier)))
+	return hex.EncodeToString(sum[:])[:12]
+}
+
+// warmPool is a set of pre-created, unlinked filesets which CreateVolume
+// claims by renaming them to the volume name.
+type warmPool struct {
+	spec warmPoolSpec
+	conn connectors.SpectrumScaleConnector
+
+	lock       sync.Mutex
+	size       int
+	filesets   []string
+	discovered bool
+	refilling  bool
+}
+
+// getWarmPool returns the warm pool of scVol and updates its size from the
+// StorageClass, the largest size requested for a pool is kept. The fileset
+// type in opt must be resolved, as createFilesetVol compares it with the type
+// of the claimed fileset.
+func (cs *ScaleControllerServer) getWarmPool(scVol *scaleVolume, opt map[string]interface{}) *warmPool {
+	spec := warmPoolSpec{
+		clusterID:     scVol.ClusterId,
+		filesystem:    scVol.VolBackendFs,
+		filesetType:   optionString(opt, connectors.UserSpecifiedFilesetType),
+		inodeLimit:    optionString(opt, connectors.UserSpecifiedInodeLimit),
+		parentFileset: scVol.ParentFileset,
+		tier:          scVol.Tier,
+	}
+	value, _ := cs.Driver.warmpoolmap.LoadOrStore(spec.id(), &warmPool{spec: spec, conn: scVol.Connector})
+	pool := value.(*warmPool)
+
+	pool.lock.Lock()
+	defer pool.lock.Unlock()
+	if scVol.WarmPoolSize > pool.size {
+		pool.size = scVol.WarmPoolSize
+	}
+	return pool
+}
+
+// claimWarmPoolFileset renames a fileset of the warm pool of scVol to volName,
+// so that createFilesetVol only has to link it and set the quota. If the pool
+// is empty or the claim fails the fileset is created by createFilesetVol as
+// usual. The pool is refilled in the background.
+func (cs *ScaleControllerServer) claimWarmPoolFileset(ctx context.Context, scVol *scaleVolume, volName string, opt map[string]interface{}) {
+	loggerId := utils.GetLoggerId(ctx)
+	pool := cs.getWarmPool(scVol, opt)
+	defer cs.refillWarmPool(ctx, pool)
+
+	// a retried CreateVolume finds the fileset it claimed or created before
+	filesetInfo, err := scVol.Connector.ListFileset(ctx, scVol.VolBackendFs, volName)
+	if err != nil || !reflect.ValueOf(filesetInfo).IsZero() {
+		return
+	}
+
+	for {
+		poolFileset, ok := pool.take()
+		if !ok {
+			klog.Infof("[%s] volume:[%v] - warm pool [%s] is empty, creating the fileset", loggerId, volName, pool.spec.id())
+			return
+		}
+
+		opts := map[string]interface{}{
+			connectors.FilesetNewNameKey: volName,
+			connectors.FilesetCommentKey: fmt.Sprintf(connectors.FilesetCommentValue, scVol.PVCName, scVol.Namespace),
+		}
+		err := scVol.Connector.UpdateFileset(ctx, scVol.VolBackendFs, "", poolFileset, opts, "")
+		if err != nil {
+			// the fileset may have been removed from the pool outside of the driver
+			klog.Errorf("[%s] volume:[%v] - unable to claim fileset [%v] of warm pool [%s]. Error: %v", loggerId, volName, poolFileset, pool.spec.id(), err)
+			continue
+		}
+		klog.Infof("[%s] volume:[%v] - claimed fileset [%v] of warm pool [%s]", loggerId, volName, poolFileset, pool.spec.id())
+		cs.journalStep(ctx, volName, journalStepFilesetCreated)
+		return
+	}
+}
+
+// optionString returns the value of key in opt, empty if it is not set.
+func optionString(opt map[string]interface{}, key string) string {
+	if value, ok := opt[key]; ok && value != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return ""
+}
+
+func (pool *warmPool) take() (string, bool) {
+	pool.lock.Lock()
+	defer pool.lock.Unlock()
+	if len(pool.filesets) == 0 {
+		return "", false
+	}
+	fileset := pool.filesets[0]
+	pool.filesets = pool.filesets[1:]
+	return fileset, true
+}
+
+// refillWarmPool creates filesets in the background until the pool has its
+// size. The unlinked filesets left by a previous run of the driver are added
+// to the pool first.
+func (cs *ScaleControllerServer) refillWarmPool(ctx context.Context, pool *warmPool) {
+	pool.lock.Lock()
+	if pool.refilling {
+		pool.lock.Unlock()
+		return
+	}
+	pool.refilling = true
+	pool.lock.Unlock()
+
+	ctx = context.WithoutCancel(ctx)
+	loggerId := utils.GetLoggerId(ctx)
+	cs.Driver.asyncJobs.Add(1)
+	go func() {
+		defer cs.Driver.asyncJobs.Done()
+		defer func() {
+			pool.lock.Lock()
+			pool.refilling = false
+			pool.lock.Unlock()
+		}()
+
+		if err := pool.discover(ctx); err != nil {
+			klog.Errorf("[%s] unable to discover filesets of warm pool [%s]. Error: %v", loggerId, pool.spec.id(), err)
+			return
+		}
+
+		for {
+			pool.lock.Lock()
+			missing := pool.size - len(pool.filesets)
+			pool.lock.Unlock()
+			if missing <= 0 || cs.Driver.isStopping() {
+				return
+			}
+
+			name := fmt.Sprintf("%s-%s-%s", warmPoolFilesetPrefix, pool.spec.id(), strconv.FormatInt(time.Now().UnixNano(), 36))
+			opts := map[string]interface{}{
+				connectors.UserSpecifiedFilesetType: pool.spec.filesetType,
+				connectors.FilesetCommentKey:        fmt.Sprintf(connectors.WarmPoolFilesetComment, pool.spec.id()),
+			}
+			if pool.spec.inodeLimit != "" {
+				opts[connectors.UserSpecifiedInodeLimit] = pool.spec.inodeLimit
+			}
+			if pool.spec.parentFileset != "" {
+				opts[connectors.UserSpecifiedParentFset] = pool.spec.parentFileset
+			}
+			if err := pool.conn.CreateFileset(ctx, pool.spec.filesystem, "", name, opts, "", "", nil); err != nil {
+				klog.Errorf("[%s] unable to create fileset [%v] of warm pool [%s] in filesystem [%v]. Error: %v", loggerId, name, pool.spec.id(), pool.spec.filesystem, err)
+				return
+			}
+			klog.V(4).Infof("[%s] created fileset [%v] of warm pool [%s]", loggerId, name, pool.spec.id())
+
+			pool.lock.Lock()
+			pool.filesets = append(pool.filesets, name)
+			pool.lock.Unlock()
+		}
+	}()
+}
+
+// discover adds the unlinked filesets of the pool which exist in the
+// filesystem, e.g. created before a restart of the driver.
+func (pool *warmPool) discover(ctx context.Context) error {
+	pool.lock.Lock()
+	discovered := pool.discovered
+	pool.lock.Unlock()
+	if discovered {
+		return nil
+	}
+
+	filesets, err := pool.conn.ListFilesets(ctx, pool.spec.filesystem)
+	if err != nil {
+		return err
+	}
+	comment := fmt.Sprintf(connectors.WarmPoolFilesetComment, pool.spec.id())
+
+	pool.lock.Lock()
+	defer pool.lock.Unlock()
+	for _, fileset := range filesets {
+		if fileset.Config.Comment != comment {
+			continue
+		}
+		if fileset.Config.Path != "" && fileset.Config.Path != filesetUnlinkedPath {
+			continue
+		}
+		pool.filesets = append(pool.filesets, fileset.FilesetName)
+	}
+	pool.discovered = true
+	return nil
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2026 IBM Corp.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CSIScaleSnapshotScheduleSpec specifies which volumes are snapshotted, when,
+// and for how long the snapshots are kept.
+type CSIScaleSnapshotScheduleSpec struct {
+
+	// schedule is a cron expression with five fields (minute hour day-of-month month day-of-week)
+	// in UTC, at which snapshots are created.
+	// +kubebuilder:validation:MinLength:=9
+	Schedule string `json:"schedule"`
+
+	// persistentVolumeClaimName is the name of a single PersistentVolumeClaim to snapshot.
+	// Either persistentVolumeClaimName or selector must be specified.
+	// +kubebuilder:validation:Optional
+	PersistentVolumeClaimName string `json:"persistentVolumeClaimName,omitempty"`
+
+	// selector selects the PersistentVolumeClaims in the namespace of the schedule to snapshot.
+	// +kubebuilder:validation:Optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+
+	// volumeSnapshotClassName is the VolumeSnapshotClass used for the created VolumeSnapshots.
+	// +kubebuilder:validation:Optional
+	VolumeSnapshotClassName string `json:"volumeSnapshotClassName,omitempty"`
+
+	// retention defines when the snapshots created by this schedule are expired.
+	// +kubebuilder:validation:Optional
+	Retention CSISnapshotRetention `json:"retention,omitempty"`
+
+	// consistencyGroup snapshots all selected volumes of a consistency group together
+	// and fails the run for the whole group if one of its volumes cannot be snapshotted.
+	// +kubebuilder:default:=true
+	// +kubebuilder:validation:Optional
+	ConsistencyGroup *bool `json:"consistencyGroup,omitempty"`
+
+	// suspend stops creating new snapshots, expiry of existing snapshots continues.
+	// +kubebuilder:default:=false
+	// +kubebuilder:validation:Optional
+	Suspend bool `json:"suspend,omitempty"`
+}
+
+// CSISnapshotRetention defines how many and how old snapshots are kept per volume.
+type CSISnapshotRetention struct {
+
+	// maxCount is the maximum number of snapshots kept per volume.
+	// +kubebuilder:validation:Minimum:=1
+	// +kubebuilder:validation:Maximum:=256
+	// +kubebuilder:validation:Optional
+	MaxCount int32 `json:"maxCount,omitempty"`
+
+	// maxAge is the maximum age of a snapshot, e.g. 168h.
+	// +kubebuilder:validation:Optional
+	MaxAge *metav1.Duration `json:"maxAge,omitempty"`
+}
+
+// CSIScaleSnapshotScheduleStatus defines the observed state of CSIScaleSnapshotSchedule
+type CSIScaleSnapshotScheduleStatus struct {
+
+	// lastScheduleTime is the last time snapshots were created by this schedule.
+	LastScheduleTime *metav1.Time `json:"lastScheduleTime,omitempty"`
+
+	// nextScheduleTime is the next time snapshots are created by this schedule.
+	NextScheduleTime *metav1.Time `json:"nextScheduleTime,omitempty"`
+
+	// snapshotCount is the number of VolumeSnapshots currently kept by this schedule.
+	SnapshotCount int32 `json:"snapshotCount,omitempty"`
+
+	// conditions contains the details for one aspect of the current state of this custom resource.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=csss, categories=scale, scope=Namespaced
+// +kubebuilder:printcolumn:name="Schedule",type=string,JSONPath=`.spec.schedule`,description="Cron schedule."
+// +kubebuilder:printcolumn:name="Suspend",type=boolean,JSONPath=`.spec.suspend`,description="Snapshot creation is suspended."
+// +kubebuilder:printcolumn:name="Last Schedule",type=date,JSONPath=`.status.lastScheduleTime`,description="Last time snapshots were created."
+// +kubebuilder:printcolumn:name="Snapshots",type=integer,JSONPath=`.status.snapshotCount`,description="Number of snapshots kept."
+
+// CSIScaleSnapshotSchedule is the Schema for the csiscalesnapshotschedules API
+type CSIScaleSnapshotSchedule struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CSIScaleSnapshotScheduleSpec   `json:"spec,omitempty"`
+	Status CSIScaleSnapshotScheduleStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// CSIScaleSnapshotScheduleList contains a list of CSIScaleSnapshotSchedule
+type CSIScaleSnapshotScheduleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CSIScaleSnapshotSchedule `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CSIScaleSnapshotSchedule{}, &CSIScaleSnapshotScheduleList{})
+}
+
+const (
+	// SnapshotScheduleReady is the condition type reporting whether the last run of the schedule succeeded.
+	SnapshotScheduleReady = "Ready"
+
+	InvalidSchedule       CSIReason = "InvalidSchedule"
+	SnapshotCreated       CSIReason = "SnapshotCreated"
+	SnapshotCreateFailed  CSIReason = "SnapshotCreateFailed"
+	SnapshotExpired       CSIReason = "SnapshotExpired"
+	SnapshotExpireFailed  CSIReason = "SnapshotExpireFailed"
+	SnapshotLimitReached  CSIReason = "SnapshotLimitReached"
+	SnapshotScheduleValid CSIReason = "SnapshotScheduleValid"
+)
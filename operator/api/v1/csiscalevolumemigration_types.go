@@ -0,0 +1,169 @@
+/*
+Copyright 2026 IBM Corp.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CSIScaleVolumeMigrationSpec specifies the volume to migrate and where to.
+type CSIScaleVolumeMigrationSpec struct {
+
+	// persistentVolumeClaimName is the name of the PersistentVolumeClaim to migrate, in the
+	// namespace of the migration. The PersistentVolumeClaim keeps its name after the migration.
+	// +kubebuilder:validation:MinLength:=1
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="persistentVolumeClaimName is immutable"
+	PersistentVolumeClaimName string `json:"persistentVolumeClaimName"`
+
+	// targetFilesystem is the name of the filesystem the volume is migrated to,
+	// as known on the primary cluster.
+	// +kubebuilder:validation:MinLength:=1
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="targetFilesystem is immutable"
+	TargetFilesystem string `json:"targetFilesystem"`
+
+	// targetClusterId is the ID of the cluster owning targetFilesystem, required when
+	// targetFilesystem is remotely mounted on the primary cluster.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="targetClusterId is immutable"
+	// +kubebuilder:validation:Optional
+	TargetClusterId string `json:"targetClusterId,omitempty"`
+
+	// cutover allows the migration to switch the PersistentVolumeClaim to the migrated volume.
+	// The workloads using the PersistentVolumeClaim must be scaled down for the cutover,
+	// the data is copied again while no pod uses the volume, so files deleted from the source
+	// volume after the initial copy are not carried over.
+	// New pods cannot use the PersistentVolumeClaim while the data is copied again and the
+	// PersistentVolume is replaced, the PersistentVolumeClaim carries the annotation
+	// spectrumscale.csi.ibm.com/migration-cutover meanwhile.
+	// +kubebuilder:default:=false
+	// +kubebuilder:validation:Optional
+	Cutover bool `json:"cutover,omitempty"`
+}
+
+// CSIScaleVolumeMigrationPhase is the phase of a volume migration.
+// +kubebuilder:validation:Enum=Pending;Provisioning;Copying;WaitingForCutover;Syncing;CuttingOver;Completed;Failed
+type CSIScaleVolumeMigrationPhase string
+
+const (
+	VolumeMigrationPending           CSIScaleVolumeMigrationPhase = "Pending"
+	VolumeMigrationProvisioning      CSIScaleVolumeMigrationPhase = "Provisioning"
+	VolumeMigrationCopying           CSIScaleVolumeMigrationPhase = "Copying"
+	VolumeMigrationWaitingForCutover CSIScaleVolumeMigrationPhase = "WaitingForCutover"
+	VolumeMigrationSyncing           CSIScaleVolumeMigrationPhase = "Syncing"
+	VolumeMigrationCuttingOver       CSIScaleVolumeMigrationPhase = "CuttingOver"
+	VolumeMigrationCompleted         CSIScaleVolumeMigrationPhase = "Completed"
+	VolumeMigrationFailed            CSIScaleVolumeMigrationPhase = "Failed"
+)
+
+// CSIScaleVolumeMigrationStatus defines the observed state of CSIScaleVolumeMigration
+type CSIScaleVolumeMigrationStatus struct {
+
+	// phase is the current phase of the migration.
+	Phase CSIScaleVolumeMigrationPhase `json:"phase,omitempty"`
+
+	// persistentVolumeName is the name of the PersistentVolume bound to the PersistentVolumeClaim.
+	PersistentVolumeName string `json:"persistentVolumeName,omitempty"`
+
+	// sourceVolumeHandle is the volume handle of the PersistentVolume before the migration.
+	SourceVolumeHandle string `json:"sourceVolumeHandle,omitempty"`
+
+	// targetVolumeHandle is the volume handle of the PersistentVolume after the migration.
+	TargetVolumeHandle string `json:"targetVolumeHandle,omitempty"`
+
+	// totalBytes is the space used by the source volume when the copy started.
+	TotalBytes int64 `json:"totalBytes,omitempty"`
+
+	// copiedBytes is the space used by the target volume.
+	CopiedBytes int64 `json:"copiedBytes,omitempty"`
+
+	// progress is the copied data in percent of the source volume.
+	Progress int32 `json:"progress,omitempty"`
+
+	// copyJob is the IBM Storage Scale job copying the data of the current phase.
+	CopyJob *CSIScaleVolumeMigrationCopyJob `json:"copyJob,omitempty"`
+
+	// startTime is the time the migration started.
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// completionTime is the time the migration completed.
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// conditions contains the details for one aspect of the current state of this custom resource.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// CSIScaleVolumeMigrationCopyJob is an IBM Storage Scale job copying the data of a migration.
+type CSIScaleVolumeMigrationCopyJob struct {
+
+	// phase is the phase of the migration the job copies the data for.
+	Phase CSIScaleVolumeMigrationPhase `json:"phase"`
+
+	// jobId is the ID of the job.
+	JobID int64 `json:"jobId"`
+
+	// statusCode is the HTTP status code the job was submitted with.
+	StatusCode int `json:"statusCode"`
+
+	// completed is set once the job finished.
+	Completed bool `json:"completed,omitempty"`
+
+	// error is the error the job failed with.
+	Error string `json:"error,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=csvm, categories=scale, scope=Namespaced
+// +kubebuilder:printcolumn:name="PVC",type=string,JSONPath=`.spec.persistentVolumeClaimName`,description="Migrated PersistentVolumeClaim."
+// +kubebuilder:printcolumn:name="Target Filesystem",type=string,JSONPath=`.spec.targetFilesystem`,description="Filesystem the volume is migrated to."
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`,description="Phase of the migration."
+// +kubebuilder:printcolumn:name="Progress",type=integer,JSONPath=`.status.progress`,description="Copied data in percent."
+
+// CSIScaleVolumeMigration is the Schema for the csiscalevolumemigrations API
+type CSIScaleVolumeMigration struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CSIScaleVolumeMigrationSpec   `json:"spec,omitempty"`
+	Status CSIScaleVolumeMigrationStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// CSIScaleVolumeMigrationList contains a list of CSIScaleVolumeMigration
+type CSIScaleVolumeMigrationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CSIScaleVolumeMigration `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CSIScaleVolumeMigration{}, &CSIScaleVolumeMigrationList{})
+}
+
+const (
+	// VolumeMigrationReady is the condition type reporting whether the migration makes progress.
+	VolumeMigrationReady = "Ready"
+
+	MigrationInvalid         CSIReason = "MigrationInvalid"
+	MigrationProvisioned     CSIReason = "MigrationProvisioned"
+	MigrationProvisionFailed CSIReason = "MigrationProvisionFailed"
+	MigrationCopying         CSIReason = "MigrationCopying"
+	MigrationCopyFailed      CSIReason = "MigrationCopyFailed"
+	MigrationWaiting         CSIReason = "MigrationWaitingForCutover"
+	MigrationCutoverFailed   CSIReason = "MigrationCutoverFailed"
+	MigrationCompleted       CSIReason = "MigrationCompleted"
+)
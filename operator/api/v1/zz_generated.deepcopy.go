@@ -236,6 +236,265 @@ func (in *CSIScaleOperatorStatus) DeepCopy() *CSIScaleOperatorStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CSIScaleSnapshotSchedule) DeepCopyInto(out *CSIScaleSnapshotSchedule) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CSIScaleSnapshotSchedule.
+func (in *CSIScaleSnapshotSchedule) DeepCopy() *CSIScaleSnapshotSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(CSIScaleSnapshotSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CSIScaleSnapshotSchedule) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CSIScaleSnapshotScheduleList) DeepCopyInto(out *CSIScaleSnapshotScheduleList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CSIScaleSnapshotSchedule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CSIScaleSnapshotScheduleList.
+func (in *CSIScaleSnapshotScheduleList) DeepCopy() *CSIScaleSnapshotScheduleList {
+	if in == nil {
+		return nil
+	}
+	out := new(CSIScaleSnapshotScheduleList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CSIScaleSnapshotScheduleList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CSIScaleSnapshotScheduleSpec) DeepCopyInto(out *CSIScaleSnapshotScheduleSpec) {
+	*out = *in
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Retention.DeepCopyInto(&out.Retention)
+	if in.ConsistencyGroup != nil {
+		in, out := &in.ConsistencyGroup, &out.ConsistencyGroup
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CSIScaleSnapshotScheduleSpec.
+func (in *CSIScaleSnapshotScheduleSpec) DeepCopy() *CSIScaleSnapshotScheduleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CSIScaleSnapshotScheduleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CSIScaleSnapshotScheduleStatus) DeepCopyInto(out *CSIScaleSnapshotScheduleStatus) {
+	*out = *in
+	if in.LastScheduleTime != nil {
+		in, out := &in.LastScheduleTime, &out.LastScheduleTime
+		*out = (*in).DeepCopy()
+	}
+	if in.NextScheduleTime != nil {
+		in, out := &in.NextScheduleTime, &out.NextScheduleTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CSIScaleSnapshotScheduleStatus.
+func (in *CSIScaleSnapshotScheduleStatus) DeepCopy() *CSIScaleSnapshotScheduleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CSIScaleSnapshotScheduleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CSIScaleVolumeMigration) DeepCopyInto(out *CSIScaleVolumeMigration) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CSIScaleVolumeMigration.
+func (in *CSIScaleVolumeMigration) DeepCopy() *CSIScaleVolumeMigration {
+	if in == nil {
+		return nil
+	}
+	out := new(CSIScaleVolumeMigration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CSIScaleVolumeMigration) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CSIScaleVolumeMigrationCopyJob) DeepCopyInto(out *CSIScaleVolumeMigrationCopyJob) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CSIScaleVolumeMigrationCopyJob.
+func (in *CSIScaleVolumeMigrationCopyJob) DeepCopy() *CSIScaleVolumeMigrationCopyJob {
+	if in == nil {
+		return nil
+	}
+	out := new(CSIScaleVolumeMigrationCopyJob)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CSIScaleVolumeMigrationList) DeepCopyInto(out *CSIScaleVolumeMigrationList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CSIScaleVolumeMigration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CSIScaleVolumeMigrationList.
+func (in *CSIScaleVolumeMigrationList) DeepCopy() *CSIScaleVolumeMigrationList {
+	if in == nil {
+		return nil
+	}
+	out := new(CSIScaleVolumeMigrationList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CSIScaleVolumeMigrationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CSIScaleVolumeMigrationSpec) DeepCopyInto(out *CSIScaleVolumeMigrationSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CSIScaleVolumeMigrationSpec.
+func (in *CSIScaleVolumeMigrationSpec) DeepCopy() *CSIScaleVolumeMigrationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CSIScaleVolumeMigrationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CSIScaleVolumeMigrationStatus) DeepCopyInto(out *CSIScaleVolumeMigrationStatus) {
+	*out = *in
+	if in.CopyJob != nil {
+		in, out := &in.CopyJob, &out.CopyJob
+		*out = new(CSIScaleVolumeMigrationCopyJob)
+		**out = **in
+	}
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CSIScaleVolumeMigrationStatus.
+func (in *CSIScaleVolumeMigrationStatus) DeepCopy() *CSIScaleVolumeMigrationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CSIScaleVolumeMigrationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CSISnapshotRetention) DeepCopyInto(out *CSISnapshotRetention) {
+	*out = *in
+	if in.MaxAge != nil {
+		in, out := &in.MaxAge, &out.MaxAge
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CSISnapshotRetention.
+func (in *CSISnapshotRetention) DeepCopy() *CSISnapshotRetention {
+	if in == nil {
+		return nil
+	}
+	out := new(CSISnapshotRetention)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodeMapping) DeepCopyInto(out *NodeMapping) {
 	*out = *in
@@ -153,6 +153,7 @@ const (
 	EnvPersistentLogKey               = "PERSISTENT_LOG"
 	EnvNodePublishMethodKey           = "NODEPUBLISH_METHOD"
 	EnvVolumeStatsCapabilityKey       = "VOLUME_STATS_CAPABILITY"
+	EnvSnapshotDiffTreeWalkKey        = "SNAPSHOT_DIFF_TREE_WALK"
 	HostNetworkKey                    = "HOST_NETWORK"
 	EnvVolNamePrefixKey               = "VOLUME_NAME_PREFIX"
 
@@ -161,6 +162,7 @@ const (
 	EnvPersistentLogKeyPrefixed         = EnvVarPrefix + EnvPersistentLogKey
 	EnvNodePublishMethodKeyPrefixed     = EnvVarPrefix + EnvNodePublishMethodKey
 	EnvVolumeStatsCapabilityKeyPrefixed = EnvVarPrefix + EnvVolumeStatsCapabilityKey
+	EnvSnapshotDiffTreeWalkKeyPrefixed  = EnvVarPrefix + EnvSnapshotDiffTreeWalkKey
 	EnvVolNamePrefixKeyPrefixed         = EnvVarPrefix + EnvVolNamePrefixKey
 
 	// Optional ConfigMap default values if not provided in the cm
@@ -193,6 +195,7 @@ var CSIOptionalConfigMapKeys = []string{
 	EnvPersistentLogKeyPrefixed,
 	EnvNodePublishMethodKeyPrefixed,
 	EnvVolumeStatsCapabilityKeyPrefixed,
+	EnvSnapshotDiffTreeWalkKeyPrefixed,
 	DaemonSetUpgradeMaxUnavailableKey,
 	EnvVolNamePrefixKeyPrefixed,
 	HostNetworkKey,
@@ -206,6 +209,7 @@ var EnvLogLevelValues = []string{"TRACE", "DEBUG", "INFO", "WARNING", "ERROR", "
 var EnvNodePublishMethodValues = []string{"SYMLINK", "BINDMOUNT"}
 var EnvPersistentLogValues = []string{"ENABLED", "DISABLED"}
 var EnvVolumeStatsCapabilityValues = []string{"ENABLED", "DISABLED"}
+var EnvSnapshotDiffTreeWalkValues = []string{"ENABLED", "DISABLED"}
 var EnvHostNetworkValues = []string{"ENABLED", "DISABLED"}
 
 const (
@@ -2436,6 +2436,8 @@ func (r *CSIScaleOperatorReconciler) parseConfigMap(ctx context.Context, instanc
 				validateEnvVarValue(config.EnvNodePublishMethodValues[:], keyUpper, value, validEnvMap, invalidEnvValueMap)
 			case config.EnvVolumeStatsCapabilityKeyPrefixed:
 				validateEnvVarValue(config.EnvVolumeStatsCapabilityValues[:], keyUpper, value, validEnvMap, invalidEnvValueMap)
+			case config.EnvSnapshotDiffTreeWalkKeyPrefixed:
+				validateEnvVarValue(config.EnvSnapshotDiffTreeWalkValues[:], keyUpper, value, validEnvMap, invalidEnvValueMap)
 			case config.EnvVolNamePrefixKeyPrefixed:
 				validateVolNamePrefix(ctx, keyUpper, strings.ToLower(value), validEnvMap, invalidEnvValueMap)
 			case config.DaemonSetUpgradeMaxUnavailableKey:
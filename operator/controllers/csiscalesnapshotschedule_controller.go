@@ -0,0 +1,492 @@
+/*
+Copyright 2026 IBM Corp.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	csiLog "sigs.k8s.io/controller-runtime/pkg/log"
+
+	csiv1 "github.com/IBM/ibm-spectrum-scale-csi/operator/api/v1"
+	config "github.com/IBM/ibm-spectrum-scale-csi/operator/controllers/config"
+	"github.com/IBM/ibm-spectrum-scale-csi/operator/controllers/util/cron"
+	"github.com/IBM/ibm-spectrum-scale-csi/operator/controllers/util/volumehandle"
+)
+
+// +kubebuilder:rbac:groups="snapshot.storage.k8s.io",resources=volumesnapshots,verbs=create;delete;get;list;watch
+// +kubebuilder:rbac:groups="snapshot.storage.k8s.io",resources=volumesnapshotcontents,verbs=get;list
+// +kubebuilder:rbac:groups="",resources=persistentvolumes,verbs=get;list;watch
+
+// CSIScaleSnapshotScheduleReconciler reconciles a CSIScaleSnapshotSchedule object
+type CSIScaleSnapshotScheduleReconciler struct {
+	Client   client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+}
+
+const (
+	CSIScaleSnapshotScheduleControllerName = "CSIScaleSnapshotSchedule"
+
+	// snapshotScheduleLabel is set on every VolumeSnapshot created by a schedule
+	snapshotScheduleLabel = "csi.ibm.com/snapshot-schedule"
+	// snapshotPVCLabel holds the name of the snapshotted PersistentVolumeClaim
+	snapshotPVCLabel = "csi.ibm.com/snapshot-pvc"
+
+	// maxSnapshotsPerFileset is the snapshot limit of a fileset enforced by the driver
+	maxSnapshotsPerFileset = 256
+	storageClassAdvanced   = "1"
+)
+
+var (
+	volumeSnapshotListGVK        = schema.GroupVersionKind{Group: "snapshot.storage.k8s.io", Version: "v1", Kind: "VolumeSnapshotList"}
+	volumeSnapshotGVK            = schema.GroupVersionKind{Group: "snapshot.storage.k8s.io", Version: "v1", Kind: "VolumeSnapshot"}
+	volumeSnapshotContentListGVK = schema.GroupVersionKind{Group: "snapshot.storage.k8s.io", Version: "v1", Kind: "VolumeSnapshotContentList"}
+)
+
+// scheduledVolume is a PersistentVolumeClaim selected by a schedule together
+// with the fileset which holds its snapshots.
+type scheduledVolume struct {
+	pvcName string
+	// filesetKey identifies the fileset whose snapshots count against the
+	// limit, the consistency group fileset for consistency group volumes.
+	filesetKey         string
+	isConsistencyGroup bool
+}
+
+// Reconcile creates the VolumeSnapshots of a CSIScaleSnapshotSchedule when they
+// are due and expires the ones which are beyond the retention.
+func (r *CSIScaleSnapshotScheduleReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := csiLog.FromContext(ctx).WithName("SnapshotScheduleReconcile")
+
+	instance := &csiv1.CSIScaleSnapshotSchedule{}
+	err := r.Client.Get(ctx, req.NamespacedName, instance)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			logger.Info("CSIScaleSnapshotSchedule resource not found. Ignoring since object must be deleted.", "name", req.NamespacedName)
+			return ctrl.Result{}, nil
+		}
+		logger.Error(err, "Failed to get CSIScaleSnapshotSchedule.")
+		return ctrl.Result{}, err
+	}
+
+	schedule, err := cron.Parse(instance.Spec.Schedule)
+	if err == nil && instance.Spec.PersistentVolumeClaimName == "" && instance.Spec.Selector == nil {
+		err = fmt.Errorf("either persistentVolumeClaimName or selector must be specified")
+	}
+	if err != nil {
+		message := fmt.Sprintf("Invalid snapshot schedule %s: %v", req.NamespacedName, err)
+		logger.Error(err, message)
+		r.setScheduleCondition(instance, corev1.EventTypeWarning, metav1.ConditionFalse, csiv1.InvalidSchedule, message)
+		return ctrl.Result{}, r.Client.Status().Update(ctx, instance)
+	}
+
+	now := time.Now().UTC()
+	snapshots, err := r.listScheduleSnapshots(ctx, instance)
+	if err != nil {
+		logger.Error(err, "Failed to list the VolumeSnapshots of the schedule.")
+		return ctrl.Result{}, err
+	}
+
+	remaining, nextExpiry := r.expireSnapshots(ctx, instance, snapshots, now)
+
+	lastScheduleTime := instance.CreationTimestamp.Time
+	if instance.Status.LastScheduleTime != nil {
+		lastScheduleTime = instance.Status.LastScheduleTime.Time
+	}
+	nextScheduleTime := schedule.Next(lastScheduleTime.UTC())
+
+	if !instance.Spec.Suspend && !nextScheduleTime.IsZero() && !now.Before(nextScheduleTime) {
+		created, err := r.createScheduledSnapshots(ctx, instance, nextScheduleTime)
+		if err != nil {
+			message := fmt.Sprintf("Failed to create snapshots for schedule %s: %v", req.NamespacedName, err)
+			logger.Error(err, message)
+			r.setScheduleCondition(instance, corev1.EventTypeWarning, metav1.ConditionFalse, csiv1.SnapshotCreateFailed, message)
+		} else {
+			message := fmt.Sprintf("Created %d snapshots for schedule %s", created, req.NamespacedName)
+			logger.Info(message)
+			r.setScheduleCondition(instance, corev1.EventTypeNormal, metav1.ConditionTrue, csiv1.SnapshotCreated, message)
+		}
+		remaining += created
+		instance.Status.LastScheduleTime = &metav1.Time{Time: now}
+		nextScheduleTime = schedule.Next(now)
+	} else if meta.FindStatusCondition(instance.Status.Conditions, csiv1.SnapshotScheduleReady) == nil {
+		meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+			Type:    csiv1.SnapshotScheduleReady,
+			Status:  metav1.ConditionTrue,
+			Reason:  string(csiv1.SnapshotScheduleValid),
+			Message: "Snapshot schedule is valid",
+		})
+	}
+
+	instance.Status.SnapshotCount = int32(remaining)
+	if nextScheduleTime.IsZero() {
+		instance.Status.NextScheduleTime = nil
+	} else {
+		instance.Status.NextScheduleTime = &metav1.Time{Time: nextScheduleTime}
+	}
+	if err := r.Client.Status().Update(ctx, instance); err != nil {
+		logger.Error(err, "Failed to update the status of CSIScaleSnapshotSchedule.")
+		return ctrl.Result{}, err
+	}
+
+	requeueAt := nextScheduleTime
+	if requeueAt.IsZero() || (!nextExpiry.IsZero() && nextExpiry.Before(requeueAt)) {
+		requeueAt = nextExpiry
+	}
+	if requeueAt.IsZero() {
+		return ctrl.Result{}, nil
+	}
+	requeueAfter := requeueAt.Sub(time.Now().UTC())
+	if requeueAfter < time.Second {
+		requeueAfter = time.Second
+	}
+	logger.Info("Snapshot schedule reconciled.", "name", req.NamespacedName, "requeueAfter", requeueAfter.String())
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// listScheduleSnapshots returns the VolumeSnapshots created by the schedule.
+func (r *CSIScaleSnapshotScheduleReconciler) listScheduleSnapshots(ctx context.Context, instance *csiv1.CSIScaleSnapshotSchedule) ([]unstructured.Unstructured, error) {
+	snapshotList := &unstructured.UnstructuredList{}
+	snapshotList.SetGroupVersionKind(volumeSnapshotListGVK)
+	err := r.Client.List(ctx, snapshotList, client.InNamespace(instance.Namespace), client.MatchingLabels{snapshotScheduleLabel: instance.Name})
+	if err != nil {
+		return nil, err
+	}
+	return snapshotList.Items, nil
+}
+
+// expireSnapshots deletes the snapshots of every volume which exceed the
+// retention count or age. It returns the number of remaining snapshots and the
+// time the next snapshot expires by age.
+func (r *CSIScaleSnapshotScheduleReconciler) expireSnapshots(ctx context.Context, instance *csiv1.CSIScaleSnapshotSchedule, snapshots []unstructured.Unstructured, now time.Time) (int, time.Time) {
+	logger := csiLog.FromContext(ctx).WithName("expireSnapshots")
+	retention := instance.Spec.Retention
+
+	snapshotsByPVC := map[string][]unstructured.Unstructured{}
+	for _, snapshot := range snapshots {
+		if snapshot.GetDeletionTimestamp() != nil {
+			continue
+		}
+		pvcName := snapshot.GetLabels()[snapshotPVCLabel]
+		snapshotsByPVC[pvcName] = append(snapshotsByPVC[pvcName], snapshot)
+	}
+
+	remaining := 0
+	nextExpiry := time.Time{}
+	for _, pvcSnapshots := range snapshotsByPVC {
+		// newest first
+		sort.Slice(pvcSnapshots, func(i, j int) bool {
+			return pvcSnapshots[i].GetCreationTimestamp().After(pvcSnapshots[j].GetCreationTimestamp().Time)
+		})
+		for i := range pvcSnapshots {
+			snapshot := &pvcSnapshots[i]
+			created := snapshot.GetCreationTimestamp().Time
+			reason := ""
+			if retention.MaxCount > 0 && i >= int(retention.MaxCount) {
+				reason = fmt.Sprintf("more than %d snapshots exist", retention.MaxCount)
+			} else if retention.MaxAge != nil && now.Sub(created) > retention.MaxAge.Duration {
+				reason = fmt.Sprintf("it is older than %s", retention.MaxAge.Duration.String())
+			}
+
+			if reason == "" {
+				remaining++
+				if retention.MaxAge != nil {
+					expiry := created.Add(retention.MaxAge.Duration)
+					if nextExpiry.IsZero() || expiry.Before(nextExpiry) {
+						nextExpiry = expiry
+					}
+				}
+				continue
+			}
+
+			if err := r.Client.Delete(ctx, snapshot); err != nil && !errors.IsNotFound(err) {
+				message := fmt.Sprintf("Failed to expire VolumeSnapshot %s/%s: %v", snapshot.GetNamespace(), snapshot.GetName(), err)
+				logger.Error(err, message)
+				RaiseCSOEvent(instance, r.Recorder, corev1.EventTypeWarning, string(csiv1.SnapshotExpireFailed), message)
+				remaining++
+				continue
+			}
+			message := fmt.Sprintf("Expired VolumeSnapshot %s/%s as %s", snapshot.GetNamespace(), snapshot.GetName(), reason)
+			logger.Info(message)
+			RaiseCSOEvent(instance, r.Recorder, corev1.EventTypeNormal, string(csiv1.SnapshotExpired), message)
+		}
+	}
+	return remaining, nextExpiry
+}
+
+// createScheduledSnapshots creates one VolumeSnapshot for every selected volume
+// and returns the number of created snapshots. Volumes of a fileset which has
+// reached the snapshot limit are skipped. With consistency group awareness all
+// volumes of a consistency group are snapshotted together or not at all.
+// The snapshots are named after the scheduled time, so a retried reconcile of
+// the same schedule time finds the snapshots it created before.
+func (r *CSIScaleSnapshotScheduleReconciler) createScheduledSnapshots(ctx context.Context, instance *csiv1.CSIScaleSnapshotSchedule, scheduleTime time.Time) (int, error) {
+	logger := csiLog.FromContext(ctx).WithName("createScheduledSnapshots")
+
+	volumes, err := r.getScheduledVolumes(ctx, instance)
+	if err != nil {
+		return 0, err
+	}
+	snapshotCounts, err := r.getFilesetSnapshotCounts(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	consistencyGroupAware := instance.Spec.ConsistencyGroup == nil || *instance.Spec.ConsistencyGroup
+	groups := map[string][]scheduledVolume{}
+	groupKeys := []string{}
+	for _, volume := range volumes {
+		key := volume.filesetKey
+		if !consistencyGroupAware || !volume.isConsistencyGroup {
+			// every volume is its own group
+			key = volume.filesetKey + "/" + volume.pvcName
+		}
+		if _, ok := groups[key]; !ok {
+			groupKeys = append(groupKeys, key)
+		}
+		groups[key] = append(groups[key], volume)
+	}
+	sort.Strings(groupKeys)
+
+	created := 0
+	var failures []string
+	for _, key := range groupKeys {
+		group := groups[key]
+		filesetKey := group[0].filesetKey
+		// volumes of one consistency group share a single fileset snapshot
+		if snapshotCounts[filesetKey]+1 > maxSnapshotsPerFileset {
+			message := fmt.Sprintf("Skipped snapshot of %s as fileset %s reached the limit of %d snapshots", pvcNames(group), filesetKey, maxSnapshotsPerFileset)
+			logger.Info(message)
+			RaiseCSOEvent(instance, r.Recorder, corev1.EventTypeWarning, string(csiv1.SnapshotLimitReached), message)
+			failures = append(failures, message)
+			continue
+		}
+
+		groupSnapshots := []*unstructured.Unstructured{}
+		var groupErr error
+		for _, volume := range group {
+			snapshot := r.newVolumeSnapshot(instance, volume.pvcName, scheduleTime)
+			if err := r.Client.Create(ctx, snapshot); err != nil && !errors.IsAlreadyExists(err) {
+				groupErr = fmt.Errorf("failed to create VolumeSnapshot %s/%s: %v", snapshot.GetNamespace(), snapshot.GetName(), err)
+				break
+			}
+			groupSnapshots = append(groupSnapshots, snapshot)
+		}
+
+		if groupErr != nil && len(group) > 1 {
+			// roll back the partial consistency group snapshot
+			for _, snapshot := range groupSnapshots {
+				if err := r.Client.Delete(ctx, snapshot); err != nil && !errors.IsNotFound(err) {
+					logger.Error(err, "Failed to delete VolumeSnapshot of incomplete consistency group snapshot", "name", snapshot.GetName())
+				}
+			}
+			groupSnapshots = nil
+		}
+		if groupErr != nil {
+			logger.Error(groupErr, "Failed to snapshot volumes", "pvcs", pvcNames(group))
+			RaiseCSOEvent(instance, r.Recorder, corev1.EventTypeWarning, string(csiv1.SnapshotCreateFailed), groupErr.Error())
+			failures = append(failures, groupErr.Error())
+		}
+		if len(groupSnapshots) > 0 {
+			snapshotCounts[filesetKey]++
+			created += len(groupSnapshots)
+		}
+	}
+
+	if len(failures) > 0 {
+		return created, fmt.Errorf("%s", strings.Join(failures, "; "))
+	}
+	return created, nil
+}
+
+// getScheduledVolumes returns the bound PersistentVolumeClaims of this driver
+// selected by the schedule.
+func (r *CSIScaleSnapshotScheduleReconciler) getScheduledVolumes(ctx context.Context, instance *csiv1.CSIScaleSnapshotSchedule) ([]scheduledVolume, error) {
+	logger := csiLog.FromContext(ctx).WithName("getScheduledVolumes")
+
+	pvcs := []corev1.PersistentVolumeClaim{}
+	if instance.Spec.PersistentVolumeClaimName != "" {
+		pvc := corev1.PersistentVolumeClaim{}
+		err := r.Client.Get(ctx, client.ObjectKey{Namespace: instance.Namespace, Name: instance.Spec.PersistentVolumeClaimName}, &pvc)
+		if err != nil {
+			return nil, err
+		}
+		pvcs = append(pvcs, pvc)
+	} else {
+		selector, err := metav1.LabelSelectorAsSelector(instance.Spec.Selector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid selector: %v", err)
+		}
+		pvcList := corev1.PersistentVolumeClaimList{}
+		err = r.Client.List(ctx, &pvcList, client.InNamespace(instance.Namespace), client.MatchingLabelsSelector{Selector: selector})
+		if err != nil {
+			return nil, err
+		}
+		pvcs = pvcList.Items
+	}
+
+	volumes := []scheduledVolume{}
+	for _, pvc := range pvcs {
+		if pvc.Status.Phase != corev1.ClaimBound || pvc.Spec.VolumeName == "" {
+			logger.Info("Skipping PersistentVolumeClaim which is not bound", "name", pvc.Name)
+			continue
+		}
+		pv := corev1.PersistentVolume{}
+		if err := r.Client.Get(ctx, client.ObjectKey{Name: pvc.Spec.VolumeName}, &pv); err != nil {
+			return nil, err
+		}
+		if pv.Spec.CSI == nil || pv.Spec.CSI.Driver != config.DriverName {
+			logger.Info("Skipping PersistentVolumeClaim which is not provisioned by IBM Storage Scale CSI driver", "name", pvc.Name)
+			continue
+		}
+		filesetKey, isConsistencyGroup := filesetKeyFromVolumeHandle(pv.Spec.CSI.VolumeHandle)
+		if filesetKey == "" {
+			logger.Info("Skipping PersistentVolumeClaim which does not support snapshots", "name", pvc.Name)
+			continue
+		}
+		volumes = append(volumes, scheduledVolume{pvcName: pvc.Name, filesetKey: filesetKey, isConsistencyGroup: isConsistencyGroup})
+	}
+	return volumes, nil
+}
+
+// getFilesetSnapshotCounts returns the number of snapshots of this driver per
+// fileset, counted from the VolumeSnapshotContents in the cluster.
+func (r *CSIScaleSnapshotScheduleReconciler) getFilesetSnapshotCounts(ctx context.Context) (map[string]int, error) {
+	contentList := &unstructured.UnstructuredList{}
+	contentList.SetGroupVersionKind(volumeSnapshotContentListGVK)
+	if err := r.Client.List(ctx, contentList); err != nil {
+		return nil, err
+	}
+
+	snapshots := map[string]map[string]bool{}
+	for _, content := range contentList.Items {
+		driverName, _, _ := unstructured.NestedString(content.Object, "spec", "driver")
+		if driverName != config.DriverName {
+			continue
+		}
+		snapHandle, found, _ := unstructured.NestedString(content.Object, "status", "snapshotHandle")
+		if !found {
+			continue
+		}
+		filesetKey, snapName := filesetKeyFromSnapshotHandle(snapHandle)
+		if filesetKey == "" {
+			continue
+		}
+		if snapshots[filesetKey] == nil {
+			snapshots[filesetKey] = map[string]bool{}
+		}
+		snapshots[filesetKey][snapName] = true
+	}
+
+	counts := map[string]int{}
+	for filesetKey, snapNames := range snapshots {
+		counts[filesetKey] = len(snapNames)
+	}
+	return counts, nil
+}
+
+func (r *CSIScaleSnapshotScheduleReconciler) newVolumeSnapshot(instance *csiv1.CSIScaleSnapshotSchedule, pvcName string, scheduleTime time.Time) *unstructured.Unstructured {
+	snapshot := &unstructured.Unstructured{}
+	snapshot.SetGroupVersionKind(volumeSnapshotGVK)
+	snapshot.SetNamespace(instance.Namespace)
+	snapshot.SetName(fmt.Sprintf("%s-%s-%s", instance.Name, pvcName, scheduleTime.UTC().Format("20060102150405")))
+	snapshot.SetLabels(map[string]string{
+		snapshotScheduleLabel: instance.Name,
+		snapshotPVCLabel:      pvcName,
+	})
+	_ = unstructured.SetNestedField(snapshot.Object, pvcName, "spec", "source", "persistentVolumeClaimName")
+	if instance.Spec.VolumeSnapshotClassName != "" {
+		_ = unstructured.SetNestedField(snapshot.Object, instance.Spec.VolumeSnapshotClassName, "spec", "volumeSnapshotClassName")
+	}
+	return snapshot
+}
+
+func (r *CSIScaleSnapshotScheduleReconciler) setScheduleCondition(instance *csiv1.CSIScaleSnapshotSchedule, eventType string, status metav1.ConditionStatus, reason csiv1.CSIReason, message string) {
+	meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+		Type:    csiv1.SnapshotScheduleReady,
+		Status:  status,
+		Reason:  string(reason),
+		Message: message,
+	})
+	RaiseCSOEvent(instance, r.Recorder, eventType, string(reason), message)
+}
+
+// filesetKeyFromVolumeHandle returns the fileset holding the snapshots of a
+// volume as <filesystem uuid>/<fileset> and whether the volume belongs to a
+// consistency group. An empty key is returned for volumes without snapshot support.
+func filesetKeyFromVolumeHandle(volumeHandle string) (string, bool) {
+	// <storageclass_type>;<type_of_volume>;<cluster_id>;<filesystem_uuid>;<consistency_group>;<fileset_name>;<path>
+	members := volumehandle.Split(volumeHandle, 7)
+	if len(members) != 7 || members[5] == "" {
+		return "", false
+	}
+	if members[0] == storageClassAdvanced {
+		return members[3] + "/" + members[4], true
+	}
+	return members[3] + "/" + members[5], false
+}
+
+// filesetKeyFromSnapshotHandle returns the fileset key and the name of the
+// fileset snapshot referenced by a snapshot handle.
+func filesetKeyFromSnapshotHandle(snapHandle string) (string, string) {
+	members := volumehandle.Split(snapHandle, 9)
+	switch len(members) {
+	case 8, 9:
+		// <storageclass_type>;<volume_type>;<cluster_id>;<filesystem_uuid>;<consistency_group>;<fileset_name>;<snapshot_name>;<meta_snapshot_name>[;<path>]
+		if members[0] == storageClassAdvanced {
+			return members[3] + "/" + members[4], members[6]
+		}
+		return members[3] + "/" + members[5], members[6]
+	case 4, 5:
+		// <cluster_id>;<filesystem_uuid>;<fileset_name>;<snapshot_name>[;<path>]
+		return members[1] + "/" + members[2], members[3]
+	}
+	return "", ""
+}
+
+func pvcNames(volumes []scheduledVolume) string {
+	names := make([]string, 0, len(volumes))
+	for _, volume := range volumes {
+		names = append(names, volume.pvcName)
+	}
+	return strings.Join(names, ",")
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *CSIScaleSnapshotScheduleReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	logger := csiLog.Log.WithName("SetupWithManager")
+	logger.Info("Setting up the snapshot schedule controller with the manager.")
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(CSIScaleSnapshotScheduleControllerName).
+		For(&csiv1.CSIScaleSnapshotSchedule{}).
+		Complete(r)
+}
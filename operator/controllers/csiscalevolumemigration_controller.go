@@ -0,0 +1,811 @@
+/*
+Copyright 2026 IBM Corp.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	csiLog "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/IBM/ibm-spectrum-scale-csi/driver/csiplugin/connectors"
+	csiv1 "github.com/IBM/ibm-spectrum-scale-csi/operator/api/v1"
+	config "github.com/IBM/ibm-spectrum-scale-csi/operator/controllers/config"
+	"github.com/IBM/ibm-spectrum-scale-csi/operator/controllers/util/volumehandle"
+)
+
+// +kubebuilder:rbac:groups="",resources=persistentvolumes,verbs=create;delete;get;list;patch;update;watch
+// +kubebuilder:rbac:groups="storage.k8s.io",resources=volumeattachments,verbs=get;list
+
+// CSIScaleVolumeMigrationReconciler reconciles a CSIScaleVolumeMigration object
+type CSIScaleVolumeMigrationReconciler struct {
+	Client   client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+}
+
+const (
+	CSIScaleVolumeMigrationControllerName = "CSIScaleVolumeMigration"
+
+	// migrationTargetPVAnnotation holds the PersistentVolume which replaces the
+	// migrated one, saved before the source PersistentVolume is deleted.
+	migrationTargetPVAnnotation = "csi.ibm.com/migration-target-pv"
+
+	// migrationCutoverAnnotation is set on the migrated PersistentVolumeClaim
+	// during the final sync and the cutover. The driver refuses to publish
+	// the volume to new pods while it is set, the name is shared with
+	// driver/csiplugin/migrationcutover.go.
+	migrationCutoverAnnotation = "spectrumscale.csi.ibm.com/migration-cutover"
+
+	migrationPollInterval = 30 * time.Second
+	migrationRetryDelay   = time.Minute
+
+	storageClassClassic         = "0"
+	dependentFilesetVolume      = "1"
+	independentFilesetVolume    = "2"
+	filesystemTypeRemote        = "remote"
+	defaultIndependentInodeSize = "1M"
+)
+
+// migrationCopyWaiters holds the IDs of the copy jobs waited for by this
+// operator. The jobs themselves are recorded in the status of the migrations,
+// a job found there without a waiter is waited for again.
+var migrationCopyWaiters sync.Map
+
+// scaleVolumeHandle holds the members of the volume handle of a fileset based volume.
+type scaleVolumeHandle struct {
+	storageClassType string
+	volumeType       string
+	clusterId        string
+	fsUUID           string
+	consistencyGroup string
+	filesetName      string
+	path             string
+}
+
+// parseVolumeHandle splits
+// <storageclass_type>;<type_of_volume>;<cluster_id>;<filesystem_uuid>;<consistency_group>;<fileset_name>;<path>
+func parseVolumeHandle(volumeHandle string) (scaleVolumeHandle, error) {
+	members := volumehandle.Split(volumeHandle, 7)
+	if len(members) != 7 {
+		return scaleVolumeHandle{}, fmt.Errorf("unsupported volume handle %s", volumeHandle)
+	}
+	return scaleVolumeHandle{
+		storageClassType: members[0],
+		volumeType:       members[1],
+		clusterId:        members[2],
+		fsUUID:           members[3],
+		consistencyGroup: members[4],
+		filesetName:      members[5],
+		path:             members[6],
+	}, nil
+}
+
+func (h scaleVolumeHandle) String() string {
+	return volumehandle.Join(h.storageClassType, h.volumeType, h.clusterId, h.fsUUID, h.consistencyGroup, h.filesetName, h.path)
+}
+
+// Reconcile moves a CSIScaleVolumeMigration through its phases. The data is copied
+// to a fileset with the same name on the target filesystem while the volume stays
+// in use, and the PersistentVolume is replaced by one referencing the target fileset
+// once the cutover is allowed and no pod uses the volume anymore.
+func (r *CSIScaleVolumeMigrationReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := csiLog.FromContext(ctx).WithName("VolumeMigrationReconcile")
+
+	instance := &csiv1.CSIScaleVolumeMigration{}
+	err := r.Client.Get(ctx, req.NamespacedName, instance)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			logger.Info("CSIScaleVolumeMigration resource not found. Ignoring since object must be deleted.", "name", req.NamespacedName)
+			return ctrl.Result{}, nil
+		}
+		logger.Error(err, "Failed to get CSIScaleVolumeMigration.")
+		return ctrl.Result{}, err
+	}
+
+	if instance.Status.Phase == csiv1.VolumeMigrationCompleted || instance.Status.Phase == csiv1.VolumeMigrationFailed {
+		return ctrl.Result{}, nil
+	}
+
+	if _, ok := scaleConnMap[config.Primary]; !ok {
+		logger.Info("IBM Storage Scale connectors are not initialized yet, waiting for CSIScaleOperator to be reconciled.")
+		return ctrl.Result{RequeueAfter: migrationPollInterval}, nil
+	}
+
+	var result ctrl.Result
+	switch instance.Status.Phase {
+	case "", csiv1.VolumeMigrationPending:
+		result, err = r.planMigration(ctx, instance)
+	case csiv1.VolumeMigrationProvisioning:
+		result, err = r.provisionTargetFileset(ctx, instance)
+	case csiv1.VolumeMigrationCopying:
+		result, err = r.copyVolumeData(ctx, instance, csiv1.VolumeMigrationWaitingForCutover)
+	case csiv1.VolumeMigrationWaitingForCutover:
+		result, err = r.waitForCutover(ctx, instance)
+	case csiv1.VolumeMigrationSyncing:
+		result, err = r.copyVolumeData(ctx, instance, csiv1.VolumeMigrationCuttingOver)
+	case csiv1.VolumeMigrationCuttingOver:
+		result, err = r.cutover(ctx, instance)
+	}
+	if err == nil {
+		// the annotation is set before the Syncing phase is recorded, so no
+		// pod starts using the volume while its data is synchronized
+		inCutover := instance.Status.Phase == csiv1.VolumeMigrationSyncing || instance.Status.Phase == csiv1.VolumeMigrationCuttingOver
+		err = r.setCutoverAnnotation(ctx, instance, inCutover)
+	}
+	if err != nil {
+		logger.Error(err, "Failed to reconcile CSIScaleVolumeMigration.", "name", req.NamespacedName, "phase", instance.Status.Phase)
+		return ctrl.Result{}, err
+	}
+
+	if err := r.Client.Status().Update(ctx, instance); err != nil {
+		logger.Error(err, "Failed to update the status of CSIScaleVolumeMigration.")
+		return ctrl.Result{}, err
+	}
+	return result, nil
+}
+
+// planMigration validates the migrated volume and computes the volume handle of
+// the migrated volume.
+func (r *CSIScaleVolumeMigrationReconciler) planMigration(ctx context.Context, instance *csiv1.CSIScaleVolumeMigration) (ctrl.Result, error) {
+	logger := csiLog.FromContext(ctx).WithName("planMigration")
+
+	pvc := &corev1.PersistentVolumeClaim{}
+	err := r.Client.Get(ctx, client.ObjectKey{Namespace: instance.Namespace, Name: instance.Spec.PersistentVolumeClaimName}, pvc)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return r.failMigration(instance, csiv1.MigrationInvalid, fmt.Sprintf("PersistentVolumeClaim %s/%s does not exist", instance.Namespace, instance.Spec.PersistentVolumeClaimName))
+		}
+		return ctrl.Result{}, err
+	}
+	if pvc.Status.Phase != corev1.ClaimBound || pvc.Spec.VolumeName == "" {
+		message := fmt.Sprintf("Waiting for PersistentVolumeClaim %s/%s to be bound", pvc.Namespace, pvc.Name)
+		r.setMigrationCondition(instance, corev1.EventTypeNormal, metav1.ConditionFalse, csiv1.MigrationInvalid, message)
+		instance.Status.Phase = csiv1.VolumeMigrationPending
+		return ctrl.Result{RequeueAfter: migrationPollInterval}, nil
+	}
+
+	pv := &corev1.PersistentVolume{}
+	if err := r.Client.Get(ctx, client.ObjectKey{Name: pvc.Spec.VolumeName}, pv); err != nil {
+		return ctrl.Result{}, err
+	}
+	if pv.Spec.CSI == nil || pv.Spec.CSI.Driver != config.DriverName {
+		return r.failMigration(instance, csiv1.MigrationInvalid, fmt.Sprintf("PersistentVolume %s is not provisioned by IBM Storage Scale CSI driver", pv.Name))
+	}
+
+	source, err := parseVolumeHandle(pv.Spec.CSI.VolumeHandle)
+	if err != nil {
+		return r.failMigration(instance, csiv1.MigrationInvalid, fmt.Sprintf("PersistentVolume %s cannot be migrated: %v", pv.Name, err))
+	}
+	// only volumes of dynamically provisioned classic filesets hold their data
+	// in the <fileset>/<fileset>-data directory copied by the migration
+	if source.storageClassType != storageClassClassic ||
+		(source.volumeType != dependentFilesetVolume && source.volumeType != independentFilesetVolume) ||
+		source.filesetName == "" || !strings.HasSuffix(source.path, "/"+source.filesetName+"-data") {
+		return r.failMigration(instance, csiv1.MigrationInvalid, fmt.Sprintf("PersistentVolume %s cannot be migrated, only dynamically provisioned fileset based volumes of a classic StorageClass are supported", pv.Name))
+	}
+
+	primaryConn := scaleConnMap[config.Primary]
+	targetFs, err := primaryConn.GetFilesystemDetails(ctx, instance.Spec.TargetFilesystem)
+	if err != nil {
+		return r.failMigration(instance, csiv1.MigrationInvalid, fmt.Sprintf("Filesystem %s is not known to the primary cluster: %v", instance.Spec.TargetFilesystem, err))
+	}
+	if targetFs.UUID == source.fsUUID {
+		return r.failMigration(instance, csiv1.MigrationInvalid, fmt.Sprintf("PersistentVolume %s already resides on filesystem %s", pv.Name, instance.Spec.TargetFilesystem))
+	}
+
+	primaryClusterId, err := primaryConn.GetClusterId(ctx)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	targetClusterId := primaryClusterId
+	if targetFs.Type == filesystemTypeRemote {
+		if instance.Spec.TargetClusterId == "" {
+			return r.failMigration(instance, csiv1.MigrationInvalid, fmt.Sprintf("targetClusterId is required as filesystem %s is remotely mounted on the primary cluster", instance.Spec.TargetFilesystem))
+		}
+		targetClusterId = instance.Spec.TargetClusterId
+	} else if instance.Spec.TargetClusterId != "" && instance.Spec.TargetClusterId != primaryClusterId {
+		return r.failMigration(instance, csiv1.MigrationInvalid, fmt.Sprintf("Filesystem %s is owned by the primary cluster %s, not by cluster %s", instance.Spec.TargetFilesystem, primaryClusterId, instance.Spec.TargetClusterId))
+	}
+	if _, ok := scaleConnMap[targetClusterId]; !ok {
+		return r.failMigration(instance, csiv1.MigrationInvalid, fmt.Sprintf("Cluster %s is not configured in CSIScaleOperator", targetClusterId))
+	}
+
+	sourceConn, ok := scaleConnMap[source.clusterId]
+	if !ok {
+		return r.failMigration(instance, csiv1.MigrationInvalid, fmt.Sprintf("Cluster %s of PersistentVolume %s is not configured in CSIScaleOperator", source.clusterId, pv.Name))
+	}
+	// the data is copied by the cluster of the source volume
+	if _, err := sourceConn.GetFilesystemName(ctx, targetFs.UUID); err != nil {
+		return r.failMigration(instance, csiv1.MigrationInvalid, fmt.Sprintf("Filesystem %s must be mounted on cluster %s of PersistentVolume %s: %v", instance.Spec.TargetFilesystem, source.clusterId, pv.Name, err))
+	}
+
+	target := source
+	target.clusterId = targetClusterId
+	target.fsUUID = targetFs.UUID
+	target.path = fmt.Sprintf("%s/%s/%s-data", targetFs.Mount.MountPoint, source.filesetName, source.filesetName)
+
+	instance.Status.PersistentVolumeName = pv.Name
+	instance.Status.SourceVolumeHandle = pv.Spec.CSI.VolumeHandle
+	instance.Status.TargetVolumeHandle = target.String()
+	instance.Status.StartTime = &metav1.Time{Time: time.Now()}
+	instance.Status.Phase = csiv1.VolumeMigrationProvisioning
+	logger.Info("Planned volume migration", "persistentVolume", pv.Name, "source", instance.Status.SourceVolumeHandle, "target", instance.Status.TargetVolumeHandle)
+	return ctrl.Result{Requeue: true}, nil
+}
+
+// provisionTargetFileset creates and links the target fileset with the quota of
+// the source fileset, and creates the data directory of the volume in it.
+func (r *CSIScaleVolumeMigrationReconciler) provisionTargetFileset(ctx context.Context, instance *csiv1.CSIScaleVolumeMigration) (ctrl.Result, error) {
+	logger := csiLog.FromContext(ctx).WithName("provisionTargetFileset")
+
+	source, target, err := r.getMigrationHandles(instance)
+	if err != nil {
+		return r.failMigration(instance, csiv1.MigrationInvalid, err.Error())
+	}
+	sourceConn, sourceFs, err := getMigrationConnector(ctx, source.clusterId, source.fsUUID)
+	if err != nil {
+		return r.retryMigration(instance, csiv1.MigrationProvisionFailed, err)
+	}
+	targetConn, targetFs, err := getMigrationConnector(ctx, target.clusterId, target.fsUUID)
+	if err != nil {
+		return r.retryMigration(instance, csiv1.MigrationProvisionFailed, err)
+	}
+	filesetName := source.filesetName
+
+	sourceFileset, err := sourceConn.ListFileset(ctx, sourceFs, filesetName)
+	if err != nil {
+		return r.retryMigration(instance, csiv1.MigrationProvisionFailed, fmt.Errorf("unable to get fileset %s of filesystem %s: %v", filesetName, sourceFs, err))
+	}
+
+	uid, gid, err := r.getVolumeOwner(ctx, instance)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	opts := map[string]interface{}{
+		connectors.UserSpecifiedUid:  uid,
+		connectors.UserSpecifiedGid:  gid,
+		connectors.FilesetCommentKey: fmt.Sprintf(connectors.FilesetCommentValue, instance.Spec.PersistentVolumeClaimName, instance.Namespace),
+	}
+	if source.volumeType == independentFilesetVolume {
+		opts[connectors.UserSpecifiedFilesetType] = "independent"
+		opts[connectors.UserSpecifiedInodeLimit] = defaultIndependentInodeSize
+		if sourceFileset.Config.MaxNumInodes > 0 {
+			opts[connectors.UserSpecifiedInodeLimit] = strconv.Itoa(sourceFileset.Config.MaxNumInodes)
+		}
+	} else {
+		opts[connectors.UserSpecifiedFilesetType] = "dependent"
+		opts[connectors.UserSpecifiedParentFset] = "root"
+	}
+
+	// CreateFileset succeeds when the fileset already exists, from an earlier
+	// attempt of this migration
+	if err := targetConn.CreateFileset(ctx, targetFs, "", filesetName, opts, "", "", nil); err != nil {
+		return r.retryMigration(instance, csiv1.MigrationProvisionFailed, fmt.Errorf("unable to create fileset %s in filesystem %s: %v", filesetName, targetFs, err))
+	}
+
+	linked, err := targetConn.IsFilesetLinked(ctx, targetFs, filesetName)
+	if err != nil {
+		return r.retryMigration(instance, csiv1.MigrationProvisionFailed, fmt.Errorf("unable to check if fileset %s of filesystem %s is linked: %v", filesetName, targetFs, err))
+	}
+	if !linked {
+		mountPoint, err := targetConn.GetFilesystemMountpoint(ctx, targetFs)
+		if err != nil {
+			return r.retryMigration(instance, csiv1.MigrationProvisionFailed, fmt.Errorf("unable to get mount point of filesystem %s: %v", targetFs, err))
+		}
+		if err := targetConn.LinkFileset(ctx, targetFs, filesetName, fmt.Sprintf("%s/%s", mountPoint, filesetName)); err != nil {
+			return r.retryMigration(instance, csiv1.MigrationProvisionFailed, fmt.Errorf("unable to link fileset %s of filesystem %s: %v", filesetName, targetFs, err))
+		}
+	}
+
+	quota, err := sourceConn.GetFilesetQuotaDetails(ctx, sourceFs, filesetName)
+	if err != nil {
+		return r.retryMigration(instance, csiv1.MigrationProvisionFailed, fmt.Errorf("unable to get quota of fileset %s of filesystem %s: %v", filesetName, sourceFs, err))
+	}
+	if quota.BlockLimit > 0 {
+		// the quota is reported in KiB
+		hardLimit := strconv.FormatInt(int64(quota.BlockLimit)*1024, 10)
+		if err := targetConn.SetFilesetQuota(ctx, targetFs, filesetName, hardLimit, hardLimit); err != nil {
+			return r.retryMigration(instance, csiv1.MigrationProvisionFailed, fmt.Errorf("unable to set quota of fileset %s of filesystem %s: %v", filesetName, targetFs, err))
+		}
+	}
+
+	dataDir := fmt.Sprintf("%s/%s-data", filesetName, filesetName)
+	if err := targetConn.MakeDirectory(ctx, targetFs, dataDir, uid, gid); err != nil {
+		return r.retryMigration(instance, csiv1.MigrationProvisionFailed, fmt.Errorf("unable to create directory %s in filesystem %s: %v", dataDir, targetFs, err))
+	}
+
+	message := fmt.Sprintf("Created fileset %s in filesystem %s", filesetName, targetFs)
+	logger.Info(message)
+	r.setMigrationCondition(instance, corev1.EventTypeNormal, metav1.ConditionTrue, csiv1.MigrationProvisioned, message)
+	instance.Status.TotalBytes = int64(quota.BlockUsage) * 1024
+	instance.Status.Phase = csiv1.VolumeMigrationCopying
+	return ctrl.Result{Requeue: true}, nil
+}
+
+// copyVolumeData copies the data directory of the source fileset to the target
+// fileset in an IBM Storage Scale job and moves the migration to the next phase
+// once the job is done. The job is recorded in the status, so a restart of the
+// operator waits for the running job instead of starting another copy. The
+// data directory of the target fileset is cleared before the copy of the
+// Syncing phase, so files deleted from the source are not carried over.
+func (r *CSIScaleVolumeMigrationReconciler) copyVolumeData(ctx context.Context, instance *csiv1.CSIScaleVolumeMigration, nextPhase csiv1.CSIScaleVolumeMigrationPhase) (ctrl.Result, error) {
+	logger := csiLog.FromContext(ctx).WithName("copyVolumeData")
+
+	source, target, err := r.getMigrationHandles(instance)
+	if err != nil {
+		return r.failMigration(instance, csiv1.MigrationInvalid, err.Error())
+	}
+	sourceConn, sourceFs, err := getMigrationConnector(ctx, source.clusterId, source.fsUUID)
+	if err != nil {
+		return r.retryMigration(instance, csiv1.MigrationCopyFailed, err)
+	}
+
+	job := instance.Status.CopyJob
+	if job != nil && job.Phase != instance.Status.Phase {
+		job = nil
+	}
+
+	if job == nil {
+		if instance.Status.Phase == csiv1.VolumeMigrationSyncing {
+			if err := r.clearTargetData(ctx, instance, target); err != nil {
+				return r.retryMigration(instance, csiv1.MigrationCopyFailed, err)
+			}
+		}
+
+		// the target filesystem is accessed through its mount point on the
+		// cluster of the source volume
+		copyFs, err := sourceConn.GetFilesystemName(ctx, target.fsUUID)
+		if err != nil {
+			return r.retryMigration(instance, csiv1.MigrationCopyFailed, fmt.Errorf("unable to get the name of the target filesystem on cluster %s: %v", source.clusterId, err))
+		}
+		mountPoint, err := sourceConn.GetFilesystemMountpoint(ctx, copyFs)
+		if err != nil {
+			return r.retryMigration(instance, csiv1.MigrationCopyFailed, fmt.Errorf("unable to get the mount point of the target filesystem on cluster %s: %v", source.clusterId, err))
+		}
+		targetPath := fmt.Sprintf("%s/%s/%s-data", mountPoint, target.filesetName, target.filesetName)
+		jobStatus, jobID, err := sourceConn.CopyFilesetPath(ctx, sourceFs, source.filesetName, fmt.Sprintf("%s-data", source.filesetName), targetPath, "")
+		if err != nil {
+			return r.retryMigration(instance, csiv1.MigrationCopyFailed, fmt.Errorf("unable to copy data of fileset %s: %v", source.filesetName, err))
+		}
+		instance.Status.CopyJob = &csiv1.CSIScaleVolumeMigrationCopyJob{
+			Phase:      instance.Status.Phase,
+			JobID:      int64(jobID),
+			StatusCode: jobStatus,
+		}
+
+		message := fmt.Sprintf("Copying data of fileset %s from filesystem %s", source.filesetName, sourceFs)
+		logger.Info(message, "phase", instance.Status.Phase, "jobId", jobID)
+		r.setMigrationCondition(instance, corev1.EventTypeNormal, metav1.ConditionTrue, csiv1.MigrationCopying, message)
+		return ctrl.Result{RequeueAfter: migrationPollInterval}, nil
+	}
+
+	if !job.Completed {
+		r.waitForCopyJob(client.ObjectKeyFromObject(instance), sourceConn, *job)
+		r.updateMigrationProgress(ctx, instance, target)
+		return ctrl.Result{RequeueAfter: migrationPollInterval}, nil
+	}
+
+	instance.Status.CopyJob = nil
+	if job.Error != "" {
+		return r.retryMigration(instance, csiv1.MigrationCopyFailed, fmt.Errorf("unable to copy data of fileset %s: %s", source.filesetName, job.Error))
+	}
+	r.updateMigrationProgress(ctx, instance, target)
+
+	logger.Info("Copied data of fileset", "fileset", source.filesetName, "phase", instance.Status.Phase)
+	instance.Status.Phase = nextPhase
+	if nextPhase == csiv1.VolumeMigrationWaitingForCutover {
+		return r.waitForCutover(ctx, instance)
+	}
+	return ctrl.Result{Requeue: true}, nil
+}
+
+// waitForCopyJob waits in the background for a copy job unless it is waited
+// for already, and records its result in the status of the migration.
+func (r *CSIScaleVolumeMigrationReconciler) waitForCopyJob(key client.ObjectKey, conn connectors.SpectrumScaleConnector, job csiv1.CSIScaleVolumeMigrationCopyJob) {
+	if _, waiting := migrationCopyWaiters.LoadOrStore(job.JobID, struct{}{}); waiting {
+		return
+	}
+
+	go func() {
+		defer migrationCopyWaiters.Delete(job.JobID)
+
+		ctx := context.Background()
+		logger := csiLog.FromContext(ctx).WithName("waitForCopyJob")
+		jobErr := conn.WaitForJobCompletion(ctx, job.StatusCode, uint64(job.JobID))
+
+		err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			instance := &csiv1.CSIScaleVolumeMigration{}
+			if err := r.Client.Get(ctx, key, instance); err != nil {
+				return err
+			}
+			if instance.Status.CopyJob == nil || instance.Status.CopyJob.JobID != job.JobID {
+				return nil
+			}
+			instance.Status.CopyJob.Completed = true
+			if jobErr != nil {
+				instance.Status.CopyJob.Error = jobErr.Error()
+			}
+			return r.Client.Status().Update(ctx, instance)
+		})
+		if err != nil && !errors.IsNotFound(err) {
+			// the job is waited for again by the next reconcile
+			logger.Error(err, "Failed to record the result of the copy job.", "name", key, "jobId", job.JobID)
+		}
+	}()
+}
+
+// clearTargetData deletes the data directory of the target fileset and
+// creates it again empty, owned by the owner of the migrated volume.
+func (r *CSIScaleVolumeMigrationReconciler) clearTargetData(ctx context.Context, instance *csiv1.CSIScaleVolumeMigration, target scaleVolumeHandle) error {
+	targetConn, targetFs, err := getMigrationConnector(ctx, target.clusterId, target.fsUUID)
+	if err != nil {
+		return err
+	}
+	uid, gid, err := r.getVolumeOwner(ctx, instance)
+	if err != nil {
+		return err
+	}
+
+	dataDir := fmt.Sprintf("%s/%s-data", target.filesetName, target.filesetName)
+	if err := targetConn.DeleteDirectory(ctx, targetFs, dataDir, false); err != nil {
+		return fmt.Errorf("unable to delete directory %s in filesystem %s: %v", dataDir, targetFs, err)
+	}
+	if err := targetConn.MakeDirectory(ctx, targetFs, dataDir, uid, gid); err != nil {
+		return fmt.Errorf("unable to create directory %s in filesystem %s: %v", dataDir, targetFs, err)
+	}
+	return nil
+}
+
+// updateMigrationProgress reports the space used by the target fileset against
+// the space used by the source fileset when the copy started.
+func (r *CSIScaleVolumeMigrationReconciler) updateMigrationProgress(ctx context.Context, instance *csiv1.CSIScaleVolumeMigration, target scaleVolumeHandle) {
+	logger := csiLog.FromContext(ctx).WithName("updateMigrationProgress")
+
+	targetConn, targetFs, err := getMigrationConnector(ctx, target.clusterId, target.fsUUID)
+	if err != nil {
+		logger.Info("Unable to report migration progress", "error", err.Error())
+		return
+	}
+	quota, err := targetConn.GetFilesetQuotaDetails(ctx, targetFs, target.filesetName)
+	if err != nil {
+		logger.Info("Unable to report migration progress", "error", err.Error())
+		return
+	}
+	instance.Status.CopiedBytes = int64(quota.BlockUsage) * 1024
+	if instance.Status.TotalBytes > 0 {
+		progress := instance.Status.CopiedBytes * 100 / instance.Status.TotalBytes
+		if progress > 100 {
+			progress = 100
+		}
+		instance.Status.Progress = int32(progress)
+	}
+}
+
+// waitForCutover starts the final sync once the cutover is allowed and the volume
+// is not used by any pod.
+func (r *CSIScaleVolumeMigrationReconciler) waitForCutover(ctx context.Context, instance *csiv1.CSIScaleVolumeMigration) (ctrl.Result, error) {
+	logger := csiLog.FromContext(ctx).WithName("waitForCutover")
+
+	if !instance.Spec.Cutover {
+		message := fmt.Sprintf("Initial copy completed, set spec.cutover to switch PersistentVolumeClaim %s to filesystem %s", instance.Spec.PersistentVolumeClaimName, instance.Spec.TargetFilesystem)
+		r.setMigrationCondition(instance, corev1.EventTypeNormal, metav1.ConditionTrue, csiv1.MigrationWaiting, message)
+		instance.Status.Phase = csiv1.VolumeMigrationWaitingForCutover
+		return ctrl.Result{}, nil
+	}
+
+	user, err := r.getVolumeUser(ctx, instance)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if user != "" {
+		message := fmt.Sprintf("Waiting for the cutover, PersistentVolumeClaim %s is used by %s", instance.Spec.PersistentVolumeClaimName, user)
+		r.setMigrationCondition(instance, corev1.EventTypeNormal, metav1.ConditionTrue, csiv1.MigrationWaiting, message)
+		instance.Status.Phase = csiv1.VolumeMigrationWaitingForCutover
+		return ctrl.Result{RequeueAfter: migrationPollInterval}, nil
+	}
+
+	logger.Info("Volume is not in use, starting the final sync", "persistentVolumeClaim", instance.Spec.PersistentVolumeClaimName)
+	instance.Status.Phase = csiv1.VolumeMigrationSyncing
+	return ctrl.Result{Requeue: true}, nil
+}
+
+// cutover replaces the PersistentVolume of the migrated PersistentVolumeClaim by
+// a PersistentVolume with the same name referencing the target fileset. The
+// PersistentVolumeClaim is not deleted, it is bound again to the new
+// PersistentVolume by the claimRef carried over from the source PersistentVolume.
+func (r *CSIScaleVolumeMigrationReconciler) cutover(ctx context.Context, instance *csiv1.CSIScaleVolumeMigration) (ctrl.Result, error) {
+	logger := csiLog.FromContext(ctx).WithName("cutover")
+
+	pv := &corev1.PersistentVolume{}
+	err := r.Client.Get(ctx, client.ObjectKey{Name: instance.Status.PersistentVolumeName}, pv)
+	if err != nil && !errors.IsNotFound(err) {
+		return ctrl.Result{}, err
+	}
+	pvExists := err == nil
+
+	if pvExists && pv.Spec.CSI != nil && pv.Spec.CSI.VolumeHandle == instance.Status.SourceVolumeHandle {
+		// a pod may have been started while the data was synchronized
+		user, err := r.getVolumeUser(ctx, instance)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if user != "" {
+			message := fmt.Sprintf("PersistentVolumeClaim %s is used by %s again, synchronizing the data once it is unused", instance.Spec.PersistentVolumeClaimName, user)
+			r.setMigrationCondition(instance, corev1.EventTypeWarning, metav1.ConditionTrue, csiv1.MigrationWaiting, message)
+			instance.Status.Phase = csiv1.VolumeMigrationWaitingForCutover
+			return ctrl.Result{RequeueAfter: migrationPollInterval}, nil
+		}
+
+		if _, saved := instance.Annotations[migrationTargetPVAnnotation]; !saved {
+			targetPV, err := r.newTargetPersistentVolume(instance, pv)
+			if err != nil {
+				return r.failMigration(instance, csiv1.MigrationCutoverFailed, fmt.Sprintf("Unable to create the PersistentVolume of the migrated volume: %v", err))
+			}
+			if instance.Annotations == nil {
+				instance.Annotations = map[string]string{}
+			}
+			instance.Annotations[migrationTargetPVAnnotation] = targetPV
+			status := instance.Status.DeepCopy()
+			if err := r.Client.Update(ctx, instance); err != nil {
+				return ctrl.Result{}, err
+			}
+			instance.Status = *status
+		}
+
+		// retain the source fileset and remove the protection and attacher finalizers
+		// so that the PersistentVolume can be deleted while it is bound
+		if pv.Spec.PersistentVolumeReclaimPolicy != corev1.PersistentVolumeReclaimRetain || len(pv.Finalizers) > 0 {
+			pv.Spec.PersistentVolumeReclaimPolicy = corev1.PersistentVolumeReclaimRetain
+			pv.Finalizers = nil
+			if err := r.Client.Update(ctx, pv); err != nil {
+				return ctrl.Result{}, err
+			}
+		}
+		if err := r.Client.Delete(ctx, pv); err != nil && !errors.IsNotFound(err) {
+			return ctrl.Result{}, err
+		}
+		logger.Info("Deleted the source PersistentVolume", "persistentVolume", pv.Name)
+		return ctrl.Result{RequeueAfter: time.Second}, nil
+	}
+
+	if !pvExists {
+		targetPV := &corev1.PersistentVolume{}
+		if err := json.Unmarshal([]byte(instance.Annotations[migrationTargetPVAnnotation]), targetPV); err != nil {
+			return r.failMigration(instance, csiv1.MigrationCutoverFailed, fmt.Sprintf("Unable to read the PersistentVolume of the migrated volume from annotation %s: %v", migrationTargetPVAnnotation, err))
+		}
+		if err := r.Client.Create(ctx, targetPV); err != nil && !errors.IsAlreadyExists(err) {
+			return ctrl.Result{}, err
+		}
+		logger.Info("Created the PersistentVolume of the migrated volume", "persistentVolume", targetPV.Name, "volumeHandle", instance.Status.TargetVolumeHandle)
+		return ctrl.Result{RequeueAfter: time.Second}, nil
+	}
+
+	if pv.Spec.CSI == nil || pv.Spec.CSI.VolumeHandle != instance.Status.TargetVolumeHandle {
+		return r.failMigration(instance, csiv1.MigrationCutoverFailed, fmt.Sprintf("PersistentVolume %s has been replaced by another volume", pv.Name))
+	}
+
+	pvc := &corev1.PersistentVolumeClaim{}
+	if err := r.Client.Get(ctx, client.ObjectKey{Namespace: instance.Namespace, Name: instance.Spec.PersistentVolumeClaimName}, pvc); err != nil {
+		return ctrl.Result{}, err
+	}
+	if pvc.Status.Phase != corev1.ClaimBound {
+		return ctrl.Result{RequeueAfter: time.Second * 5}, nil
+	}
+
+	source, _ := parseVolumeHandle(instance.Status.SourceVolumeHandle)
+	message := fmt.Sprintf("PersistentVolumeClaim %s migrated to filesystem %s, fileset %s on the source filesystem is retained and can be deleted once the migrated data is verified",
+		instance.Spec.PersistentVolumeClaimName, instance.Spec.TargetFilesystem, source.filesetName)
+	logger.Info(message)
+	r.setMigrationCondition(instance, corev1.EventTypeNormal, metav1.ConditionTrue, csiv1.MigrationCompleted, message)
+	instance.Status.Phase = csiv1.VolumeMigrationCompleted
+	instance.Status.CompletionTime = &metav1.Time{Time: time.Now()}
+	return ctrl.Result{}, nil
+}
+
+// setCutoverAnnotation sets or removes the cutover annotation of the migrated
+// PersistentVolumeClaim.
+func (r *CSIScaleVolumeMigrationReconciler) setCutoverAnnotation(ctx context.Context, instance *csiv1.CSIScaleVolumeMigration, inCutover bool) error {
+	pvc := &corev1.PersistentVolumeClaim{}
+	err := r.Client.Get(ctx, client.ObjectKey{Namespace: instance.Namespace, Name: instance.Spec.PersistentVolumeClaimName}, pvc)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	migration, annotated := pvc.Annotations[migrationCutoverAnnotation]
+	if inCutover == annotated || (annotated && migration != instance.Name) {
+		return nil
+	}
+
+	patch := client.MergeFrom(pvc.DeepCopy())
+	if inCutover {
+		if pvc.Annotations == nil {
+			pvc.Annotations = map[string]string{}
+		}
+		pvc.Annotations[migrationCutoverAnnotation] = instance.Name
+	} else {
+		delete(pvc.Annotations, migrationCutoverAnnotation)
+	}
+	return r.Client.Patch(ctx, pvc, patch)
+}
+
+// newTargetPersistentVolume returns the serialized PersistentVolume which replaces
+// the source PersistentVolume, with the same name, claim and reclaim policy.
+func (r *CSIScaleVolumeMigrationReconciler) newTargetPersistentVolume(instance *csiv1.CSIScaleVolumeMigration, pv *corev1.PersistentVolume) (string, error) {
+	targetPV := &corev1.PersistentVolume{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "PersistentVolume"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        pv.Name,
+			Labels:      pv.Labels,
+			Annotations: pv.Annotations,
+		},
+		Spec: *pv.Spec.DeepCopy(),
+	}
+	if targetPV.Spec.ClaimRef != nil {
+		targetPV.Spec.ClaimRef.ResourceVersion = ""
+	}
+
+	attributes := map[string]string{}
+	for key, value := range pv.Spec.CSI.VolumeAttributes {
+		attributes[key] = value
+	}
+	attributes[connectors.UserSpecifiedVolBackendFs] = instance.Spec.TargetFilesystem
+	if instance.Spec.TargetClusterId != "" {
+		attributes[connectors.UserSpecifiedClusterId] = instance.Spec.TargetClusterId
+	} else {
+		delete(attributes, connectors.UserSpecifiedClusterId)
+	}
+	targetPV.Spec.CSI.VolumeAttributes = attributes
+	targetPV.Spec.CSI.VolumeHandle = instance.Status.TargetVolumeHandle
+
+	data, err := json.Marshal(targetPV)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// getVolumeUser returns the pod or node using the migrated volume, or an empty
+// string when the volume is not in use.
+func (r *CSIScaleVolumeMigrationReconciler) getVolumeUser(ctx context.Context, instance *csiv1.CSIScaleVolumeMigration) (string, error) {
+	pods := &corev1.PodList{}
+	if err := r.Client.List(ctx, pods, client.InNamespace(instance.Namespace)); err != nil {
+		return "", err
+	}
+	for _, pod := range pods.Items {
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		for _, volume := range pod.Spec.Volumes {
+			if volume.PersistentVolumeClaim != nil && volume.PersistentVolumeClaim.ClaimName == instance.Spec.PersistentVolumeClaimName {
+				return fmt.Sprintf("pod %s", pod.Name), nil
+			}
+		}
+	}
+
+	attachments := &storagev1.VolumeAttachmentList{}
+	if err := r.Client.List(ctx, attachments); err != nil {
+		return "", err
+	}
+	for _, attachment := range attachments.Items {
+		if attachment.Spec.Source.PersistentVolumeName != nil && *attachment.Spec.Source.PersistentVolumeName == instance.Status.PersistentVolumeName {
+			return fmt.Sprintf("node %s", attachment.Spec.NodeName), nil
+		}
+	}
+	return "", nil
+}
+
+// getVolumeOwner returns the uid and gid the migrated volume was created with.
+func (r *CSIScaleVolumeMigrationReconciler) getVolumeOwner(ctx context.Context, instance *csiv1.CSIScaleVolumeMigration) (string, string, error) {
+	uid, gid := "0", "0"
+	pv := &corev1.PersistentVolume{}
+	if err := r.Client.Get(ctx, client.ObjectKey{Name: instance.Status.PersistentVolumeName}, pv); err != nil {
+		return "", "", err
+	}
+	if pv.Spec.CSI != nil {
+		if value, ok := pv.Spec.CSI.VolumeAttributes[connectors.UserSpecifiedUid]; ok {
+			uid = value
+		}
+		if value, ok := pv.Spec.CSI.VolumeAttributes[connectors.UserSpecifiedGid]; ok {
+			gid = value
+		}
+	}
+	return uid, gid, nil
+}
+
+func (r *CSIScaleVolumeMigrationReconciler) getMigrationHandles(instance *csiv1.CSIScaleVolumeMigration) (scaleVolumeHandle, scaleVolumeHandle, error) {
+	source, err := parseVolumeHandle(instance.Status.SourceVolumeHandle)
+	if err != nil {
+		return scaleVolumeHandle{}, scaleVolumeHandle{}, err
+	}
+	target, err := parseVolumeHandle(instance.Status.TargetVolumeHandle)
+	if err != nil {
+		return scaleVolumeHandle{}, scaleVolumeHandle{}, err
+	}
+	return source, target, nil
+}
+
+// getMigrationConnector returns the connector of a cluster together with the name
+// of the filesystem with the given UUID on that cluster.
+func getMigrationConnector(ctx context.Context, clusterId, fsUUID string) (connectors.SpectrumScaleConnector, string, error) {
+	conn, ok := scaleConnMap[clusterId]
+	if !ok {
+		return nil, "", fmt.Errorf("cluster %s is not configured in CSIScaleOperator", clusterId)
+	}
+	filesystemName, err := conn.GetFilesystemName(ctx, fsUUID)
+	if err != nil {
+		return nil, "", fmt.Errorf("unable to get the name of filesystem %s on cluster %s: %v", fsUUID, clusterId, err)
+	}
+	return conn, filesystemName, nil
+}
+
+// retryMigration reports a failed step which is retried after migrationRetryDelay.
+func (r *CSIScaleVolumeMigrationReconciler) retryMigration(instance *csiv1.CSIScaleVolumeMigration, reason csiv1.CSIReason, err error) (ctrl.Result, error) {
+	r.setMigrationCondition(instance, corev1.EventTypeWarning, metav1.ConditionFalse, reason, err.Error())
+	return ctrl.Result{RequeueAfter: migrationRetryDelay}, nil
+}
+
+// failMigration stops a migration which cannot complete.
+func (r *CSIScaleVolumeMigrationReconciler) failMigration(instance *csiv1.CSIScaleVolumeMigration, reason csiv1.CSIReason, message string) (ctrl.Result, error) {
+	r.setMigrationCondition(instance, corev1.EventTypeWarning, metav1.ConditionFalse, reason, message)
+	instance.Status.Phase = csiv1.VolumeMigrationFailed
+	instance.Status.CompletionTime = &metav1.Time{Time: time.Now()}
+	return ctrl.Result{}, nil
+}
+
+func (r *CSIScaleVolumeMigrationReconciler) setMigrationCondition(instance *csiv1.CSIScaleVolumeMigration, eventType string, status metav1.ConditionStatus, reason csiv1.CSIReason, message string) {
+	condition := meta.FindStatusCondition(instance.Status.Conditions, csiv1.VolumeMigrationReady)
+	if condition != nil && condition.Reason == string(reason) && condition.Message == message {
+		return
+	}
+	meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+		Type:    csiv1.VolumeMigrationReady,
+		Status:  status,
+		Reason:  string(reason),
+		Message: message,
+	})
+	RaiseCSOEvent(instance, r.Recorder, eventType, string(reason), message)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *CSIScaleVolumeMigrationReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	logger := csiLog.Log.WithName("SetupWithManager")
+	logger.Info("Setting up the volume migration controller with the manager.")
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(CSIScaleVolumeMigrationControllerName).
+		For(&csiv1.CSIScaleVolumeMigration{}).
+		Complete(r)
+}
@@ -0,0 +1,379 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/IBM/ibm-spectrum-scale-csi/driver/csiplugin/connectors"
+	csiv1 "github.com/IBM/ibm-spectrum-scale-csi/operator/api/v1"
+	config "github.com/IBM/ibm-spectrum-scale-csi/operator/controllers/config"
+)
+
+const (
+	migrationTestNamespace    = "apps"
+	migrationTestPVC          = "data"
+	migrationTestPV           = "pvc-1"
+	migrationTestSourceHandle = "0;2;123;UUID-1;;pvc-1;/ibm/fs1/pvc-1/pvc-1-data"
+	migrationTestTargetHandle = "0;2;123;UUID-2;;pvc-1;/ibm/fs2/pvc-1/pvc-1-data"
+)
+
+// fakeMigrationConnector serves the filesystems fs1 and fs2 of cluster 123.
+// Calls of methods the migration does not use panic on the nil interface.
+type fakeMigrationConnector struct {
+	connectors.SpectrumScaleConnector
+
+	mu          sync.Mutex
+	jobs        uint64
+	deletedDirs []string
+}
+
+var migrationTestFilesystems = map[string]connectors.FileSystem_v2{
+	"fs1": {Name: "fs1", UUID: "UUID-1", Mount: connectors.MountInfo{MountPoint: "/ibm/fs1"}},
+	"fs2": {Name: "fs2", UUID: "UUID-2", Mount: connectors.MountInfo{MountPoint: "/ibm/fs2"}},
+}
+
+func (c *fakeMigrationConnector) GetClusterId(ctx context.Context) (string, error) {
+	return "123", nil
+}
+
+func (c *fakeMigrationConnector) GetFilesystemDetails(ctx context.Context, filesystemName string) (connectors.FileSystem_v2, error) {
+	fs, ok := migrationTestFilesystems[filesystemName]
+	if !ok {
+		return connectors.FileSystem_v2{}, fmt.Errorf("filesystem %s not found", filesystemName)
+	}
+	return fs, nil
+}
+
+func (c *fakeMigrationConnector) GetFilesystemName(ctx context.Context, filesystemUUID string) (string, error) {
+	for name, fs := range migrationTestFilesystems {
+		if fs.UUID == filesystemUUID {
+			return name, nil
+		}
+	}
+	return "", fmt.Errorf("filesystem %s not found", filesystemUUID)
+}
+
+func (c *fakeMigrationConnector) GetFilesystemMountpoint(ctx context.Context, filesystemName string) (string, error) {
+	return migrationTestFilesystems[filesystemName].Mount.MountPoint, nil
+}
+
+func (c *fakeMigrationConnector) ListFileset(ctx context.Context, filesystemName string, filesetName string) (connectors.Fileset_v2, error) {
+	return connectors.Fileset_v2{}, nil
+}
+
+func (c *fakeMigrationConnector) CreateFileset(ctx context.Context, filesystemName string, volumeType string, filesetName string, opts map[string]interface{}, mode string, exportMapName string, nfsInfo map[string]string) error {
+	return nil
+}
+
+func (c *fakeMigrationConnector) IsFilesetLinked(ctx context.Context, filesystemName string, filesetName string) (bool, error) {
+	return true, nil
+}
+
+func (c *fakeMigrationConnector) GetFilesetQuotaDetails(ctx context.Context, filesystemName string, filesetName string) (connectors.Quota_v2, error) {
+	return connectors.Quota_v2{BlockUsage: 1024}, nil
+}
+
+func (c *fakeMigrationConnector) MakeDirectory(ctx context.Context, filesystemName string, relativePath string, uid string, gid string) error {
+	return nil
+}
+
+func (c *fakeMigrationConnector) DeleteDirectory(ctx context.Context, filesystemName string, dirName string, safe bool) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.deletedDirs = append(c.deletedDirs, filesystemName+":"+dirName)
+	return nil
+}
+
+func (c *fakeMigrationConnector) CopyFilesetPath(ctx context.Context, filesystemName string, filesetName string, srcPath string, targetPath string, nodeclass string) (int, uint64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.jobs++
+	return 202, c.jobs, nil
+}
+
+func (c *fakeMigrationConnector) WaitForJobCompletion(ctx context.Context, statusCode int, jobID uint64) error {
+	return nil
+}
+
+// newMigrationTestReconciler returns a reconciler of a migration of the bound
+// PersistentVolumeClaim data from fs1 to fs2.
+func newMigrationTestReconciler(t *testing.T) (*CSIScaleVolumeMigrationReconciler, *fakeMigrationConnector) {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := csiv1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Namespace: migrationTestNamespace, Name: migrationTestPVC},
+		Spec:       corev1.PersistentVolumeClaimSpec{VolumeName: migrationTestPV},
+		Status:     corev1.PersistentVolumeClaimStatus{Phase: corev1.ClaimBound},
+	}
+	pv := &corev1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{Name: migrationTestPV, Finalizers: []string{"kubernetes.io/pv-protection"}},
+		Spec: corev1.PersistentVolumeSpec{
+			PersistentVolumeReclaimPolicy: corev1.PersistentVolumeReclaimDelete,
+			ClaimRef:                      &corev1.ObjectReference{Namespace: migrationTestNamespace, Name: migrationTestPVC},
+			PersistentVolumeSource: corev1.PersistentVolumeSource{
+				CSI: &corev1.CSIPersistentVolumeSource{
+					Driver:           config.DriverName,
+					VolumeHandle:     migrationTestSourceHandle,
+					VolumeAttributes: map[string]string{connectors.UserSpecifiedVolBackendFs: "fs1"},
+				},
+			},
+		},
+	}
+	migration := &csiv1.CSIScaleVolumeMigration{
+		ObjectMeta: metav1.ObjectMeta{Namespace: migrationTestNamespace, Name: "move-data"},
+		Spec: csiv1.CSIScaleVolumeMigrationSpec{
+			PersistentVolumeClaimName: migrationTestPVC,
+			TargetFilesystem:          "fs2",
+		},
+	}
+
+	conn := &fakeMigrationConnector{}
+	savedConnMap := scaleConnMap
+	scaleConnMap = map[string]connectors.SpectrumScaleConnector{config.Primary: conn, "123": conn}
+	t.Cleanup(func() { scaleConnMap = savedConnMap })
+
+	r := &CSIScaleVolumeMigrationReconciler{
+		Client: fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(pvc, pv, migration).
+			WithStatusSubresource(&csiv1.CSIScaleVolumeMigration{}).
+			Build(),
+		Scheme:   scheme,
+		Recorder: record.NewFakeRecorder(1000),
+	}
+	return r, conn
+}
+
+var migrationTestKey = types.NamespacedName{Namespace: migrationTestNamespace, Name: "move-data"}
+
+// reconcileMigrationUntil reconciles the migration until it reaches phase and
+// returns it. Copy jobs complete in the background, a reconcile conflicting
+// with the update of a completed job is repeated.
+func reconcileMigrationUntil(t *testing.T, r *CSIScaleVolumeMigrationReconciler, phase csiv1.CSIScaleVolumeMigrationPhase) *csiv1.CSIScaleVolumeMigration {
+	t.Helper()
+	ctx := context.Background()
+	instance := &csiv1.CSIScaleVolumeMigration{}
+	for i := 0; i < 10; i++ {
+		if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: migrationTestKey}); err != nil && !errors.IsConflict(err) {
+			t.Fatalf("Reconcile() error = %v", err)
+		}
+		waitForMigrationCopyWaiters(t)
+		if err := r.Client.Get(ctx, migrationTestKey, instance); err != nil {
+			t.Fatal(err)
+		}
+		if instance.Status.Phase == phase {
+			return instance
+		}
+	}
+	t.Fatalf("migration is in phase %s, want %s", instance.Status.Phase, phase)
+	return nil
+}
+
+func waitForMigrationCopyWaiters(t *testing.T) {
+	t.Helper()
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+		waiting := false
+		migrationCopyWaiters.Range(func(key, value interface{}) bool {
+			waiting = true
+			return false
+		})
+		if !waiting {
+			return
+		}
+	}
+	t.Fatal("copy jobs are still waited for")
+}
+
+func getMigrationTestPVC(t *testing.T, r *CSIScaleVolumeMigrationReconciler) *corev1.PersistentVolumeClaim {
+	t.Helper()
+	pvc := &corev1.PersistentVolumeClaim{}
+	if err := r.Client.Get(context.Background(), client.ObjectKey{Namespace: migrationTestNamespace, Name: migrationTestPVC}, pvc); err != nil {
+		t.Fatal(err)
+	}
+	return pvc
+}
+
+func setMigrationCutover(t *testing.T, r *CSIScaleVolumeMigrationReconciler) {
+	t.Helper()
+	ctx := context.Background()
+	instance := &csiv1.CSIScaleVolumeMigration{}
+	if err := r.Client.Get(ctx, migrationTestKey, instance); err != nil {
+		t.Fatal(err)
+	}
+	instance.Spec.Cutover = true
+	if err := r.Client.Update(ctx, instance); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func newMigrationTestPod(name string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: migrationTestNamespace, Name: name},
+		Spec: corev1.PodSpec{
+			Volumes: []corev1.Volume{{
+				Name: "data",
+				VolumeSource: corev1.VolumeSource{
+					PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: migrationTestPVC},
+				},
+			}},
+		},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+}
+
+func checkCutoverAnnotation(t *testing.T, r *CSIScaleVolumeMigrationReconciler, want bool) {
+	t.Helper()
+	value, annotated := getMigrationTestPVC(t, r).Annotations[migrationCutoverAnnotation]
+	if annotated != want {
+		t.Errorf("cutover annotation set = %t, want %t", annotated, want)
+	}
+	if annotated && value != migrationTestKey.Name {
+		t.Errorf("cutover annotation = %q, want %q", value, migrationTestKey.Name)
+	}
+}
+
+func TestVolumeMigrationPhases(t *testing.T) {
+	r, conn := newMigrationTestReconciler(t)
+	ctx := context.Background()
+
+	instance := reconcileMigrationUntil(t, r, csiv1.VolumeMigrationProvisioning)
+	if instance.Status.PersistentVolumeName != migrationTestPV || instance.Status.SourceVolumeHandle != migrationTestSourceHandle ||
+		instance.Status.TargetVolumeHandle != migrationTestTargetHandle {
+		t.Errorf("planned migration of %q from %q to %q", instance.Status.PersistentVolumeName, instance.Status.SourceVolumeHandle, instance.Status.TargetVolumeHandle)
+	}
+
+	reconcileMigrationUntil(t, r, csiv1.VolumeMigrationCopying)
+	instance = reconcileMigrationUntil(t, r, csiv1.VolumeMigrationWaitingForCutover)
+	if instance.Status.CopyJob != nil {
+		t.Errorf("copy job %+v is kept after the copy", instance.Status.CopyJob)
+	}
+	checkCutoverAnnotation(t, r, false)
+
+	// the cutover waits for the pods using the volume
+	setMigrationCutover(t, r)
+	pod := newMigrationTestPod("app")
+	if err := r.Client.Create(ctx, pod); err != nil {
+		t.Fatal(err)
+	}
+	instance = reconcileMigrationUntil(t, r, csiv1.VolumeMigrationWaitingForCutover)
+	if condition := meta.FindStatusCondition(instance.Status.Conditions, csiv1.VolumeMigrationReady); condition == nil || condition.Reason != string(csiv1.MigrationWaiting) {
+		t.Errorf("condition = %+v, want reason %s", condition, csiv1.MigrationWaiting)
+	}
+	checkCutoverAnnotation(t, r, false)
+
+	// new pods are refused by the driver from the final sync on
+	if err := r.Client.Delete(ctx, pod); err != nil {
+		t.Fatal(err)
+	}
+	reconcileMigrationUntil(t, r, csiv1.VolumeMigrationSyncing)
+	checkCutoverAnnotation(t, r, true)
+
+	reconcileMigrationUntil(t, r, csiv1.VolumeMigrationCuttingOver)
+	checkCutoverAnnotation(t, r, true)
+	if len(conn.deletedDirs) != 1 || conn.deletedDirs[0] != "fs2:pvc-1/pvc-1-data" {
+		t.Errorf("deleted directories = %q, want the target data directory cleared before the final sync", conn.deletedDirs)
+	}
+
+	instance = reconcileMigrationUntil(t, r, csiv1.VolumeMigrationCompleted)
+	if instance.Status.CompletionTime == nil {
+		t.Errorf("completionTime is not set")
+	}
+	checkCutoverAnnotation(t, r, false)
+
+	pv := &corev1.PersistentVolume{}
+	if err := r.Client.Get(ctx, client.ObjectKey{Name: migrationTestPV}, pv); err != nil {
+		t.Fatal(err)
+	}
+	if pv.Spec.CSI.VolumeHandle != migrationTestTargetHandle {
+		t.Errorf("volume handle = %q, want %q", pv.Spec.CSI.VolumeHandle, migrationTestTargetHandle)
+	}
+	if pv.Spec.CSI.VolumeAttributes[connectors.UserSpecifiedVolBackendFs] != "fs2" {
+		t.Errorf("volume attributes = %v, want volBackendFs fs2", pv.Spec.CSI.VolumeAttributes)
+	}
+	if pv.Spec.ClaimRef == nil || pv.Spec.ClaimRef.Name != migrationTestPVC {
+		t.Errorf("claimRef = %+v, want PersistentVolumeClaim %s", pv.Spec.ClaimRef, migrationTestPVC)
+	}
+}
+
+func TestVolumeMigrationUsedDuringSync(t *testing.T) {
+	r, _ := newMigrationTestReconciler(t)
+	ctx := context.Background()
+
+	setMigrationCutover(t, r)
+	reconcileMigrationUntil(t, r, csiv1.VolumeMigrationSyncing)
+	checkCutoverAnnotation(t, r, true)
+
+	// a pod started while the data was synchronized sends the migration back
+	// to wait for the cutover, and may use the source volume again
+	if err := r.Client.Create(ctx, newMigrationTestPod("app")); err != nil {
+		t.Fatal(err)
+	}
+	reconcileMigrationUntil(t, r, csiv1.VolumeMigrationWaitingForCutover)
+	checkCutoverAnnotation(t, r, false)
+
+	pv := &corev1.PersistentVolume{}
+	if err := r.Client.Get(ctx, client.ObjectKey{Name: migrationTestPV}, pv); err != nil {
+		t.Fatal(err)
+	}
+	if pv.Spec.CSI.VolumeHandle != migrationTestSourceHandle {
+		t.Errorf("volume handle = %q, want the source volume %q", pv.Spec.CSI.VolumeHandle, migrationTestSourceHandle)
+	}
+}
+
+func TestVolumeMigrationInvalid(t *testing.T) {
+	r, _ := newMigrationTestReconciler(t)
+	ctx := context.Background()
+
+	instance := &csiv1.CSIScaleVolumeMigration{}
+	if err := r.Client.Get(ctx, migrationTestKey, instance); err != nil {
+		t.Fatal(err)
+	}
+	instance.Spec.TargetFilesystem = "fs1"
+	if err := r.Client.Update(ctx, instance); err != nil {
+		t.Fatal(err)
+	}
+
+	instance = reconcileMigrationUntil(t, r, csiv1.VolumeMigrationFailed)
+	if condition := meta.FindStatusCondition(instance.Status.Conditions, csiv1.VolumeMigrationReady); condition == nil || condition.Reason != string(csiv1.MigrationInvalid) {
+		t.Errorf("condition = %+v, want reason %s", condition, csiv1.MigrationInvalid)
+	}
+	checkCutoverAnnotation(t, r, false)
+}
@@ -553,7 +553,7 @@ func (c *CSIScaleOperator) GenerateNodePluginClusterRole() *rbacv1.ClusterRole {
 			{
 				APIGroups: []string{""},
 				Resources: []string{persistentVolumeClaimsResource},
-				Verbs:     []string{verbGet, verbList, verbWatch},
+				Verbs:     []string{verbGet, verbList, verbWatch, verbPatch},
 			},
 			{
 				APIGroups: []string{storageApiGroup},
@@ -566,6 +566,16 @@ func (c *CSIScaleOperator) GenerateNodePluginClusterRole() *rbacv1.ClusterRole {
 				Resources: []string{namespacesResource},
 				Verbs:     []string{verbGet, verbList},
 			},
+			{
+				APIGroups: []string{snapshotStorageApiGroup},
+				Resources: []string{volumeSnapshotContentsResource},
+				Verbs:     []string{verbGet, verbList},
+			},
+			{
+				APIGroups: []string{""},
+				Resources: []string{eventsResource},
+				Verbs:     []string{verbCreate, verbPatch},
+			},
 		},
 	}
 	if len(c.Spec.CSIpspname) != 0 {
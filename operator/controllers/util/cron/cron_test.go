@@ -0,0 +1,108 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func date(year int, month time.Month, day, hour, min int) time.Time {
+	return time.Date(year, month, day, hour, min, 0, 0, time.UTC)
+}
+
+func TestNext(t *testing.T) {
+	// 2026-03-01 is a Sunday
+	tests := []struct {
+		name string
+		spec string
+		from time.Time
+		want time.Time
+	}{
+		{"every minute", "* * * * *", date(2026, 3, 1, 10, 7), date(2026, 3, 1, 10, 8)},
+		{"seconds are truncated", "* * * * *", date(2026, 3, 1, 10, 7).Add(30 * time.Second), date(2026, 3, 1, 10, 8)},
+		{"single value", "30 8 * * *", date(2026, 3, 1, 10, 7), date(2026, 3, 2, 8, 30)},
+		{"list", "5 4 * * 1,3", date(2026, 3, 2, 4, 5), date(2026, 3, 4, 4, 5)},
+		{"range", "0 9-17 * * *", date(2026, 3, 1, 17, 0), date(2026, 3, 2, 9, 0)},
+		{"step", "*/15 * * * *", date(2026, 3, 1, 10, 7), date(2026, 3, 1, 10, 15)},
+		{"step wraps the hour", "*/15 * * * *", date(2026, 3, 1, 10, 45), date(2026, 3, 1, 11, 0)},
+		{"range with step", "0 9-17/4 * * *", date(2026, 3, 1, 13, 0), date(2026, 3, 1, 17, 0)},
+		{"range with step past the end", "0 9-17/4 * * *", date(2026, 3, 1, 17, 0), date(2026, 3, 2, 9, 0)},
+		{"value with step", "10/20 * * * *", date(2026, 3, 1, 10, 31), date(2026, 3, 1, 10, 50)},
+		{"day of month", "0 0 13 * *", date(2026, 3, 1, 0, 0), date(2026, 3, 13, 0, 0)},
+		{"day of week", "0 0 * * 5", date(2026, 3, 1, 0, 0), date(2026, 3, 6, 0, 0)},
+		{"sunday as 7", "0 0 * * 7", date(2026, 3, 1, 0, 0), date(2026, 3, 8, 0, 0)},
+		{"day of month or day of week", "0 0 13 * 5", date(2026, 3, 1, 0, 0), date(2026, 3, 6, 0, 0)},
+		{"day of month or day of week after the weekday", "0 0 13 * 5", date(2026, 3, 6, 0, 0), date(2026, 3, 13, 0, 0)},
+		// a day of month starting with "*" is unrestricted, so both must match
+		{"day of month step and day of week", "0 0 */10 * 5", date(2026, 3, 1, 0, 0), date(2026, 5, 1, 0, 0)},
+		{"month", "0 0 1 6 *", date(2026, 3, 1, 0, 0), date(2026, 6, 1, 0, 0)},
+		{"month rollover", "0 0 31 * *", date(2026, 4, 1, 0, 0), date(2026, 5, 31, 0, 0)},
+		{"month rollover at month end", "0 12 * * *", date(2026, 4, 30, 12, 0), date(2026, 5, 1, 12, 0)},
+		{"year rollover", "59 23 31 12 *", date(2026, 12, 31, 23, 59), date(2027, 12, 31, 23, 59)},
+		{"leap day", "0 0 29 2 *", date(2026, 3, 1, 0, 0), date(2028, 2, 29, 0, 0)},
+		{"never", "0 0 30 2 *", date(2026, 3, 1, 0, 0), time.Time{}},
+		{"@yearly", "@yearly", date(2026, 3, 1, 0, 0), date(2027, 1, 1, 0, 0)},
+		{"@annually", "@annually", date(2026, 3, 1, 0, 0), date(2027, 1, 1, 0, 0)},
+		{"@monthly", "@monthly", date(2026, 12, 15, 0, 0), date(2027, 1, 1, 0, 0)},
+		{"@weekly", "@weekly", date(2026, 3, 1, 0, 0), date(2026, 3, 8, 0, 0)},
+		{"@daily", "@daily", date(2026, 3, 1, 0, 0), date(2026, 3, 2, 0, 0)},
+		{"@midnight", "@midnight", date(2026, 3, 1, 23, 59), date(2026, 3, 2, 0, 0)},
+		{"@hourly", "@hourly", date(2026, 3, 1, 10, 30), date(2026, 3, 1, 11, 0)},
+		{"surrounding spaces", " @hourly ", date(2026, 3, 1, 10, 30), date(2026, 3, 1, 11, 0)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := Parse(tt.spec)
+			if err != nil {
+				t.Fatalf("Parse(%q) error = %v", tt.spec, err)
+			}
+			if got := schedule.Next(tt.from); !got.Equal(tt.want) {
+				t.Errorf("Parse(%q).Next(%v) = %v, want %v", tt.spec, tt.from, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		spec string
+	}{
+		{"empty", ""},
+		{"too few fields", "* * * *"},
+		{"too many fields", "* * * * * *"},
+		{"unknown macro", "@every 5m"},
+		{"minute out of range", "60 * * * *"},
+		{"hour out of range", "* 24 * * *"},
+		{"day of month zero", "* * 0 * *"},
+		{"month out of range", "* * * 13 *"},
+		{"day of week out of range", "* * * * 8"},
+		{"reversed range", "5-1 * * * *"},
+		{"zero step", "*/0 * * * *"},
+		{"negative step", "*/-1 * * * *"},
+		{"not a number", "a * * * *"},
+		{"empty list item", "1,,2 * * * *"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Parse(tt.spec); err == nil {
+				t.Errorf("Parse(%q) error = nil, want an error", tt.spec)
+			}
+		})
+	}
+}
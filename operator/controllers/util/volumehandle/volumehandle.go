@@ -0,0 +1,77 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package volumehandle splits and joins the volume and snapshot handles of
+// the driver. The driver escapes '%' as "%25" and ';' as "%3B" in every
+// member of a handle, see the scalehandle package of the driver.
+//
+// TODO: the driver version pinned in go.mod predates the scalehandle
+// package. Bump the pin to a driver release containing
+// csiplugin/pkg/scalehandle and replace this package by its DecodeVolume,
+// DecodeSnapshot and Encode. Until then volumehandle_test.go keeps both
+// codecs in line with the fixtures of the scalehandle tests.
+package volumehandle
+
+import "strings"
+
+const separator = ";"
+
+var escaper = strings.NewReplacer("%", "%25", ";", "%3B")
+
+// Split returns the unescaped members of a handle, at most n. Releases of the
+// driver without escaping wrote a path containing ';' unchanged, the path is
+// the last member so everything after the first n-1 members is kept in it.
+func Split(handle string, n int) []string {
+	members := strings.SplitN(handle, separator, n)
+	for i := range members {
+		members[i] = unescape(members[i])
+	}
+	return members
+}
+
+// Join escapes members and returns the handle.
+func Join(members ...string) string {
+	escaped := make([]string, len(members))
+	for i, member := range members {
+		escaped[i] = escaper.Replace(member)
+	}
+	return strings.Join(escaped, separator)
+}
+
+// unescape reverses the escaping, a '%' which does not start "%25" or "%3B"
+// is kept as written by releases without escaping.
+func unescape(member string) string {
+	if !strings.Contains(member, "%") {
+		return member
+	}
+	var b strings.Builder
+	for i := 0; i < len(member); i++ {
+		if member[i] == '%' && i+2 < len(member) {
+			switch strings.ToUpper(member[i+1 : i+3]) {
+			case "25":
+				b.WriteByte('%')
+				i += 2
+				continue
+			case "3B":
+				b.WriteByte(';')
+				i += 2
+				continue
+			}
+		}
+		b.WriteByte(member[i])
+	}
+	return b.String()
+}
@@ -0,0 +1,95 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package volumehandle
+
+import (
+	"reflect"
+	"testing"
+)
+
+// The version 2 volume handles of the fixtures in
+// driver/csiplugin/pkg/scalehandle/scalehandle_test.go, split into
+// storageClassType, volumeType, clusterId, fsUUID, consistencyGroup,
+// filesetName and path. Keep both in line until the scalehandle package is
+// used instead.
+var volumeHandles = []struct {
+	handle  string
+	members []string
+}{
+	{"0;0;7118073361626808055;09762E35:5D26932A;;;/ibm/gpfs0/lw/pvc-4",
+		[]string{"0", "0", "7118073361626808055", "09762E35:5D26932A", "", "", "/ibm/gpfs0/lw/pvc-4"}},
+	{"0;2;7118073361626808055;09762E35:5D26932A;;pvc-5;/ibm/gpfs0/pvc-5/pvc-5-data",
+		[]string{"0", "2", "7118073361626808055", "09762E35:5D26932A", "", "pvc-5", "/ibm/gpfs0/pvc-5/pvc-5-data"}},
+	{"1;1;7118073361626808055;09762E35:5D26932A;cluster-ns;pvc-6;/ibm/gpfs0/cluster-ns/pvc-6",
+		[]string{"1", "1", "7118073361626808055", "09762E35:5D26932A", "cluster-ns", "pvc-6", "/ibm/gpfs0/cluster-ns/pvc-6"}},
+	{"0;4;7118073361626808055;09762E35:5D26932A;pvc-7:snap-1;pvc-8;/ibm/gpfs0/pvc-8/pvc-8-data",
+		[]string{"0", "4", "7118073361626808055", "09762E35:5D26932A", "pvc-7:snap-1", "pvc-8", "/ibm/gpfs0/pvc-8/pvc-8-data"}},
+	{"0;2;7118073361626808055;09762E35:5D26932A;;static;/ibm/gpfs0/100%25 used",
+		[]string{"0", "2", "7118073361626808055", "09762E35:5D26932A", "", "static", "/ibm/gpfs0/100% used"}},
+}
+
+// The version 2 snapshot handles of the scalehandle fixtures, split into
+// storageClassType, volumeType, clusterId, fsUUID, consistencyGroup,
+// filesetName, snapshotName, metaSnapshotName and path.
+var snapshotHandles = []struct {
+	handle  string
+	members []string
+}{
+	{"1;1;7118073361626808055;09762E35:5D26932A;cluster-ns;pvc-2;snap-2;snapshot-2",
+		[]string{"1", "1", "7118073361626808055", "09762E35:5D26932A", "cluster-ns", "pvc-2", "snap-2", "snapshot-2"}},
+	{"0;2;7118073361626808055;09762E35:5D26932A;;pvc-3;snap-3;;pvc-3-data",
+		[]string{"0", "2", "7118073361626808055", "09762E35:5D26932A", "", "pvc-3", "snap-3", "", "pvc-3-data"}},
+	{"0;2;7118073361626808055;09762E35:5D26932A;;static;snap-4;;/",
+		[]string{"0", "2", "7118073361626808055", "09762E35:5D26932A", "", "static", "snap-4", "", "/"}},
+}
+
+func TestVolumeHandles(t *testing.T) {
+	for _, tc := range volumeHandles {
+		if got := Split(tc.handle, 7); !reflect.DeepEqual(got, tc.members) {
+			t.Errorf("Split(%q) = %q, want %q", tc.handle, got, tc.members)
+		}
+		if got := Join(tc.members...); got != tc.handle {
+			t.Errorf("Join(%q) = %q, want %q", tc.members, got, tc.handle)
+		}
+	}
+}
+
+func TestSnapshotHandles(t *testing.T) {
+	for _, tc := range snapshotHandles {
+		if got := Split(tc.handle, 9); !reflect.DeepEqual(got, tc.members) {
+			t.Errorf("Split(%q) = %q, want %q", tc.handle, got, tc.members)
+		}
+		if got := Join(tc.members...); got != tc.handle {
+			t.Errorf("Join(%q) = %q, want %q", tc.members, got, tc.handle)
+		}
+	}
+}
+
+func TestUnescapedPath(t *testing.T) {
+	// releases without escaping wrote a path containing ';' unchanged
+	members := Split("0;2;1;uuid;;pvc-1;/ibm/gpfs0/a;b", 7)
+	if members[6] != "/ibm/gpfs0/a;b" {
+		t.Errorf("path = %q, want %q", members[6], "/ibm/gpfs0/a;b")
+	}
+	if got := Join(members...); got != "0;2;1;uuid;;pvc-1;/ibm/gpfs0/a%3Bb" {
+		t.Errorf("Join(%q) = %q", members, got)
+	}
+	// a '%' not starting an escape is kept as written
+	if got := Split("0;2;1;uuid;;pvc-1;/ibm/gpfs0/100%", 7)[6]; got != "/ibm/gpfs0/100%" {
+		t.Errorf("path = %q, want %q", got, "/ibm/gpfs0/100%")
+	}
+}
@@ -166,6 +166,22 @@ func main() {
 		setupLog.Error(err, "unable to create controller", "controller", controllers.CSIScaleOperatorControllerName)
 		os.Exit(1)
 	}
+	if err = (&controllers.CSIScaleSnapshotScheduleReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor(controllers.CSIScaleSnapshotScheduleControllerName),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", controllers.CSIScaleSnapshotScheduleControllerName)
+		os.Exit(1)
+	}
+	if err = (&controllers.CSIScaleVolumeMigrationReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor(controllers.CSIScaleVolumeMigrationControllerName),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", controllers.CSIScaleVolumeMigrationControllerName)
+		os.Exit(1)
+	}
 	//+kubebuilder:scaffold:builder
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {